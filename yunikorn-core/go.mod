@@ -32,8 +32,12 @@ require (
 	google.golang.org/appengine v1.6.2 // indirect
 	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55 // indirect
 	google.golang.org/grpc v1.23.0
+	gopkg.in/asn1-ber.v1 v1.0.0-20181015200546-f715ec2f112d // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
+	gopkg.in/ldap.v3 v3.0.3
 	gopkg.in/yaml.v2 v2.2.2
 	gotest.tools v0.0.0-20181223230014-1083505acf35
 	honnef.co/go/tools v0.0.1-2019.2.2 // indirect
 )
+
+replace github.com/cloudera/yunikorn-scheduler-interface => ../yunikorn-scheduler-interface
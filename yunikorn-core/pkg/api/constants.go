@@ -34,4 +34,6 @@ const (
     APPLICATION_ID  = "si.io/application-id"
     CONTAINER_IMAGE = "si.io/container-image"
     CONTAINER_PORTS = "si.io/container-ports"
+    // creation time of a recovered allocation in seconds since the epoch
+    CREATION_TIME   = "si.io/creation-time"
 )
@@ -17,10 +17,15 @@ limitations under the License.
 package cache
 
 import (
+    "github.com/cloudera/yunikorn-core/pkg/api"
     "github.com/cloudera/yunikorn-core/pkg/common"
     "github.com/cloudera/yunikorn-core/pkg/common/commonevents"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/log"
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "go.uber.org/zap"
+    "strconv"
+    "time"
 )
 
 /* Related to Allocation */
@@ -31,6 +36,7 @@ type AllocationInfo struct {
     // Other information
     ApplicationId     string
     AllocatedResource *resources.Resource
+    CreateTime        time.Time
 }
 
 func NewAllocationInfo(uuid string, alloc *commonevents.AllocationProposal) *AllocationInfo {
@@ -48,7 +54,25 @@ func NewAllocationInfo(uuid string, alloc *commonevents.AllocationProposal) *All
         },
         ApplicationId:     alloc.ApplicationId,
         AllocatedResource: alloc.AllocatedResource,
+        CreateTime:        time.Now(),
     }
 
     return allocation
 }
+
+// Get the creation time of an allocation that is recovered from a node.
+// The RM passes the time the allocation was originally created as a tag. If the tag is not set, or cannot be parsed,
+// the current time is used.
+func getRecoveredCreateTime(tags map[string]string) time.Time {
+    value, ok := tags[api.CREATION_TIME]
+    if !ok {
+        return time.Now()
+    }
+    seconds, err := strconv.ParseInt(value, 10, 64)
+    if err != nil || seconds <= 0 {
+        log.Logger().Warn("invalid creation time on recovered allocation, using current time",
+            zap.String("creationTime", value))
+        return time.Now()
+    }
+    return time.Unix(seconds, 0)
+}
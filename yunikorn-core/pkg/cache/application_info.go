@@ -17,9 +17,14 @@ limitations under the License.
 package cache
 
 import (
+    "fmt"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
     "github.com/cloudera/yunikorn-core/pkg/common/security"
+    "github.com/cloudera/yunikorn-core/pkg/events"
+    "github.com/cloudera/yunikorn-core/pkg/log"
+    "github.com/cloudera/yunikorn-core/pkg/metrics"
     "github.com/looplab/fsm"
+    "go.uber.org/zap"
     "strings"
     "sync"
     "time"
@@ -39,22 +44,70 @@ type ApplicationInfo struct {
     allocatedResource *resources.Resource        // total allocated resources  总的分配资源
     allocations       map[string]*AllocationInfo // list of all allocations
     stateMachine      *fsm.FSM                   // application state machine
+    stateTime         time.Time                  // time of the last state transition
+    stateLog          []*StateLogEntry           // all state transitions of the application
+    pendingAsks       bool                       // the application has outstanding asks in the scheduler
+    placement         *PlacementDecision         // outcome of the placement rules, nil if no rules were run
     lock sync.RWMutex
 }
 
+// The maximum number of state transitions kept in the state log of an application
+const maxStateLogEntries = 20
+
+// A state transition of the application
+type StateLogEntry struct {
+    Time             time.Time
+    ApplicationState string
+}
+
+// The results of a placement rule in the placement trace
+const (
+    PlacementPlaced       = "placed"
+    PlacementNoMatch      = "no match"
+    PlacementDenied       = "denied"
+    PlacementNotAccepting = "not accepting"
+    PlacementFailed       = "failed"
+)
+
+// The outcome of running the placement rules for an application.
+// The trace contains an entry for each rule that was executed in order, the queue and rule are empty if the
+// application was not placed.
+type PlacementDecision struct {
+    Queue string
+    Rule  string
+    Trace []PlacementRuleTrace
+}
+
+// The result of one placement rule:
+// - the queue the rule returned, empty if the rule did not match
+// - the result of the rule, one of the placement results
+// - a message explaining the result
+// - the queue does not exist and is created (or would be created) for the application
+type PlacementRuleTrace struct {
+    Rule     string
+    Queue    string
+    Result   string
+    Message  string
+    NewQueue bool
+}
+
 // Create a new application
 func NewApplicationInfo(appId, partition, queueName string, ugi security.UserGroup, tags map[string]string) *ApplicationInfo {
-    return &ApplicationInfo{
+    now := time.Now()
+    ai := &ApplicationInfo{
         ApplicationId: appId,
         Partition: partition,
         QueueName: queueName,
-        SubmissionTime: time.Now().UnixNano(),
+        SubmissionTime: now.UnixNano(),
         tags: tags,
         user: ugi,
         allocatedResource: resources.NewResource(),
         allocations: make(map[string]*AllocationInfo),
-        stateMachine: newAppState(),
+        stateTime: now,
+        stateLog: []*StateLogEntry{{Time: now, ApplicationState: New.String()}},
     }
+    ai.stateMachine = newAppState(ai.onStateChange)
+    return ai
 }
 
 // Return the current allocations for the application.
@@ -88,6 +141,131 @@ func (ai *ApplicationInfo) HandleApplicationEvent(event ApplicationEvent) error
     return err
 }
 
+// Record the state transition and update the metrics, called by the state machine after each transition.
+func (ai *ApplicationInfo) onStateChange(event *fsm.Event) {
+    ai.lock.Lock()
+    ai.stateTime = time.Now()
+    ai.stateLog = append(ai.stateLog, &StateLogEntry{Time: ai.stateTime, ApplicationState: event.Dst})
+    // only keep the latest transitions: an application can move between running and waiting without limit
+    if len(ai.stateLog) > maxStateLogEntries {
+        ai.stateLog = ai.stateLog[len(ai.stateLog)-maxStateLogEntries:]
+    }
+    queueName := ai.QueueName
+    ai.lock.Unlock()
+
+    events.Record(&events.Event{
+        ObjectType:    events.ObjectApplication,
+        ObjectId:      ai.ApplicationId,
+        Partition:     ai.Partition,
+        ApplicationId: ai.ApplicationId,
+        QueueName:     queueName,
+        Reason:        events.ApplicationStateChanged,
+        Message:       fmt.Sprintf("%s to %s on %s", event.Src, event.Dst, event.Event),
+    })
+
+    m := metrics.GetInstance()
+    m.DecApplicationsInState(event.Src)
+    m.IncApplicationsInState(event.Dst)
+    if event.Src == Running.String() {
+        m.DecTotalApplicationsRunning()
+    }
+    switch event.Dst {
+    case Running.String():
+        m.IncTotalApplicationsRunning()
+    case Completed.String():
+        m.IncTotalApplicationsCompleted()
+    }
+}
+
+// Return the time of the last state transition.
+func (ai *ApplicationInfo) GetStateTime() time.Time {
+    ai.lock.RLock()
+    defer ai.lock.RUnlock()
+
+    return ai.stateTime
+}
+
+// Return a copy of all state transitions of the application, oldest first.
+func (ai *ApplicationInfo) GetStateLog() []StateLogEntry {
+    ai.lock.RLock()
+    defer ai.lock.RUnlock()
+
+    stateLog := make([]StateLogEntry, len(ai.stateLog))
+    for i, entry := range ai.stateLog {
+        stateLog[i] = *entry
+    }
+    return stateLog
+}
+
+// Set the outcome of the placement rules for the application.
+func (ai *ApplicationInfo) SetPlacementDecision(decision *PlacementDecision) {
+    ai.lock.Lock()
+    defer ai.lock.Unlock()
+
+    ai.placement = decision
+}
+
+// Return the outcome of the placement rules, nil if the application was not placed by the rules.
+func (ai *ApplicationInfo) GetPlacementDecision() *PlacementDecision {
+    ai.lock.RLock()
+    defer ai.lock.RUnlock()
+
+    return ai.placement
+}
+
+// Set the pending asks flag for the application without changing the state.
+// Used when the pending asks change because of an allocation that is in flight: the state is updated when the
+// allocation is confirmed or rejected.
+func (ai *ApplicationInfo) SetPendingAsks(pending bool) {
+    ai.lock.Lock()
+    defer ai.lock.Unlock()
+
+    ai.pendingAsks = pending
+}
+
+// Set the pending asks flag for the application and update the state based on the change.
+// Used when asks are added to or removed from the application.
+func (ai *ApplicationInfo) UpdatePendingAsks(pending bool) {
+    ai.SetPendingAsks(pending)
+    ai.updateState()
+}
+
+// Update the state of the application based on the allocations and pending asks:
+// - the first allocation starts an accepted application
+// - a second allocation or a new allocation after waiting or completing means the application runs
+// - no allocations but pending asks means the application waits
+// - no allocations and no pending asks means the application is completing
+// Must not be called while holding the application lock, the state machine callback locks the application.
+func (ai *ApplicationInfo) updateState() {
+    ai.lock.RLock()
+    allocations := len(ai.allocations)
+    pending := ai.pendingAsks
+    ai.lock.RUnlock()
+
+    var event ApplicationEvent
+    switch state := ai.GetApplicationState(); {
+    case allocations > 0 && state == Accepted.String():
+        event = StartApplication
+    case allocations > 1 && state == Starting.String(),
+        allocations > 0 && (state == Waiting.String() || state == Completing.String()):
+        event = RunApplication
+    case allocations == 0 && pending &&
+        (state == Starting.String() || state == Running.String() || state == Completing.String()):
+        event = WaitApplication
+    case allocations == 0 && !pending &&
+        (state == Accepted.String() || state == Starting.String() || state == Running.String() || state == Waiting.String()):
+        event = FinishApplication
+    default:
+        return
+    }
+    if err := ai.HandleApplicationEvent(event); err != nil {
+        log.Logger().Warn("application state update failed",
+            zap.String("appId", ai.ApplicationId),
+            zap.String("event", event.String()),
+            zap.Error(err))
+    }
+}
+
 // Return the total allocated resources for the application.
 func (ai *ApplicationInfo) GetAllocatedResource() *resources.Resource {
     ai.lock.RLock()
@@ -106,48 +284,65 @@ func (ai *ApplicationInfo) SetQueue(leaf *QueueInfo) {
     ai.QueueName = leaf.GetQueuePath()
 }
 
-// Add a new allocation to the application
-func (ai *ApplicationInfo) addAllocation(info *AllocationInfo) {
+// Move the application to a different leaf queue. The queue name of all allocations is updated to the new queue.
+// The resources of the queues are not changed, see PartitionInfo.MoveApplication.
+func (ai *ApplicationInfo) moveQueue(leaf *QueueInfo) {
     ai.lock.Lock()
     defer ai.lock.Unlock()
 
+    ai.leafQueue = leaf
+    ai.QueueName = leaf.GetQueuePath()
+    for _, alloc := range ai.allocations {
+        alloc.AllocationProto.QueueName = ai.QueueName
+    }
+}
+
+// Add a new allocation to the application and update the application state.
+func (ai *ApplicationInfo) addAllocation(info *AllocationInfo) {
+    ai.lock.Lock()
     ai.allocations[info.AllocationProto.Uuid] = info
     ai.allocatedResource = resources.Add(ai.allocatedResource, info.AllocatedResource)
+    ai.lock.Unlock()
+
+    ai.updateState()
 }
 
-// Remove a specific allocation from the application.
+// Remove a specific allocation from the application and update the application state.
 // Return the allocation that was removed.
 func (ai *ApplicationInfo) removeAllocation(uuid string) *AllocationInfo {
     ai.lock.Lock()
-    defer ai.lock.Unlock()
-
     alloc := ai.allocations[uuid]
 
     if alloc != nil {
         // When app has the allocation, update map, and update allocated resource of the app
         ai.allocatedResource = resources.Sub(ai.allocatedResource, alloc.AllocatedResource)
         delete(ai.allocations, uuid)
-        return alloc
     }
+    ai.lock.Unlock()
 
-    return nil
+    if alloc != nil {
+        ai.updateState()
+    }
+    return alloc
 }
 
-// Remove all allocations from the application.
+// Remove all allocations from the application and update the application state.
 // All allocations that have been removed are returned.
 func (ai *ApplicationInfo) removeAllAllocations() []*AllocationInfo {
     allocationsToRelease := make([]*AllocationInfo, 0)
 
     ai.lock.Lock()
-    defer ai.lock.Unlock()
-
     for _, alloc := range ai.allocations {
         allocationsToRelease = append(allocationsToRelease, alloc)
     }
     // cleanup allocated resource for app
     ai.allocatedResource = resources.NewResource()
     ai.allocations = make(map[string]*AllocationInfo)
+    ai.lock.Unlock()
 
+    if len(allocationsToRelease) > 0 {
+        ai.updateState()
+    }
     return allocationsToRelease
 }
 
@@ -181,7 +181,7 @@ func TestCompletedTransition(t *testing.T) {
 	assert.Assert(t, err == nil)
 	assert.Equal(t, appInfo.GetApplicationState(), Running.String())
 
-	// completed only from run
+	// running to completed
 	err = appInfo.HandleApplicationEvent(CompleteApplication)
 	assert.Assert(t, err == nil)
 	assert.Equal(t, appInfo.GetApplicationState(), Completed.String())
@@ -191,7 +191,7 @@ func TestCompletedTransition(t *testing.T) {
 	assert.Assert(t, err != nil)
 	assert.Equal(t, appInfo.GetApplicationState(), Completed.String())
 
-	// completed fails from all but running
+	// completed fails from new
 	appInfo2 := newApplicationInfo("app-00002", "default", "root.a")
 	assert.Equal(t, appInfo2.GetApplicationState(), New.String())
 	err = appInfo.HandleApplicationEvent(CompleteApplication)
@@ -215,3 +215,118 @@ func TestKilledTransition(t *testing.T) {
 	assert.Assert(t, err == nil)
 	assert.Equal(t, appInfo.GetApplicationState(), Killed.String())
 }
+
+func TestStartingWaitingCompletingTransition(t *testing.T) {
+	appInfo := newApplicationInfo("app-00001", "default", "root.a")
+	err := appInfo.HandleApplicationEvent(AcceptApplication)
+	assert.Assert(t, err == nil)
+
+	// starting only from accepted
+	err = appInfo.HandleApplicationEvent(StartApplication)
+	assert.Assert(t, err == nil)
+	assert.Equal(t, appInfo.GetApplicationState(), Starting.String())
+	err = appInfo.HandleApplicationEvent(StartApplication)
+	assert.Assert(t, err != nil)
+
+	// waiting and running switch back and forth
+	err = appInfo.HandleApplicationEvent(WaitApplication)
+	assert.Assert(t, err == nil)
+	assert.Equal(t, appInfo.GetApplicationState(), Waiting.String())
+	err = appInfo.HandleApplicationEvent(RunApplication)
+	assert.Assert(t, err == nil)
+	assert.Equal(t, appInfo.GetApplicationState(), Running.String())
+
+	// completing can be left for running or completed
+	err = appInfo.HandleApplicationEvent(FinishApplication)
+	assert.Assert(t, err == nil)
+	assert.Equal(t, appInfo.GetApplicationState(), Completing.String())
+	err = appInfo.HandleApplicationEvent(RunApplication)
+	assert.Assert(t, err == nil)
+	err = appInfo.HandleApplicationEvent(FinishApplication)
+	assert.Assert(t, err == nil)
+	err = appInfo.HandleApplicationEvent(CompleteApplication)
+	assert.Assert(t, err == nil)
+	assert.Equal(t, appInfo.GetApplicationState(), Completed.String())
+
+	// no way back from completed
+	err = appInfo.HandleApplicationEvent(WaitApplication)
+	assert.Assert(t, err != nil)
+	err = appInfo.HandleApplicationEvent(FinishApplication)
+	assert.Assert(t, err != nil)
+
+	// waiting from new fails
+	appInfo2 := newApplicationInfo("app-00002", "default", "root.a")
+	err = appInfo2.HandleApplicationEvent(WaitApplication)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, appInfo2.GetApplicationState(), New.String())
+}
+
+func TestStateLogLimit(t *testing.T) {
+	appInfo := newApplicationInfo("app-00001", "default", "root.a")
+	err := appInfo.HandleApplicationEvent(AcceptApplication)
+	assert.Assert(t, err == nil)
+	err = appInfo.HandleApplicationEvent(StartApplication)
+	assert.Assert(t, err == nil)
+
+	// switching back and forth only keeps the latest transitions
+	for i := 0; i < maxStateLogEntries; i++ {
+		err = appInfo.HandleApplicationEvent(WaitApplication)
+		assert.Assert(t, err == nil)
+		err = appInfo.HandleApplicationEvent(RunApplication)
+		assert.Assert(t, err == nil)
+	}
+	stateLog := appInfo.GetStateLog()
+	assert.Equal(t, len(stateLog), maxStateLogEntries)
+	assert.Equal(t, stateLog[len(stateLog)-1].ApplicationState, Running.String())
+}
+
+func TestAutomaticStateUpdates(t *testing.T) {
+	appInfo := newApplicationInfo("app-00001", "default", "root.a")
+	res, _ := resources.NewResourceFromConf(map[string]string{"memory": "100"})
+
+	// allocations for an application that is not accepted do not change the state
+	appInfo.addAllocation(CreateMockAllocationInfo("app-00001", res, "uuid-0", "root.a", "node-1"))
+	assert.Equal(t, appInfo.GetApplicationState(), New.String())
+	appInfo.removeAllocation("uuid-0")
+	err := appInfo.HandleApplicationEvent(AcceptApplication)
+	assert.Assert(t, err == nil)
+
+	// an ask on its own does not start the application
+	appInfo.UpdatePendingAsks(true)
+	assert.Equal(t, appInfo.GetApplicationState(), Accepted.String())
+
+	// first allocation starts, second runs
+	appInfo.SetPendingAsks(true)
+	appInfo.addAllocation(CreateMockAllocationInfo("app-00001", res, "uuid-1", "root.a", "node-1"))
+	assert.Equal(t, appInfo.GetApplicationState(), Starting.String())
+	appInfo.addAllocation(CreateMockAllocationInfo("app-00001", res, "uuid-2", "root.a", "node-1"))
+	assert.Equal(t, appInfo.GetApplicationState(), Running.String())
+
+	// no allocations left with pending asks: waiting, a new allocation runs again
+	appInfo.removeAllAllocations()
+	assert.Equal(t, appInfo.GetApplicationState(), Waiting.String())
+	appInfo.addAllocation(CreateMockAllocationInfo("app-00001", res, "uuid-3", "root.a", "node-1"))
+	assert.Equal(t, appInfo.GetApplicationState(), Running.String())
+
+	// no allocations and no asks: completing
+	appInfo.SetPendingAsks(false)
+	assert.Equal(t, appInfo.GetApplicationState(), Running.String())
+	appInfo.removeAllocation("uuid-3")
+	assert.Equal(t, appInfo.GetApplicationState(), Completing.String())
+	// a new ask moves the application back to waiting
+	appInfo.UpdatePendingAsks(true)
+	assert.Equal(t, appInfo.GetApplicationState(), Waiting.String())
+
+	// all transitions are logged in order
+	expected := []string{New.String(), Accepted.String(), Starting.String(), Running.String(), Waiting.String(),
+		Running.String(), Completing.String(), Waiting.String()}
+	stateLog := appInfo.GetStateLog()
+	assert.Equal(t, len(stateLog), len(expected))
+	for i, entry := range stateLog {
+		assert.Equal(t, entry.ApplicationState, expected[i])
+		if i > 0 {
+			assert.Assert(t, !entry.Time.Before(stateLog[i-1].Time), "state log is not in time order")
+		}
+	}
+	assert.Equal(t, appInfo.GetStateTime(), stateLog[len(stateLog)-1].Time)
+}
@@ -33,10 +33,14 @@ const (
 	RunApplication
 	CompleteApplication
 	KillApplication
+	StartApplication
+	WaitApplication
+	FinishApplication
 )
 
 func (ae ApplicationEvent) String() string {
-	return [...]string{"AcceptApplication", "RejectApplication", "RunApplication", "CompleteApplication", "KillApplication"}[ae]
+	return [...]string{"AcceptApplication", "RejectApplication", "RunApplication", "CompleteApplication", "KillApplication",
+		"StartApplication", "WaitApplication", "FinishApplication"}[ae]
 }
 
 // ----------------------------------
@@ -44,6 +48,11 @@ func (ae ApplicationEvent) String() string {
 // ----------------------------------
 type ApplicationState int
 
+// The application lifecycle as driven by the allocations and asks of the application:
+// - Starting: the first allocation was made for the accepted application
+// - Running: the application has more than one allocation or has been starting for a while
+// - Waiting: the application has pending asks but no allocations
+// - Completing: the application has no allocations and no pending asks, it is completed after a timeout
 const (
 	New ApplicationState = iota
 	Accepted
@@ -51,13 +60,17 @@ const (
 	Running
 	Completed
 	Killed
+	Starting
+	Waiting
+	Completing
 )
 
 func (as ApplicationState) String() string {
-	return [...]string{"New", "Accepted", "Rejected", "Running", "Completed", "Killed"}[as]
+	return [...]string{"New", "Accepted", "Rejected", "Running", "Completed", "Killed", "Starting", "Waiting", "Completing"}[as]
 }
 
-func newAppState() *fsm.FSM {
+// The enter state callback is called after each successful transition with the event and the application.
+func newAppState(enterState func(event *fsm.Event)) *fsm.FSM {
 	return fsm.NewFSM(
 		New.String(), fsm.Events{
 			{
@@ -68,17 +81,30 @@ func newAppState() *fsm.FSM {
 				Name: RejectApplication.String(),
 				Src: []string{New.String()},
 				Dst: Rejected.String(),
+			},{
+				Name: StartApplication.String(),
+				Src: []string{Accepted.String()},
+				Dst: Starting.String(),
 			},{
 				Name: RunApplication.String(),
-				Src: []string{Accepted.String(), Running.String()},
+				Src: []string{Accepted.String(), Starting.String(), Running.String(), Waiting.String(), Completing.String()},
 				Dst: Running.String(),
+			},{
+				Name: WaitApplication.String(),
+				Src: []string{Starting.String(), Running.String(), Waiting.String(), Completing.String()},
+				Dst: Waiting.String(),
+			},{
+				Name: FinishApplication.String(),
+				Src: []string{Accepted.String(), Starting.String(), Running.String(), Waiting.String(), Completing.String()},
+				Dst: Completing.String(),
 			},{
 				Name: CompleteApplication.String(),
-				Src: []string{Running.String()},
+				Src: []string{Accepted.String(), Starting.String(), Running.String(), Waiting.String(), Completing.String()},
 				Dst: Completed.String(),
 			},{
 				Name: KillApplication.String(),
-				Src: []string{New.String(), Accepted.String(), Running.String(), Killed.String()},
+				Src: []string{New.String(), Accepted.String(), Starting.String(), Running.String(), Waiting.String(),
+					Completing.String(), Killed.String()},
 				Dst: Killed.String(),
 			},
 		},
@@ -89,6 +115,9 @@ func newAppState() *fsm.FSM {
 					zap.String("source", event.Src),
 					zap.String("destination", event.Dst),
 					zap.String("event", event.Event))
+				if enterState != nil {
+					enterState(event)
+				}
 			},
 		},
 	)
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/common/security"
+)
+
+// The template applied to the unmanaged child queues of a parent queue.
+// The template is created from the configuration and should be treated as immutable: a configuration update
+// replaces the template.
+type childTemplate struct {
+    maxResource        *resources.RelativeResource
+    guaranteedResource *resources.RelativeResource
+    properties         map[string]string
+    submitACL          security.ACL
+    adminACL           security.ACL
+    maxApplications    uint64
+}
+
+// Create the template from the configuration. Returns nil if the configuration has no template set.
+// The configuration is validated before we call this: we should not see any errors.
+func newChildTemplate(conf configs.ChildTemplate) (*childTemplate, error) {
+    if !conf.IsSet() {
+        return nil, nil
+    }
+    template := &childTemplate{
+        properties:      conf.Properties,
+        maxApplications: conf.MaxApplications,
+    }
+    var err error
+    if template.submitACL, err = security.NewACL(conf.SubmitACL); err != nil {
+        return nil, err
+    }
+    if template.adminACL, err = security.NewACL(conf.AdminACL); err != nil {
+        return nil, err
+    }
+    maxResource, err := resources.NewRelativeResourceFromConf(conf.Resources.Max)
+    if err != nil {
+        return nil, err
+    }
+    if !maxResource.IsEmpty() {
+        template.maxResource = maxResource
+    }
+    guaranteedResource, err := resources.NewRelativeResourceFromConf(conf.Resources.Guaranteed)
+    if err != nil {
+        return nil, err
+    }
+    if !guaranteedResource.IsEmpty() {
+        template.guaranteedResource = guaranteedResource
+    }
+    return template, nil
+}
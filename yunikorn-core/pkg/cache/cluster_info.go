@@ -22,17 +22,23 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/common"
     "github.com/cloudera/yunikorn-core/pkg/common/commonevents"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/events"
     "github.com/cloudera/yunikorn-core/pkg/handler"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "github.com/cloudera/yunikorn-core/pkg/metrics"
+    "github.com/cloudera/yunikorn-core/pkg/plugins"
     "github.com/cloudera/yunikorn-core/pkg/rmproxy/rmevent"
     "github.com/cloudera/yunikorn-core/pkg/scheduler/schedulerevent"
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
     "go.uber.org/zap"
     "reflect"
     "sync"
+    "time"
 )
 
+// interval at which the application state timeouts are checked
+const appStateCheckInterval = time.Second
+
 //?????
 type ClusterInfo struct {
     partitions  map[string]*PartitionInfo     //里面包含app队列
@@ -49,6 +55,10 @@ type ClusterInfo struct {
 
     // Reference to scheduler metrics
     metrics metrics.CoreSchedulerMetrics
+
+    // Closed to stop the background checks, only once
+    stopChan chan struct{}
+    stopOnce sync.Once
 }
 
 func NewClusterInfo() (*ClusterInfo, metrics.CoreSchedulerMetrics) {
@@ -56,6 +66,7 @@ func NewClusterInfo() (*ClusterInfo, metrics.CoreSchedulerMetrics) {
         partitions:             make(map[string]*PartitionInfo),
         pendingRmEvents:        make(chan interface{}, 1024*1024),
         pendingSchedulerEvents: make(chan interface{}, 1024*1024),
+        stopChan:               make(chan struct{}),
     }
 
     clusterInfo.metrics = metrics.GetInstance()
@@ -72,6 +83,71 @@ func (m *ClusterInfo) StartService(handlers handler.EventHandlers) {
     go m.handleRMEvents()
     //调度事件
     go m.handleSchedulerEvents()
+    // application state timeouts
+    go m.checkApplicationStates()
+}
+
+// Stop the background checks of the cluster. Stopping a stopped service is a no-op.
+func (m *ClusterInfo) StopService() {
+    m.stopOnce.Do(func() {
+        close(m.stopChan)
+    })
+}
+
+// Periodically check the application state timeouts in all partitions until the service is stopped.
+// Applications that have been completed are removed via the scheduler as if the RM removed them.
+func (m *ClusterInfo) checkApplicationStates() {
+    ticker := time.NewTicker(appStateCheckInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-m.stopChan:
+            return
+        case now := <-ticker.C:
+            m.processApplicationTimeouts(now)
+        }
+    }
+}
+
+// Process the application state timeouts for all partitions.
+// The RM that owns a removed application is notified of the removal.
+// Lock free call, all updates occur on the underlying partition and application which are locked, or via events.
+func (m *ClusterInfo) processApplicationTimeouts(now time.Time) {
+    removedApps := make([]*si.RemoveApplicationRequest, 0)
+    rmRemovedApps := make(map[string][]*si.RemovedApplication)
+    for _, name := range m.ListPartitions() {
+        partitionInfo := m.GetPartition(name)
+        if partitionInfo == nil {
+            continue
+        }
+        for _, app := range partitionInfo.checkApplicationTimeouts(now) {
+            log.Logger().Info("removing completed application",
+                zap.String("appId", app.ApplicationId),
+                zap.String("partitionName", app.Partition))
+            removedApps = append(removedApps, &si.RemoveApplicationRequest{
+                ApplicationId: app.ApplicationId,
+                PartitionName: app.Partition,
+            })
+            rmId := common.GetRMIdFromPartitionName(app.Partition)
+            rmRemovedApps[rmId] = append(rmRemovedApps[rmId], &si.RemovedApplication{
+                ApplicationId: app.ApplicationId,
+                Reason:        "application completed",
+            })
+        }
+    }
+    if len(removedApps) > 0 {
+        m.EventHandlers.SchedulerEventHandler.HandleEvent(
+            &schedulerevent.SchedulerApplicationsUpdateEvent{
+                AddedApplications:   make([]interface{}, 0),
+                RemovedApplications: removedApps,
+            })
+    }
+    for rmId, apps := range rmRemovedApps {
+        m.EventHandlers.RMProxyEventHandler.HandleEvent(&rmevent.RMApplicationUpdateEvent{
+            RMId:                rmId,
+            RemovedApplications: apps,
+        })
+    }
 }
 
 func (m *ClusterInfo) handleSchedulerEvents() {
@@ -194,12 +270,12 @@ func (m *ClusterInfo) removePartition(name string) {
     delete(m.partitions, name)
 }
 
-// Process the application update. Add and remove applications from the partitions.
+// Process the application update. Add and remove applications from the partitions, moves are passed on to the scheduler.
 // Lock free call, all updates occur on the underlying partition which is locked, or via events.
 
 //处理shim对application的相关请求
 func (m *ClusterInfo) processApplicationUpdateFromRMUpdate(request *si.UpdateRequest) {
-    if len(request.NewApplications) == 0 && len(request.RemoveApplications) == 0 {
+    if len(request.NewApplications) == 0 && len(request.RemoveApplications) == 0 && len(request.MoveApplications) == 0 {
         return
     }
     addedAppInfosInterface := make([]interface{}, 0)
@@ -211,6 +287,7 @@ func (m *ClusterInfo) processApplicationUpdateFromRMUpdate(request *si.UpdateReq
         if partitionInfo == nil {
             msg := fmt.Sprintf("Failed to add application %s to partition %s, partition doesn't exist", app.ApplicationId, app.PartitionName)
             log.Logger().Info(msg)
+            recordRejectedApplication(app, msg)
             rejectedApps = append(rejectedApps, &si.RejectedApplication{
                 ApplicationId: app.ApplicationId,
                 Reason:        msg,
@@ -221,6 +298,7 @@ func (m *ClusterInfo) processApplicationUpdateFromRMUpdate(request *si.UpdateReq
         ugi, err := partitionInfo.convertUGI(app.Ugi)
         if err != nil {
             m.metrics.IncTotalApplicationsRejected()
+            recordRejectedApplication(app, err.Error())
             rejectedApps = append(rejectedApps, &si.RejectedApplication{
                 ApplicationId: app.ApplicationId,
                 Reason:        err.Error(),
@@ -234,6 +312,7 @@ func (m *ClusterInfo) processApplicationUpdateFromRMUpdate(request *si.UpdateReq
         //添加到partitionInfo中
         if err := partitionInfo.addNewApplication(appInfo, true); err != nil {
             m.metrics.IncTotalApplicationsRejected()
+            recordRejectedApplication(app, err.Error())
             rejectedApps = append(rejectedApps, &si.RejectedApplication{
                 ApplicationId: app.ApplicationId,
                 Reason:        err.Error(),
@@ -255,25 +334,46 @@ func (m *ClusterInfo) processApplicationUpdateFromRMUpdate(request *si.UpdateReq
             })
     }
 
-    // Update metrics with removed applications
-    if len(request.RemoveApplications) > 0 {
-        m.metrics.SubTotalApplicationsRunning(len(request.RemoveApplications))
-        // ToDO: need to improve this once we have state in YuniKorn for apps.
-        m.metrics.AddTotalApplicationsCompleted(len(request.RemoveApplications))
-    }
-
     //以上都是简单的创建一个appinfo来表示这个app，并将其添加到partitioninfo中
     //下面轮到scheduler处理了
     // Send message to Scheduler if we have anything to process (remove and or add)
-    if len(request.RemoveApplications) > 0 || len(addedAppInfosInterface) > 0 {
+    if len(request.RemoveApplications) > 0 || len(request.MoveApplications) > 0 || len(addedAppInfosInterface) > 0 {
         m.EventHandlers.SchedulerEventHandler.HandleEvent(
             &schedulerevent.SchedulerApplicationsUpdateEvent{
                 AddedApplications:   addedAppInfosInterface,
                 RemovedApplications: request.RemoveApplications,
+                MovedApplications:   request.MoveApplications,
             })
     }
 }
 
+// Record the event for an application that is rejected before it reaches the scheduler.
+func recordRejectedApplication(app *si.AddApplicationRequest, reason string) {
+    events.Record(&events.Event{
+        ObjectType:    events.ObjectApplication,
+        ObjectId:      app.ApplicationId,
+        Partition:     app.PartitionName,
+        ApplicationId: app.ApplicationId,
+        QueueName:     app.QueueName,
+        Reason:        events.ApplicationRejected,
+        Message:       reason,
+    })
+}
+
+// Record the event for an allocation proposal that is rejected by the cache.
+func recordRejectedAllocation(proposal *commonevents.AllocationProposal, reason string) {
+    events.Record(&events.Event{
+        ObjectType:    events.ObjectAsk,
+        ObjectId:      proposal.AllocationKey,
+        Partition:     proposal.PartitionName,
+        ApplicationId: proposal.ApplicationId,
+        QueueName:     proposal.QueueName,
+        NodeId:        proposal.NodeId,
+        Reason:        events.AllocationRejected,
+        Message:       reason,
+    })
+}
+
 // Process the allocation updates. Add and remove allocations for the applications.
 // Lock free call, all updates occur on the underlying application which is locked or via events.
 func (m *ClusterInfo) processNewAndReleaseAllocationRequests(request *si.UpdateRequest) {
@@ -300,7 +400,7 @@ func (m *ClusterInfo) processNewAndReleaseAllocationRequests(request *si.UpdateR
 
         // if app info doesn't exist, reject the request
         //app是会先提交给core的
-        appInfo := partitionInfo.getApplication(req.ApplicationId)
+        appInfo := partitionInfo.GetApplication(req.ApplicationId)
         if appInfo == nil {
             msg := fmt.Sprintf("Failed to find applictaion %s, for allocation %s", req.ApplicationId, req.AllocationKey)
             log.Logger().Info(msg)
@@ -312,14 +412,6 @@ func (m *ClusterInfo) processNewAndReleaseAllocationRequests(request *si.UpdateR
                 })
             continue
         }
-        // start to process allocation asks from this app
-        // transit app's state to running
-        //把app的状态变为running
-        err := appInfo.HandleApplicationEvent(RunApplication)
-        if err != nil {
-            log.Logger().Debug("Application state change failed",
-                zap.Error(err))
-        }
     }
 
     // Reject asks returned to RM Proxy for the apps and partitions not found
@@ -398,6 +490,11 @@ func (m *ClusterInfo) processNodeUpdate(request *si.UpdateRequest) {
         RejectedNodes: rejectedNodes,
     })
 
+    // new nodes add resources to schedule on
+    if len(acceptedNodes) > 0 {
+        m.notifySchedulerResourcesAvailable()
+    }
+
     // notify the scheduler to recover existing allocations
     m.EventHandlers.SchedulerEventHandler.HandleEvent(&schedulerevent.SchedulerAllocationUpdatesEvent{
         ExistingAllocations: existingAllocations,
@@ -522,15 +619,50 @@ func (m *ClusterInfo) processAllocationProposalEvent(event *cacheevent.Allocatio
     partitionInfo := m.GetPartition(proposal.PartitionName)
     allocInfo, err := partitionInfo.addNewAllocation(proposal)
     if err != nil {
+        recordRejectedAllocation(proposal, err.Error())
         // Send reject event back to scheduler
         m.EventHandlers.SchedulerEventHandler.HandleEvent(&schedulerevent.SchedulerAllocationUpdatesEvent{
             RejectedAllocations: event.AllocationProposals,
         })
         return
     }
-    rmId := common.GetRMIdFromPartitionName(proposal.PartitionName)
+    // The volumes must be bound before the allocation is confirmed to the RM.
+    // Binding volumes can take a while: do not block the event handling while waiting.
+    if plugin := plugins.GetVolumesPlugin(); plugin != nil {
+        go m.bindVolumes(plugin, partitionInfo, allocInfo, proposal)
+        return
+    }
+    m.notifyRMNewAllocation(common.GetRMIdFromPartitionName(proposal.PartitionName), allocInfo)
+}
 
-    // Send allocation event to RM.
+// Bind the volumes for a new allocation and confirm the allocation to the RM.
+// If binding fails the allocation is removed from the partition and the proposal is rejected, which
+// puts the ask back as pending in the scheduler. The RM has not seen the allocation: it is not notified.
+// Lock free call, all updates occur on the underlying partition which is locked or via events.
+func (m *ClusterInfo) bindVolumes(plugin plugins.VolumesPlugin, partitionInfo *PartitionInfo, allocInfo *AllocationInfo, proposal *commonevents.AllocationProposal) {
+    if err := plugin.VolumesBind(proposal.AllocationKey, proposal.NodeId); err != nil {
+        log.Logger().Info("failed to bind volumes, rejecting allocation",
+            zap.String("allocationKey", proposal.AllocationKey),
+            zap.String("nodeId", proposal.NodeId),
+            zap.Error(err))
+        partitionInfo.releaseAllocationsForApplication(&commonevents.ReleaseAllocation{
+            Uuid:          allocInfo.AllocationProto.Uuid,
+            ApplicationId: proposal.ApplicationId,
+            PartitionName: proposal.PartitionName,
+            Message:       fmt.Sprintf("volume binding failed: %v", err),
+        })
+        recordRejectedAllocation(proposal, fmt.Sprintf("volume binding failed: %v", err))
+        m.EventHandlers.SchedulerEventHandler.HandleEvent(&schedulerevent.SchedulerAllocationUpdatesEvent{
+            RejectedAllocations: []*commonevents.AllocationProposal{proposal},
+        })
+        return
+    }
+    m.notifyRMNewAllocation(common.GetRMIdFromPartitionName(proposal.PartitionName), allocInfo)
+}
+
+// Create a RM update event to notify RM of a new allocation
+// Lock free call, all updates occur via events.
+func (m *ClusterInfo) notifyRMNewAllocation(rmId string, allocInfo *AllocationInfo) {
     m.EventHandlers.RMProxyEventHandler.HandleEvent(&rmevent.RMNewAllocationsEvent{
         Allocations: []*si.Allocation{allocInfo.AllocationProto},
         RMId:        rmId,
@@ -564,6 +696,12 @@ func (m *ClusterInfo) notifyRMAllocationReleased(rmId string, released []*Alloca
     m.EventHandlers.RMProxyEventHandler.HandleEvent(releaseEvent)
 }
 
+// Notify the scheduler that resources became available, this triggers a scheduling cycle.
+// Lock free call, all updates occur via events.
+func (m *ClusterInfo) notifySchedulerResourcesAvailable() {
+    m.EventHandlers.SchedulerEventHandler.HandleEvent(&schedulerevent.SchedulerResourcesAvailableEvent{})
+}
+
 // Process the allocations to release.
 // Lock free call, all updates occur via events.
 func (m *ClusterInfo) processAllocationReleases(toReleases []*commonevents.ReleaseAllocation) {
@@ -578,9 +716,10 @@ func (m *ClusterInfo) processAllocationReleases(toReleases []*commonevents.Relea
         rmID := common.GetRMIdFromPartitionName(toReleaseAllocation.PartitionName)
         // release the allocation from the partition
         releasedAllocations := partitionInfo.releaseAllocationsForApplication(toReleaseAllocation)
-        // whatever was released pass it back to the RM
+        // whatever was released pass it back to the RM and let the scheduler use the resources
         if len(releasedAllocations) != 0 {
             m.notifyRMAllocationReleased(rmID, releasedAllocations, toReleaseAllocation.ReleaseType, toReleaseAllocation.Message)
+            m.notifySchedulerResourcesAvailable()
         }
     }
 }
@@ -635,5 +774,6 @@ func (m *ClusterInfo) processRemovedApplication(event *cacheevent.RemovedApplica
         rmID := common.GetRMIdFromPartitionName(event.PartitionName)
         m.notifyRMAllocationReleased(rmID, allocations, si.AllocationReleaseResponse_STOPPED_BY_RM,
             fmt.Sprintf("Application %s Removed", event.ApplicationId))
+        m.notifySchedulerResourcesAvailable()
     }
 }
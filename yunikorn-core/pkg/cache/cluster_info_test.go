@@ -0,0 +1,34 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+    "testing"
+)
+
+func TestStopServiceTwice(t *testing.T) {
+    clusterInfo, _ := NewClusterInfo()
+    go clusterInfo.checkApplicationStates()
+    clusterInfo.StopService()
+    // a second stop must not panic on the closed channel
+    clusterInfo.StopService()
+    select {
+    case <-clusterInfo.stopChan:
+    default:
+        t.Errorf("stop channel should be closed after the service is stopped")
+    }
+}
@@ -25,6 +25,7 @@ import (
 // ----------------------------------
 // object events
 // these events are used for: partitions and managed queues
+// the drain event is only used for queues
 // ----------------------------------
 type SchedulingObjectEvent int
 
@@ -32,10 +33,11 @@ const (
     Remove SchedulingObjectEvent = iota
     Start
     Stop
+    Drain
 )
 
 func (soe SchedulingObjectEvent) String() string {
-    return [...]string{"Remove", "Start", "Stop"}[soe]
+    return [...]string{"Remove", "Start", "Stop", "Drain"}[soe]
 }
 
 // ----------------------------------
@@ -72,13 +74,44 @@ func newObjectState() *fsm.FSM {
             },
         },
         fsm.Callbacks{
-            "enter_state": func(event *fsm.Event) {
-                log.Logger().Info("object transition",
-                    zap.Any("object", event.Args[0]),
-                    zap.String("source", event.Src),
-                    zap.String("destination", event.Dst),
-                    zap.String("event", event.Event))
+            "enter_state": logObjectTransition,
+        },
+    )
+}
+
+// The state of a queue: next to the object transitions an admin can drain a queue and can stop or start a draining
+// queue. The queue guards against starting a queue that is marked for removal.
+func newQueueState() *fsm.FSM {
+    return fsm.NewFSM(
+        Active.String(), fsm.Events{
+            {
+                Name: Remove.String(),
+                Src: []string{Active.String(), Draining.String()},
+                Dst: Draining.String(),
+            },{
+                Name: Start.String(),
+                Src: []string{Active.String(), Stopped.String(), Draining.String()},
+                Dst: Active.String(),
+            },{
+                Name: Stop.String(),
+                Src: []string{Active.String(), Stopped.String(), Draining.String()},
+                Dst: Stopped.String(),
+            },{
+                Name: Drain.String(),
+                Src: []string{Active.String(), Stopped.String(), Draining.String()},
+                Dst: Draining.String(),
             },
         },
+        fsm.Callbacks{
+            "enter_state": logObjectTransition,
+        },
     )
 }
+
+func logObjectTransition(event *fsm.Event) {
+    log.Logger().Info("object transition",
+        zap.Any("object", event.Args[0]),
+        zap.String("source", event.Src),
+        zap.String("destination", event.Dst),
+        zap.String("event", event.Event))
+}
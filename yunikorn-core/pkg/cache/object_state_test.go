@@ -57,6 +57,55 @@ func TestStateTransition(t *testing.T) {
     assert.Equal(t, stateMachine.Current(), Draining.String())
 }
 
+func TestQueueStateTransition(t *testing.T) {
+    // base is active
+    stateMachine := newQueueState()
+    assert.Equal(t, stateMachine.Current(), Active.String())
+
+    // active to draining by an admin
+    err := stateMachine.Event(Drain.String(), "testqueue")
+    assert.Assert(t, err == nil)
+    assert.Equal(t, stateMachine.Current(), Draining.String())
+
+    // stop on draining allowed: the queue guards against restarting a queue marked for removal
+    err = stateMachine.Event(Stop.String(), "testqueue")
+    assert.Assert(t, err == nil)
+    assert.Equal(t, stateMachine.Current(), Stopped.String())
+
+    // stopped to draining
+    err = stateMachine.Event(Drain.String(), "testqueue")
+    assert.Assert(t, err == nil)
+    assert.Equal(t, stateMachine.Current(), Draining.String())
+
+    // draining to active
+    err = stateMachine.Event(Start.String(), "testqueue")
+    assert.Assert(t, err == nil)
+    assert.Equal(t, stateMachine.Current(), Active.String())
+
+    // remove on stopped not allowed
+    stateMachine.SetState(Stopped.String())
+    err = stateMachine.Event(Remove.String(), "testqueue")
+    assert.Assert(t, err != nil)
+    assert.Equal(t, stateMachine.Current(), Stopped.String())
+}
+
+func TestPartitionStateNoDrain(t *testing.T) {
+    // partitions use the object state: drain is not an event for a partition
+    stateMachine := newObjectState()
+    err := stateMachine.Event(Drain.String(), "testpartition")
+    assert.Assert(t, err != nil)
+    assert.Equal(t, stateMachine.Current(), Active.String())
+
+    // a partition that is removed cannot be stopped or started
+    err = stateMachine.Event(Remove.String(), "testpartition")
+    assert.Assert(t, err == nil)
+    err = stateMachine.Event(Start.String(), "testpartition")
+    assert.Assert(t, err != nil)
+    err = stateMachine.Event(Stop.String(), "testpartition")
+    assert.Assert(t, err != nil)
+    assert.Equal(t, stateMachine.Current(), Draining.String())
+}
+
 func TestTransitionToSelf(t *testing.T) {
     // base is active
     stateMachine := newObjectState()
@@ -22,6 +22,7 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/common/configs"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
     "github.com/cloudera/yunikorn-core/pkg/common/security"
+    "github.com/cloudera/yunikorn-core/pkg/events"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "github.com/cloudera/yunikorn-core/pkg/metrics"
     "github.com/cloudera/yunikorn-core/pkg/webservice/dao"
@@ -34,6 +35,15 @@ import (
     "time"
 )
 
+const (
+    // default time an application can be starting before it is considered running
+    DefaultAppStartingTimeout = 5 * time.Minute
+    // default time an application stays completing before it is completed and removed
+    DefaultAppCompletingTimeout = 30 * time.Second
+    // default number of nodes evaluated in parallel for an allocation
+    DefaultNodeEvaluationParallelism = 16
+)
+
 /* Related to partitions */
 type PartitionInfo struct {
     Name string
@@ -47,6 +57,9 @@ type PartitionInfo struct {
     stateMachine           *fsm.FSM                     // the state of the queue for scheduling
     stateTime              time.Time                    // last time the state was updated (needed for cleanup)
     isPreemptable          bool                         // can allocations be preempted
+    preemption             configs.PartitionPreemptionConfig // preemption settings for the partition
+    appLifecycle           configs.PartitionApplicationsConfig // application lifecycle settings for the partition
+    nodeEvaluation         configs.PartitionNodesConfig // node evaluation settings for the partition
     rules                  *[]configs.PlacementRule     // placement rules to be loaded by the scheduler
     userGroupCache         *security.UserGroupCache     // user cache per partition
     clusterInfo            *ClusterInfo                 // link back to the cluster info
@@ -85,17 +98,45 @@ func NewPartitionInfo(partition configs.PartitionConfig, rmId string, info *Clus
         zap.String("partitionName", p.Name),
         zap.String("rmId", p.RMId))
 
-    // set preemption needed flag
+    // set preemption needed flag and settings
     p.isPreemptable = partition.Preemption.Enabled
+    p.preemption = partition.Preemption
+    p.appLifecycle = partition.Applications
+    p.nodeEvaluation = partition.Nodes
 
     p.rules = &partition.PlacementRules
     // get the user group cache for the partition
-    // TODO get the resolver from the config
-    p.userGroupCache = security.GetUserGroupCache("")
+    p.userGroupCache, err = getUserGroupCache(partition.UserGroups)
+    if err != nil {
+        return nil, err
+    }
 
     return p, nil
 }
 
+// Get the user group cache for the resolver settings of the partition.
+// Partitions with the same settings share the cache, the partition must release the cache when it stops using it.
+func getUserGroupCache(conf configs.UserGroupResolverConfig) (*security.UserGroupCache, error) {
+    return security.GetUserGroupCacheFromConfig(security.ResolverConfig{
+        Type:        strings.ToLower(conf.Resolver),
+        PositiveTTL: conf.PositiveTTL,
+        NegativeTTL: conf.NegativeTTL,
+        File:        conf.File,
+        LDAP: security.LDAPConfig{
+            URL:                conf.LDAP.URL,
+            StartTLS:           conf.LDAP.StartTLS,
+            CACertFile:         conf.LDAP.CACertFile,
+            InsecureSkipVerify: conf.LDAP.InsecureSkipVerify,
+            BindDN:             conf.LDAP.BindDN,
+            BindPassword:       conf.LDAP.BindPassword,
+            BindPasswordFile:   conf.LDAP.BindPasswordFile,
+            SearchBase:         conf.LDAP.SearchBase,
+            UserFilter:         conf.LDAP.UserFilter,
+            GroupAttribute:     conf.LDAP.GroupAttribute,
+        },
+    })
+}
+
 // Process the config structure and create a queue info tree for this partition
 func addQueueInfo(conf []configs.QueueConfig, parent *QueueInfo) error {
     // create the queue at this level
@@ -142,6 +183,69 @@ func (pi *PartitionInfo) NeedPreemption() bool {
     return pi.isPreemptable
 }
 
+// Return the preemption settings for the partition
+func (pi *PartitionInfo) GetPreemptionConfig() configs.PartitionPreemptionConfig {
+    pi.lock.RLock()
+    defer pi.lock.RUnlock()
+
+    return pi.preemption
+}
+
+// Return the time an application can be starting before it is considered running
+func (pi *PartitionInfo) GetAppStartingTimeout() time.Duration {
+    pi.lock.RLock()
+    defer pi.lock.RUnlock()
+
+    if pi.appLifecycle.StartingTimeout > 0 {
+        return pi.appLifecycle.StartingTimeout
+    }
+    return DefaultAppStartingTimeout
+}
+
+// Return the time an application stays completing before it is completed and removed
+func (pi *PartitionInfo) GetAppCompletingTimeout() time.Duration {
+    pi.lock.RLock()
+    defer pi.lock.RUnlock()
+
+    if pi.appLifecycle.CompletingTimeout > 0 {
+        return pi.appLifecycle.CompletingTimeout
+    }
+    return DefaultAppCompletingTimeout
+}
+
+// Return the number of nodes that are evaluated in parallel for an allocation
+func (pi *PartitionInfo) GetNodeEvaluationParallelism() int {
+    pi.lock.RLock()
+    defer pi.lock.RUnlock()
+
+    if pi.nodeEvaluation.Parallelism > 0 {
+        return pi.nodeEvaluation.Parallelism
+    }
+    return DefaultNodeEvaluationParallelism
+}
+
+// Return the configured percentage of the nodes that is scored for an allocation.
+// Zero means the percentage is not configured and should be derived from the size of the partition.
+func (pi *PartitionInfo) GetPercentageOfNodesToScore() int32 {
+    pi.lock.RLock()
+    defer pi.lock.RUnlock()
+
+    return pi.nodeEvaluation.PercentageOfNodesToScore
+}
+
+// Return a copy of the weights of the node scorers by scorer name.
+// Nodes are not scored if no weights are configured.
+func (pi *PartitionInfo) GetNodeScorerWeights() map[string]int32 {
+    pi.lock.RLock()
+    defer pi.lock.RUnlock()
+
+    weights := make(map[string]int32)
+    for name, weight := range pi.nodeEvaluation.Scoring {
+        weights[name] = weight
+    }
+    return weights
+}
+
 // Return the config element for the placement rules
 func (pi *PartitionInfo) GetRules() []configs.PlacementRule {
     if pi.rules == nil {
@@ -174,6 +278,7 @@ func (pi *PartitionInfo) addNewNode(node *NodeInfo, existingAllocations []*si.Al
     // update the resources available in the cluster
     pi.totalPartitionResource = resources.Add(pi.totalPartitionResource, node.TotalResource)
     pi.Root.MaxResource = pi.totalPartitionResource
+    pi.Root.updateRelativeResources()
 
     // Node is added to the system to allow processing of the allocations
     pi.nodes[node.NodeId] = node
@@ -193,6 +298,7 @@ func (pi *PartitionInfo) addNewNode(node *NodeInfo, existingAllocations []*si.Al
                 return err
             }
         }
+        pi.recordQueuesOverMax(existingAllocations)
     }
 
     // Node is accepted, scan all recovered allocations again,
@@ -201,11 +307,11 @@ func (pi *PartitionInfo) addNewNode(node *NodeInfo, existingAllocations []*si.Al
     // cannot be recovered.
     if len(existingAllocations) > 0 {
         // if an allocation of a app is accepted,
-        // transit app's state from Accepted to Running
+        // transit app's state from Accepted or Starting to Running
         for _, alloc := range existingAllocations {
             if app, ok := pi.applications[alloc.ApplicationId]; ok {
                 log.Logger().Info("", zap.String("state", app.GetApplicationState()))
-                if app.GetApplicationState() == Accepted.String() {
+                if state := app.GetApplicationState(); state == Accepted.String() || state == Starting.String() {
                     if err := app.HandleApplicationEvent(RunApplication); err != nil {
                         log.Logger().Warn("unable to handle app event - RunApplication",
                             zap.Error(err))
@@ -224,6 +330,29 @@ func (pi *PartitionInfo) addNewNode(node *NodeInfo, existingAllocations []*si.Al
     return nil
 }
 
+// Record an event for the queues that the allocations reported by a node pushed over their maximum.
+// Must be called while holding the partition lock.
+func (pi *PartitionInfo) recordQueuesOverMax(existingAllocations []*si.Allocation) {
+    recorded := make(map[string]bool)
+    for _, alloc := range existingAllocations {
+        for queue := pi.getQueue(alloc.QueueName); queue != nil; queue = queue.Parent {
+            queuePath := queue.GetQueuePath()
+            if recorded[queuePath] || !queue.IsOverMax() {
+                continue
+            }
+            recorded[queuePath] = true
+            events.Record(&events.Event{
+                ObjectType: events.ObjectQueue,
+                ObjectId:   queuePath,
+                Partition:  pi.Name,
+                QueueName:  queuePath,
+                Reason:     events.QueueOverMax,
+                Message:    fmt.Sprintf("recovered allocations put queue over maximum %v, used %v", queue.MaxResource, queue.GetAllocatedResource()),
+            })
+        }
+    }
+}
+
 // Wrapper function to convert the reported allocation into an AllocationProposal.
 // Used when a new node is added to the partition which already reports existing allocations.
 func (pi *PartitionInfo) addNodeReportedAllocations(allocation *si.Allocation) (*AllocationInfo, error) {
@@ -302,6 +431,7 @@ func (pi *PartitionInfo) removeNodeInternal(nodeId string) {
 
     pi.totalPartitionResource = resources.Sub(pi.totalPartitionResource, node.TotalResource)
     pi.Root.MaxResource = pi.totalPartitionResource
+    pi.Root.updateRelativeResources()
 
     // Remove node from list of tracked nodes
     delete(pi.nodes, nodeId)
@@ -345,6 +475,7 @@ func (pi *PartitionInfo) addNewApplication(info *ApplicationInfo, failIfExist bo
     // Add app to the partition
     pi.applications[info.ApplicationId] = info
     pi.metrics.IncTotalApplicationsAdded()
+    pi.metrics.IncApplicationsInState(info.GetApplicationState())
 
     log.Logger().Info("app added to partition",
         zap.String("appId", info.ApplicationId),
@@ -354,13 +485,22 @@ func (pi *PartitionInfo) addNewApplication(info *ApplicationInfo, failIfExist bo
 
 // Get the application object for the application ID as tracked by the partition.
 // This will return nil if the application is not part of this partition.
-func (pi *PartitionInfo) getApplication(appId string) *ApplicationInfo {
+func (pi *PartitionInfo) GetApplication(appId string) *ApplicationInfo {
     pi.lock.RLock()
     defer pi.lock.RUnlock()
 
     return pi.applications[appId]
 }
 
+// Get the allocation object for the uuid as tracked by the partition.
+// This will return nil if the allocation is not part of this partition.
+func (pi *PartitionInfo) GetAllocation(uuid string) *AllocationInfo {
+    pi.lock.RLock()
+    defer pi.lock.RUnlock()
+
+    return pi.allocations[uuid]
+}
+
 // Get the node object for the node ID as tracked by the partition.
 // This will return nil if the node is not part of this partition.
 // Visible by tests
@@ -483,6 +623,10 @@ func (pi *PartitionInfo) addNewAllocationInternal(alloc *commonevents.Allocation
         return nil, fmt.Errorf("failed to find application %s", alloc.ApplicationId)
     }
 
+    // the application could have been moved after the allocation was proposed: use the current queue of the application
+    if app.QueueName != alloc.QueueName && app.leafQueue != nil {
+        alloc.QueueName = app.QueueName
+    }
     if queue = pi.getQueue(alloc.QueueName); queue == nil || !queue.IsLeafQueue() {
         pi.metrics.IncScheduledAllocationErrors()
         return nil, fmt.Errorf("queue does not exist or is not a leaf queue %s", alloc.QueueName)
@@ -507,6 +651,10 @@ func (pi *PartitionInfo) addNewAllocationInternal(alloc *commonevents.Allocation
     // Start allocation
     allocationUuid := pi.GetNewAllocationUuid()
     allocation := NewAllocationInfo(allocationUuid, alloc)
+    // a recovered allocation keeps the time it was created, not the time it was reported
+    if nodeReported {
+        allocation.CreateTime = getRecoveredCreateTime(alloc.Tags)
+    }
 
     node.AddAllocation(allocation)
 
@@ -553,7 +701,10 @@ func (pi *PartitionInfo) RemoveRejectedApp(appId string) {
         zap.String("appId", appId),
         zap.String("partitionName", pi.Name))
     // Remove app from cache there is nothing to be cleaned up
-    delete(pi.applications, appId)
+    if app := pi.applications[appId]; app != nil {
+        pi.metrics.DecApplicationsInState(app.GetApplicationState())
+        delete(pi.applications, appId)
+    }
 }
 
 // Remove the application from the partition.
@@ -624,6 +775,14 @@ func (pi *PartitionInfo) RemoveApplication(appId string) (*ApplicationInfo, []*A
             }
         }
     }
+    // The application is done: the state change is allowed to fail for rejected, killed or completed applications
+    if err := app.HandleApplicationEvent(CompleteApplication); err != nil {
+        log.Logger().Debug("application not completed on removal",
+            zap.String("appId", appId),
+            zap.String("state", app.GetApplicationState()),
+            zap.Error(err))
+    }
+    pi.metrics.DecApplicationsInState(app.GetApplicationState())
     // Remove app from cache now that everything is cleaned up
     delete(pi.applications, appId)
 
@@ -635,6 +794,80 @@ func (pi *PartitionInfo) RemoveApplication(appId string) (*ApplicationInfo, []*A
     return app, allocations
 }
 
+// Move the application to a different leaf queue in the partition.
+// The allocated resources of the application are moved from the current queue to the target queue. The move fails if
+// the allocated resources do not fit in the maximum resources of the target queue hierarchy.
+// Access checks and the state of the target queue are checked by the scheduler before the move.
+func (pi *PartitionInfo) MoveApplication(appId string, target *QueueInfo) error {
+    pi.lock.Lock()
+    defer pi.lock.Unlock()
+
+    app := pi.applications[appId]
+    if app == nil {
+        return fmt.Errorf("application %s not found in partition %s", appId, pi.Name)
+    }
+    if target == nil || !target.IsLeafQueue() {
+        return fmt.Errorf("target queue does not exist or is not a leaf queue for application %s", appId)
+    }
+    source := app.leafQueue
+    if source == nil {
+        return fmt.Errorf("application %s is not placed in a queue", appId)
+    }
+    if source == target {
+        return fmt.Errorf("application %s is already in queue %s", appId, target.GetQueuePath())
+    }
+    allocated := app.GetAllocatedResource()
+    if err := moveAllocatedResource(source, target, allocated); err != nil {
+        return fmt.Errorf("cannot move application %s: %v", appId, err)
+    }
+    app.moveQueue(target)
+
+    log.Logger().Info("app moved in partition",
+        zap.String("appId", appId),
+        zap.String("partitionName", pi.Name),
+        zap.String("sourceQueue", source.GetQueuePath()),
+        zap.String("targetQueue", target.GetQueuePath()),
+        zap.Any("resourceMoved", allocated))
+    return nil
+}
+
+// Check the state timeouts of the applications in the partition:
+// - starting applications are considered running after the starting timeout
+// - completing applications are completed after the completing timeout
+// Returns the applications that were completed and must be removed from the partition.
+func (pi *PartitionInfo) checkApplicationTimeouts(now time.Time) []*ApplicationInfo {
+    startingTimeout := pi.GetAppStartingTimeout()
+    completingTimeout := pi.GetAppCompletingTimeout()
+
+    completed := make([]*ApplicationInfo, 0)
+    for _, app := range pi.GetApplications() {
+        timeInState := now.Sub(app.GetStateTime())
+        switch app.GetApplicationState() {
+        case Starting.String():
+            if timeInState < startingTimeout {
+                continue
+            }
+            if err := app.HandleApplicationEvent(RunApplication); err != nil {
+                log.Logger().Warn("unable to run starting application",
+                    zap.String("appId", app.ApplicationId),
+                    zap.Error(err))
+            }
+        case Completing.String():
+            if timeInState < completingTimeout {
+                continue
+            }
+            if err := app.HandleApplicationEvent(CompleteApplication); err != nil {
+                log.Logger().Warn("unable to complete completing application",
+                    zap.String("appId", app.ApplicationId),
+                    zap.Error(err))
+                continue
+            }
+            completed = append(completed, app)
+        }
+    }
+    return completed
+}
+
 // Return a copy of all the nodes registers to this partition
 func (pi *PartitionInfo) CopyNodeInfos() []*NodeInfo {
     pi.lock.RLock()
@@ -658,10 +891,40 @@ func checkAndSetResource(resource *resources.Resource) string {
     return ""
 }
 
+// Get the status of the queue as exposed in the web service.
+func GetQueueStatus(queue *QueueInfo) string {
+    switch {
+    case queue.IsStopped():
+        return "STOPPED"
+    case queue.IsDraining():
+        return "DRAINING"
+    default:
+        return "RUNNING"
+    }
+}
+
+// Convert the effective ACLs of the queue into the permissions exposed in the web service.
+func getPermissionsJson(queue *QueueInfo) dao.QueuePermissions {
+    submitACL, adminACL := queue.GetEffectiveACLs()
+    return dao.QueuePermissions{
+        Submit: getEffectiveACLJson(submitACL),
+        Admin:  getEffectiveACLJson(adminACL),
+    }
+}
+
+func getEffectiveACLJson(acl security.ACL) dao.EffectiveACL {
+    return dao.EffectiveACL{
+        AllowAll:     acl.AllowsAll(),
+        Users:        acl.AllowedUsers(),
+        Groups:       acl.AllowedGroups(),
+        DeniedUsers:  acl.DeniedUsers(),
+        DeniedGroups: acl.DeniedGroups(),
+    }
+}
+
 // TODO fix this:
 // should only return one element, only a root queue
 // remove hard coded values and unknown AbsUsedCapacity
-// map status to the draining flag
 func (pi *PartitionInfo) GetQueueInfos() []dao.QueueDAOInfo {
     pi.lock.RLock()
     defer pi.lock.RUnlock()
@@ -670,13 +933,16 @@ func (pi *PartitionInfo) GetQueueInfos() []dao.QueueDAOInfo {
 
     info := dao.QueueDAOInfo{}
     info.QueueName = pi.Root.Name
-    info.Status = "RUNNING"
+    info.Status = GetQueueStatus(pi.Root)
     info.Capacities = dao.QueueCapacity{
         Capacity:        checkAndSetResource(pi.Root.GuaranteedResource),
         MaxCapacity:     checkAndSetResource(pi.Root.MaxResource),
         UsedCapacity:    checkAndSetResource(pi.Root.GetAllocatedResource()),
         AbsUsedCapacity: "20",
+        FairShare:       checkAndSetResource(pi.Root.GetFairShare()),
     }
+    info.OverMax = pi.Root.IsOverMax()
+    info.Permissions = getPermissionsJson(pi.Root)
     info.ChildQueues = GetChildQueueInfos(pi.Root)
     queueInfos = append(queueInfos, info)
 
@@ -686,19 +952,21 @@ func (pi *PartitionInfo) GetQueueInfos() []dao.QueueDAOInfo {
 // TODO fix this:
 // should only return one element, only a root queue
 // remove hard coded values and unknown AbsUsedCapacity
-// map status to the draining flag
 func GetChildQueueInfos(info *QueueInfo) []dao.QueueDAOInfo {
     var infos []dao.QueueDAOInfo
     for _, v := range info.children {
         queue := dao.QueueDAOInfo{}
         queue.QueueName = v.Name
-        queue.Status = "RUNNING"
+        queue.Status = GetQueueStatus(v)
         queue.Capacities = dao.QueueCapacity{
             Capacity:        checkAndSetResource(v.GuaranteedResource),
             MaxCapacity:     checkAndSetResource(v.MaxResource),
             UsedCapacity:    checkAndSetResource(v.GetAllocatedResource()),
             AbsUsedCapacity: "20",
+            FairShare:       checkAndSetResource(v.GetFairShare()),
         }
+        queue.OverMax = v.IsOverMax()
+        queue.Permissions = getPermissionsJson(v)
         queue.ChildQueues = GetChildQueueInfos(v)
         infos = append(infos, queue)
     }
@@ -767,18 +1035,35 @@ func (pi *PartitionInfo) getQueue(name string) *QueueInfo {
 
 // Update the queues in the partition based on the reloaded and checked config
 func (pi *PartitionInfo) updatePartitionDetails(partition configs.PartitionConfig) error {
-    pi.lock.RLock()
-    defer pi.lock.RUnlock()
-    // update preemption needed flag
+    pi.lock.Lock()
+    defer pi.lock.Unlock()
+    // update preemption needed flag and settings
     pi.isPreemptable = partition.Preemption.Enabled
+    pi.preemption = partition.Preemption
+    pi.appLifecycle = partition.Applications
+    pi.nodeEvaluation = partition.Nodes
+    // switch the user group cache if the resolver settings changed, the old cache is stopped when it is no longer used
+    userGroupCache, err := getUserGroupCache(partition.UserGroups)
+    if err != nil {
+        return err
+    }
+    pi.userGroupCache.Release()
+    pi.userGroupCache = userGroupCache
     // start at the root: there is only one queue
     queueConf := partition.Queues[0]
     root := pi.getQueue(queueConf.Name)
-    err := root.updateQueueProps(queueConf)
+    err = root.updateQueueProps(queueConf)
+    if err != nil {
+        return err
+    }
+    err = pi.updateQueues(queueConf.Queues, root)
     if err != nil {
         return err
     }
-    return pi.updateQueues(queueConf.Queues, root)
+    // resolve the relative queue resources and the effective ACLs against the updated queue structure
+    root.updateRelativeResources()
+    root.updateEffectiveACLs()
+    return nil
 }
 
 // Update the passed in queues and then do this recursively for the children
@@ -795,6 +1080,8 @@ func (pi *PartitionInfo) updateQueues(config []configs.QueueConfig, parent *Queu
         if queue == nil {
             queue, err = NewManagedQueue(queueConfig, parent)
         } else {
+            // the queue could have been removed from the config before
+            queue.unmarkQueueForRemoval()
             err = queue.updateQueueProps(queueConfig)
         }
         if err != nil {
@@ -836,6 +1123,18 @@ func (pi *PartitionInfo) Remove() {
     // safeguard
     if pi.IsDraining() || pi.IsStopped() {
         pi.clusterInfo.removePartition(pi.Name)
+        pi.userGroupCache.Release()
+    }
+}
+
+// Release the user group cache of a partition that is not part of a cluster, like a partition created outside the
+// scheduler to simulate the placement of applications. The partition must not be used after it is released.
+func (pi *PartitionInfo) ReleaseUserGroupCache() {
+    pi.lock.Lock()
+    defer pi.lock.Unlock()
+    if pi.userGroupCache != nil {
+        pi.userGroupCache.Release()
+        pi.userGroupCache = nil
     }
 }
 
@@ -893,6 +1192,13 @@ func (pi *PartitionInfo) CreateQueues(queueName string) error {
     return nil
 }
 
+// Get the user with the groups resolved by the user group cache of the partition.
+func (pi *PartitionInfo) GetUserGroup(userName string) (security.UserGroup, error) {
+    pi.lock.RLock()
+    defer pi.lock.RUnlock()
+    return pi.userGroupCache.GetUserGroup(userName)
+}
+
 func (pi *PartitionInfo) convertUGI(ugi *si.UserGroupInformation) (security.UserGroup, error) {
     pi.lock.RLock()
     defer pi.lock.RUnlock()
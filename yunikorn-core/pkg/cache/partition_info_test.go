@@ -17,9 +17,15 @@ limitations under the License.
 package cache
 
 import (
+    "github.com/cloudera/yunikorn-core/pkg/api"
     "github.com/cloudera/yunikorn-core/pkg/common/commonevents"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/common/security"
+    "github.com/cloudera/yunikorn-core/pkg/events"
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "strconv"
+    "strings"
     "testing"
     "time"
 )
@@ -300,7 +306,7 @@ partitions:
 
     appInfo = newApplicationInfo("app-2", "default", "root.default")
     err = partition.addNewApplication(appInfo, true)
-    if err == nil || partition.getApplication("app-2") != nil {
+    if err == nil || partition.GetApplication("app-2") != nil {
         t.Errorf("add application on stopped partition should have failed but did not")
     }
 
@@ -313,7 +319,7 @@ partitions:
     waitForPartitionState(t, partition, Draining.String(), 1000)
     appInfo = newApplicationInfo("app-3", "default", "root.default")
     err = partition.addNewApplication(appInfo, true)
-    if err == nil || partition.getApplication("app-3") != nil {
+    if err == nil || partition.GetApplication("app-3") != nil {
         t.Errorf("add application on draining partition should have failed but did not")
     }
 }
@@ -391,6 +397,57 @@ partitions:
     }
 }
 
+func TestRecoveredAllocationCreateTime(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: default
+`
+
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Error(err)
+        return
+    }
+    appID := "app-1"
+    queueName := "root.default"
+    err = partition.addNewApplication(newApplicationInfo(appID, "default", queueName), true)
+    if err != nil {
+        t.Errorf("add application to partition should not have failed: %v", err)
+    }
+
+    // one allocation with the original creation time, one without and one with an invalid time
+    created := time.Now().Add(-time.Hour).Truncate(time.Second)
+    withTime := createAllocation(queueName, "node-1", "alloc-1", appID)
+    withTime.AllocationTags = map[string]string{api.CREATION_TIME: strconv.FormatInt(created.Unix(), 10)}
+    noTime := createAllocation(queueName, "node-1", "alloc-2", appID)
+    badTime := createAllocation(queueName, "node-1", "alloc-3", appID)
+    badTime.AllocationTags = map[string]string{api.CREATION_TIME: "yesterday"}
+    node := newNodeInfoForTest("node-1", resources.NewResourceFromMap(
+        map[string]resources.Quantity{resources.MEMORY: 1000}), nil)
+    before := time.Now()
+    err = partition.addNewNode(node, []*si.Allocation{withTime, noTime, badTime})
+    if err != nil {
+        t.Fatalf("add node to partition should not have failed: %v", err)
+    }
+    for _, alloc := range partition.GetNode("node-1").GetAllAllocations() {
+        switch alloc.AllocationProto.AllocationKey {
+        case "alloc-1":
+            if !alloc.CreateTime.Equal(created) {
+                t.Errorf("recovered allocation did not keep creation time expected %v got %v", created, alloc.CreateTime)
+            }
+        default:
+            if alloc.CreateTime.Before(before) {
+                t.Errorf("recovered allocation %s without valid creation time should use current time got %v",
+                    alloc.AllocationProto.AllocationKey, alloc.CreateTime)
+            }
+        }
+    }
+}
+
 func TestAddNewAllocation(t *testing.T) {
     data := `
 partitions:
@@ -479,6 +536,76 @@ partitions:
     }
 }
 
+func TestAllocationsOverQueueMax(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: default
+          resources:
+            max:
+              memory: 2
+`
+
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Error(err)
+        return
+    }
+    appID := "app-1"
+    queueName := "root.default"
+    err = partition.addNewApplication(newApplicationInfo(appID, "default", queueName), true)
+    if err != nil {
+        t.Errorf("add application to partition should not have failed: %v", err)
+    }
+    // recovered allocations are added even if they push the queue over max
+    node1 := newNodeInfoForTest("node-1", resources.NewResourceFromMap(
+        map[string]resources.Quantity{resources.MEMORY: 100}), nil)
+    existing := []*si.Allocation{
+        createAllocation(queueName, "node-1", "alloc-1", appID),
+        createAllocation(queueName, "node-1", "alloc-2", appID),
+        createAllocation(queueName, "node-1", "alloc-3", appID),
+    }
+    err = partition.addNewNode(node1, existing)
+    if err != nil || len(partition.allocations) != 3 {
+        t.Errorf("add node with existing allocations should not have failed: %v", err)
+    }
+    qi := partition.getQueue(queueName)
+    if !qi.IsOverMax() {
+        t.Errorf("queue %s should be over max: %v", qi.GetQueuePath(), qi.GetAllocatedResource())
+    }
+    queueEvents := events.GetEventStore().GetEvents("", events.ObjectQueue, queueName)
+    if len(queueEvents) == 0 || queueEvents[len(queueEvents)-1].Reason != events.QueueOverMax {
+        t.Errorf("queue over max event not recorded: %v", queueEvents)
+    }
+    queueInfos := partition.GetQueueInfos()
+    if queueInfos[0].OverMax || !queueInfos[0].ChildQueues[0].OverMax {
+        t.Errorf("only the leaf queue should be flagged as over max: %v", queueInfos)
+    }
+
+    // new allocations are rejected until the queue is back under max
+    alloc, err := partition.addNewAllocation(createAllocationProposal(queueName, "node-1", "alloc-4", appID))
+    if err == nil || alloc != nil || len(partition.allocations) != 3 {
+        t.Errorf("adding allocation over queue max worked and should have failed: %v", alloc)
+    }
+    for _, info := range partition.GetApplication(appID).GetAllAllocations()[:2] {
+        partition.releaseAllocationsForApplication(&commonevents.ReleaseAllocation{
+            Uuid:          info.AllocationProto.Uuid,
+            ApplicationId: appID,
+            PartitionName: partition.Name,
+        })
+    }
+    if qi.IsOverMax() {
+        t.Errorf("queue %s should be back under max: %v", qi.GetQueuePath(), qi.GetAllocatedResource())
+    }
+    alloc, err = partition.addNewAllocation(createAllocationProposal(queueName, "node-1", "alloc-4", appID))
+    if err != nil || alloc == nil {
+        t.Errorf("adding allocation under queue max failed: %v", err)
+    }
+}
+
 func TestRemoveApp(t *testing.T) {
     data := `
 partitions:
@@ -561,6 +688,81 @@ partitions:
     }
 }
 
+func TestApplicationTimeouts(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: default
+    applications:
+      startingtimeout: 1m
+`
+
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Error(err)
+        return
+    }
+    if partition.GetAppStartingTimeout() != time.Minute || partition.GetAppCompletingTimeout() != DefaultAppCompletingTimeout {
+        t.Errorf("application timeouts not set correctly: starting %v, completing %v",
+            partition.GetAppStartingTimeout(), partition.GetAppCompletingTimeout())
+    }
+    queueName := "root.default"
+    nodeID := "node-1"
+    node1 := newNodeInfoForTest(nodeID, resources.NewResourceFromMap(
+        map[string]resources.Quantity{resources.MEMORY: 1000}), nil)
+    if err = partition.addNewNode(node1, nil); err != nil {
+        t.Errorf("add node to partition should not have failed: %v", err)
+        return
+    }
+    starting := newApplicationInfo("app-starting", "default", queueName)
+    completing := newApplicationInfo("app-completing", "default", queueName)
+    for _, app := range []*ApplicationInfo{starting, completing} {
+        if err = partition.addNewApplication(app, true); err != nil {
+            t.Errorf("add application to partition should not have failed: %v", err)
+            return
+        }
+        _ = app.HandleApplicationEvent(AcceptApplication)
+    }
+    // one allocation starts the application
+    alloc, err := partition.addNewAllocation(createAllocationProposal(queueName, nodeID, "alloc-1", starting.ApplicationId))
+    if err != nil || starting.GetApplicationState() != Starting.String() {
+        t.Fatalf("allocation should have started the application: %s (err = %v)", starting.GetApplicationState(), err)
+    }
+    // release of the only allocation without asks: completing
+    alloc, err = partition.addNewAllocation(createAllocationProposal(queueName, nodeID, "alloc-2", completing.ApplicationId))
+    if err != nil {
+        t.Fatalf("add allocation to partition should not have failed: %v", err)
+    }
+    partition.releaseAllocationsForApplication(&commonevents.ReleaseAllocation{
+        ApplicationId: completing.ApplicationId,
+        Uuid:          alloc.AllocationProto.Uuid,
+    })
+    if completing.GetApplicationState() != Completing.String() {
+        t.Fatalf("release should have moved the application to completing: %s", completing.GetApplicationState())
+    }
+
+    // nothing happens before the timeouts
+    if completed := partition.checkApplicationTimeouts(time.Now()); len(completed) != 0 {
+        t.Errorf("no application should have been completed: %v", completed)
+    }
+    // completing times out first
+    completed := partition.checkApplicationTimeouts(time.Now().Add(DefaultAppCompletingTimeout))
+    if len(completed) != 1 || completed[0] != completing || completing.GetApplicationState() != Completed.String() {
+        t.Errorf("completing application should have been completed: %v", completed)
+    }
+    if starting.GetApplicationState() != Starting.String() {
+        t.Errorf("starting application should not have changed state: %s", starting.GetApplicationState())
+    }
+    // starting is running after the timeout
+    partition.checkApplicationTimeouts(time.Now().Add(time.Minute))
+    if starting.GetApplicationState() != Running.String() {
+        t.Errorf("starting application should be running after the timeout: %s", starting.GetApplicationState())
+    }
+}
+
 func TestRemoveAppAllocs(t *testing.T) {
     data := `
 partitions:
@@ -637,6 +839,97 @@ partitions:
     }
 }
 
+func TestMoveApplication(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: parent
+          queues:
+          - name: source
+          - name: small
+            resources:
+              max:
+                memory: 2
+        - name: target
+`
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("partition create failed: %v", err)
+    }
+    appID := "app-1"
+    source := "root.parent.source"
+    err = partition.addNewApplication(newApplicationInfo(appID, "default", source), true)
+    if err != nil {
+        t.Fatalf("add application to partition should not have failed: %v", err)
+    }
+    nodeID := "node-1"
+    node1 := newNodeInfoForTest(nodeID, resources.NewResourceFromMap(
+        map[string]resources.Quantity{resources.MEMORY: 1000}), nil)
+    if err = partition.addNewNode(node1, nil); err != nil {
+        t.Fatalf("add node to partition should not have failed: %v", err)
+    }
+    for _, allocID := range []string{"alloc-1", "alloc-2", "alloc-3"} {
+        if _, err = partition.addNewAllocation(createAllocationProposal(source, nodeID, allocID, appID)); err != nil {
+            t.Fatalf("add allocation to partition should not have failed: %v", err)
+        }
+    }
+    sourceQueue := partition.getQueue(source)
+    parentQueue := partition.getQueue("root.parent")
+    targetQueue := partition.getQueue("root.target")
+
+    // the allocated resources do not fit in the target: nothing changes
+    err = partition.MoveApplication(appID, partition.getQueue("root.parent.small"))
+    if err == nil {
+        t.Errorf("move into queue with a too small maximum should have failed")
+    }
+    if sourceQueue.GetAllocatedResource().Resources[resources.MEMORY] != 3 || partition.GetApplication(appID).QueueName != source {
+        t.Errorf("failed move changed the source queue: %v", sourceQueue.GetAllocatedResource())
+    }
+    if partition.getQueue("root.parent.small").GetAllocatedResource().Resources[resources.MEMORY] != 0 {
+        t.Errorf("failed move changed the target queue: %v", partition.getQueue("root.parent.small").GetAllocatedResource())
+    }
+    if err = partition.MoveApplication(appID, parentQueue); err == nil {
+        t.Errorf("move into a parent queue should have failed")
+    }
+    if err = partition.MoveApplication(appID, sourceQueue); err == nil {
+        t.Errorf("move into the current queue should have failed")
+    }
+    if err = partition.MoveApplication("unknown", targetQueue); err == nil {
+        t.Errorf("move of an unknown application should have failed")
+    }
+
+    // move the app: the resources move from the source hierarchy to the target
+    if err = partition.MoveApplication(appID, targetQueue); err != nil {
+        t.Fatalf("move of the application should not have failed: %v", err)
+    }
+    if sourceQueue.GetAllocatedResource().Resources[resources.MEMORY] != 0 || parentQueue.GetAllocatedResource().Resources[resources.MEMORY] != 0 {
+        t.Errorf("move did not release the source queues: %v, %v", sourceQueue.GetAllocatedResource(), parentQueue.GetAllocatedResource())
+    }
+    if targetQueue.GetAllocatedResource().Resources[resources.MEMORY] != 3 || partition.Root.GetAllocatedResource().Resources[resources.MEMORY] != 3 {
+        t.Errorf("move did not update the target and root queue: %v, %v", targetQueue.GetAllocatedResource(), partition.Root.GetAllocatedResource())
+    }
+    app := partition.GetApplication(appID)
+    if app.QueueName != "root.target" {
+        t.Errorf("application queue not updated: %s", app.QueueName)
+    }
+    for _, alloc := range app.GetAllAllocations() {
+        if alloc.AllocationProto.QueueName != "root.target" {
+            t.Errorf("allocation queue not updated: %s", alloc.AllocationProto.QueueName)
+        }
+    }
+    // an allocation proposed before the move is added to the new queue
+    alloc, err := partition.addNewAllocation(createAllocationProposal(source, nodeID, "alloc-4", appID))
+    if err != nil || alloc.AllocationProto.QueueName != "root.target" {
+        t.Fatalf("allocation for moved application not added to the new queue: %v", err)
+    }
+    if targetQueue.GetAllocatedResource().Resources[resources.MEMORY] != 4 || sourceQueue.GetAllocatedResource().Resources[resources.MEMORY] != 0 {
+        t.Errorf("allocation for moved application updated the wrong queue: %v, %v", targetQueue.GetAllocatedResource(), sourceQueue.GetAllocatedResource())
+    }
+}
+
 func TestCreateQueues(t *testing.T) {
     data := `
 partitions:
@@ -711,3 +1004,387 @@ partitions:
     }
 
 }
+
+func TestCreateQueuesWithTemplate(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: users
+          parent: true
+          properties:
+            application.sort.policy: fair
+          childtemplate:
+            resources:
+              max:
+                memory: 100
+              guaranteed:
+                memory: 10
+            properties:
+              preemption.preemptable: "false"
+            submitacl: "*"
+            maxapplications: 2
+        - name: other
+          parent: true
+          properties:
+            application.sort.policy: fifo
+`
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("partition create failed: %v", err)
+    }
+    err = partition.CreateQueues("root.users.sue")
+    if err != nil {
+        t.Fatalf("'root.users.sue' queue creation failed: %v", err)
+    }
+    queue := partition.getQueue("root.users.sue")
+    if queue == nil || queue.isManaged {
+        t.Fatalf("'root.users.sue' queue not created as an unmanaged queue: %v", queue)
+    }
+    if !resources.Equals(queue.MaxResource, resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 100})) {
+        t.Errorf("template max resource not applied, got %v", queue.MaxResource)
+    }
+    if !resources.Equals(queue.GuaranteedResource, resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 10})) {
+        t.Errorf("template guaranteed resource not applied, got %v", queue.GuaranteedResource)
+    }
+    if queue.Properties[ApplicationSortPolicy] != "fair" || queue.Properties[QueuePreemptable] != "false" {
+        t.Errorf("template properties not merged with the parent properties, got %v", queue.Properties)
+    }
+    if queue.GetMaxApplications() != 2 {
+        t.Errorf("template max applications not applied, expected 2 got %d", queue.GetMaxApplications())
+    }
+    submitACL, _ := queue.GetEffectiveACLs()
+    if !submitACL.AllowsAll() {
+        t.Errorf("template submit ACL not applied: %v", submitACL)
+    }
+
+    // the template is passed on through unmanaged parent queues
+    err = partition.CreateQueues("root.users.dev.bob")
+    if err != nil {
+        t.Fatalf("'root.users.dev.bob' queue creation failed: %v", err)
+    }
+    queue = partition.getQueue("root.users.dev.bob")
+    if queue == nil || queue.GetMaxApplications() != 2 {
+        t.Errorf("template not applied below unmanaged parent queue: %v", queue)
+    }
+
+    // queues below a parent without template have no limits but do get the parent properties
+    err = partition.CreateQueues("root.other.john")
+    if err != nil {
+        t.Fatalf("'root.other.john' queue creation failed: %v", err)
+    }
+    queue = partition.getQueue("root.other.john")
+    if queue == nil || queue.MaxResource != nil || queue.GetMaxApplications() != 0 {
+        t.Errorf("queue created without template should not have limits: %v", queue)
+    }
+    if queue.Properties[ApplicationSortPolicy] != "fifo" {
+        t.Errorf("queue created without template should have the parent properties, got %v", queue.Properties)
+    }
+
+    // changes to the template are re-applied on reload
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: users
+          parent: true
+          childtemplate:
+            resources:
+              max:
+                memory: 50
+        - name: other
+          parent: true
+`
+    conf, err := configs.LoadSchedulerConfigFromByteArray([]byte(data))
+    if err != nil {
+        t.Fatalf("config reload failed: %v", err)
+    }
+    err = partition.updatePartitionDetails(conf.Partitions[0])
+    if err != nil {
+        t.Fatalf("partition update failed: %v", err)
+    }
+    for _, name := range []string{"root.users.sue", "root.users.dev.bob"} {
+        queue = partition.getQueue(name)
+        if !resources.Equals(queue.MaxResource, resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 50})) {
+            t.Errorf("updated template max resource not applied to %s, got %v", name, queue.MaxResource)
+        }
+        if queue.GuaranteedResource != nil || queue.GetMaxApplications() != 0 {
+            t.Errorf("settings removed from the template not removed from %s: %v", name, queue)
+        }
+    }
+}
+
+func TestRelativeQueueResources(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: parent
+          resources:
+            max:
+              memory: 50%
+          queues:
+          - name: relative
+            resources:
+              guaranteed:
+                memory: 10%
+              max:
+                memory: 40%
+                vcore: 100
+          - name: absolute
+            resources:
+              max:
+                memory: 100
+          - name: partition
+            resources:
+              max:
+                memory: 30%partition
+        - name: unlimited
+          queues:
+          - name: child
+            resources:
+              max:
+                memory: 20%
+`
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("partition create failed: %v", err)
+    }
+    // no nodes: the percentages resolve to zero, not to unlimited
+    relative := partition.getQueue("root.parent.relative")
+    if memory, ok := relative.MaxResource.Resources[resources.MEMORY]; !ok || memory != 0 || relative.MaxResource.Resources[resources.VCORE] != 100 {
+        t.Errorf("relative max without partition resources incorrect: %v", relative.MaxResource)
+    }
+    if maxResource := partition.getQueue("root.parent.partition").MaxResource; maxResource == nil || !resources.IsZero(maxResource) {
+        t.Errorf("partition relative max without partition resources should be zero: %v", maxResource)
+    }
+
+    node1 := newNodeInfoForTest("node-1", resources.NewResourceFromMap(
+        map[string]resources.Quantity{resources.MEMORY: 1000}), nil)
+    if err = partition.addNewNode(node1, nil); err != nil {
+        t.Fatalf("add node to partition should not have failed: %v", err)
+    }
+    expected := map[string]resources.Quantity{
+        "root.parent":           500,
+        "root.parent.relative":  200,
+        "root.parent.absolute":  100,
+        "root.parent.partition": 300,
+        "root.unlimited.child":  200,
+    }
+    for name, memory := range expected {
+        if value := partition.getQueue(name).MaxResource.Resources[resources.MEMORY]; value != memory {
+            t.Errorf("max memory of queue %s incorrect after adding node, expected %d got %d", name, memory, value)
+        }
+    }
+    if value := relative.GuaranteedResource.Resources[resources.MEMORY]; value != 50 {
+        t.Errorf("guaranteed memory of relative queue incorrect, expected 50 got %d", value)
+    }
+
+    // the cluster grows: relative resources follow
+    node2 := newNodeInfoForTest("node-2", resources.NewResourceFromMap(
+        map[string]resources.Quantity{resources.MEMORY: 1000}), nil)
+    if err = partition.addNewNode(node2, nil); err != nil {
+        t.Fatalf("add node to partition should not have failed: %v", err)
+    }
+    if value := relative.MaxResource.Resources[resources.MEMORY]; value != 400 {
+        t.Errorf("max memory of relative queue incorrect after cluster growth, expected 400 got %d", value)
+    }
+    queueInfos := partition.GetQueueInfos()
+    var maxCapacity string
+    for _, parent := range queueInfos[0].ChildQueues {
+        for _, child := range parent.ChildQueues {
+            if child.QueueName == "relative" {
+                maxCapacity = child.Capacities.MaxCapacity
+            }
+        }
+    }
+    if maxCapacity != "[memory:400 vcore:100]" {
+        t.Errorf("resolved max capacity not exposed in the queue info, got '%s'", maxCapacity)
+    }
+
+    // the cluster shrinks
+    partition.RemoveNode("node-2")
+    if value := relative.MaxResource.Resources[resources.MEMORY]; value != 200 {
+        t.Errorf("max memory of relative queue incorrect after node removal, expected 200 got %d", value)
+    }
+}
+
+func TestQueueStateSurvivesReload(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: stopped
+        - name: drained
+`
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("partition create failed: %v", err)
+    }
+    if err = partition.GetQueue("root.stopped").ChangeState(Stop); err != nil {
+        t.Fatalf("stopping queue failed: %v", err)
+    }
+    if err = partition.GetQueue("root.drained").ChangeState(Drain); err != nil {
+        t.Fatalf("draining queue failed: %v", err)
+    }
+    conf, err := configs.LoadSchedulerConfigFromByteArray([]byte(data))
+    if err != nil {
+        t.Fatalf("config reload failed: %v", err)
+    }
+    if err = partition.updatePartitionDetails(conf.Partitions[0]); err != nil {
+        t.Fatalf("partition update failed: %v", err)
+    }
+    if status := GetQueueStatus(partition.GetQueue("root.stopped")); status != "STOPPED" {
+        t.Errorf("stopped queue state changed on reload: %s", status)
+    }
+    if status := GetQueueStatus(partition.GetQueue("root.drained")); status != "DRAINING" {
+        t.Errorf("drained queue state changed on reload: %s", status)
+    }
+    if partition.GetQueue("root.drained").IsMarkedForRemoval() {
+        t.Errorf("drained queue in the config should not be marked for removal")
+    }
+}
+
+func TestUserGroupCacheReplacedOnReload(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    usergroups:
+      resolver: none
+      positivettl: 17s
+    queues:
+      - name: root
+`
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("partition create failed: %v", err)
+    }
+    oldCache := partition.userGroupCache
+    conf, err := configs.LoadSchedulerConfigFromByteArray([]byte(strings.Replace(data, "17s", "19s", 1)))
+    if err != nil {
+        t.Fatalf("config reload failed: %v", err)
+    }
+    if err = partition.updatePartitionDetails(conf.Partitions[0]); err != nil {
+        t.Fatalf("partition update failed: %v", err)
+    }
+    if partition.userGroupCache == oldCache {
+        t.Fatal("user group cache not replaced after the resolver settings changed")
+    }
+    // the old cache is no longer used: it is stopped and a new cache is created for its settings
+    cache, err := security.GetUserGroupCacheFromConfig(security.ResolverConfig{Type: security.ResolverNone, PositiveTTL: 17 * time.Second})
+    if err != nil {
+        t.Fatalf("cache create failed: %v", err)
+    }
+    defer cache.Release()
+    if cache == oldCache {
+        t.Error("old user group cache was not released")
+    }
+}
+
+func TestEffectiveACLsUpdatedOnReload(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        submitacl: "sue,bob"
+        queues:
+        - name: parent
+          parent: true
+          queues:
+          - name: leaf
+`
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("partition create failed: %v", err)
+    }
+    leaf := partition.GetQueue("root.parent.leaf")
+    if !leaf.CheckSubmitAccess(security.UserGroup{User: "bob"}) {
+        t.Errorf("user bob should have been allowed by the root ACL")
+    }
+    conf, err := configs.LoadSchedulerConfigFromByteArray([]byte(strings.Replace(data, "sue,bob", "sue", 1)))
+    if err != nil {
+        t.Fatalf("config reload failed: %v", err)
+    }
+    if err = partition.updatePartitionDetails(conf.Partitions[0]); err != nil {
+        t.Fatalf("partition update failed: %v", err)
+    }
+    // the change on the root is passed down to all queues
+    if leaf.CheckSubmitAccess(security.UserGroup{User: "bob"}) {
+        t.Errorf("user bob removed from the root ACL should have been denied after the reload")
+    }
+    if !leaf.CheckSubmitAccess(security.UserGroup{User: "sue"}) {
+        t.Errorf("user sue should have been allowed after the reload")
+    }
+}
+
+func TestQueueReAddedToConfig(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+        - name: parent
+          queues:
+          - name: leaf
+        - name: stopped
+`
+    partition, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("partition create failed: %v", err)
+    }
+    if err = partition.GetQueue("root.stopped").ChangeState(Stop); err != nil {
+        t.Fatalf("stopping queue failed: %v", err)
+    }
+    conf, err := configs.LoadSchedulerConfigFromByteArray([]byte(data))
+    if err != nil {
+        t.Fatalf("config load failed: %v", err)
+    }
+    removed, err := configs.LoadSchedulerConfigFromByteArray([]byte(`
+partitions:
+  - name: default
+    queues:
+      - name: root
+`))
+    if err != nil {
+        t.Fatalf("config load failed: %v", err)
+    }
+    // remove the queues from the config: the queues are not empty yet
+    if err = partition.updatePartitionDetails(removed.Partitions[0]); err != nil {
+        t.Fatalf("partition update failed: %v", err)
+    }
+    leaf := partition.GetQueue("root.parent.leaf")
+    if !leaf.IsMarkedForRemoval() || !leaf.IsDraining() {
+        t.Fatalf("removed queue should be marked for removal and draining: %s", leaf.GetState())
+    }
+    // add the queues back: the flag is cleared and the queue runs again
+    if err = partition.updatePartitionDetails(conf.Partitions[0]); err != nil {
+        t.Fatalf("partition update failed: %v", err)
+    }
+    for _, name := range []string{"root.parent", "root.parent.leaf"} {
+        queue := partition.GetQueue(name)
+        if queue.IsMarkedForRemoval() || !queue.IsRunning() {
+            t.Errorf("queue %s added back to the config should be running, marked %v, state %s", name, queue.IsMarkedForRemoval(), queue.GetState())
+        }
+    }
+    if !leaf.IsAcceptingApplications() {
+        t.Errorf("queue added back to the config should accept applications")
+    }
+    if leaf.RemoveQueue() {
+        t.Errorf("queue added back to the config should not have been removed")
+    }
+    // the admin stopped queue keeps its state
+    stopped := partition.GetQueue("root.stopped")
+    if stopped.IsMarkedForRemoval() || !stopped.IsStopped() {
+        t.Errorf("stopped queue added back to the config should stay stopped, marked %v, state %s", stopped.IsMarkedForRemoval(), stopped.GetState())
+    }
+}
@@ -35,6 +35,15 @@ const (
     DotReplace = "_dot_"
     // How to sort applications, valid options are fair / fifo
     ApplicationSortPolicy = "application.sort.policy"
+    // Can allocations in the queue be preempted, valid options are true / false
+    QueuePreemptable = "preemption.preemptable"
+    // Can asks preempt lower priority allocations in the same queue, valid options are true / false
+    QueueIntraQueuePreemption = "preemption.intraqueue"
+    // Maximum number of allocations of one application in the queue that can be preempted at the same time
+    QueueDisruptionBudget = "preemption.disruptionbudget"
+    // Weight of the queue compared to its siblings when calculating the fair share, a positive number.
+    // The weight is relative to the siblings of the queue and is not inherited by child queues.
+    QueueWeight = "weight"
 )
 
 // The queue structure as used throughout the scheduler
@@ -53,9 +62,18 @@ type QueueInfo struct {
     // Private fields need protection
     adminACL          security.ACL         // admin ACL
     submitACL         security.ACL         // submit ACL
+    effectiveAdmin    security.ACL         // admin ACL narrowed by the parents, updated when an ACL changes
+    effectiveSubmit   security.ACL         // submit ACL narrowed by the parents, updated when an ACL changes
+    maxApplications   uint64               // maximum number of applications in the queue, 0 is unlimited
+    maxConfig         *resources.RelativeResource // configured max resources, can be relative to the parent
+    guaranteedConfig  *resources.RelativeResource // configured guaranteed resources, can be relative to the parent
+    template          *childTemplate       // template for unmanaged child queues (parent queue only)
     allocatedResource *resources.Resource   // set based on allocation
+    fairShare         *resources.Resource   // fair share of the queue, set by the scheduler
     isLeaf            bool                  // this is a leaf queue or not (i.e. parent)
     isManaged         bool                  // queue is part of the config, not auto created
+    markedForRemoval  bool                  // managed queue is removed from the config
+    drainedByRemoval  bool                  // managed queue is draining because it was removed from the config
     stateMachine      *fsm.FSM              // the state of the queue for scheduling
     stateTime         time.Time             // last time the state was updated (needed for cleanup)
     children          map[string]*QueueInfo // list of direct children
@@ -69,7 +87,7 @@ func NewManagedQueue(conf configs.QueueConfig, parent *QueueInfo) (*QueueInfo, e
         Parent:            parent,
         isManaged:         true,
         isLeaf:            !conf.Parent,
-        stateMachine:      newObjectState(),
+        stateMachine:      newQueueState(),
         allocatedResource: resources.NewResource(),
     }
 
@@ -85,6 +103,7 @@ func NewManagedQueue(conf configs.QueueConfig, parent *QueueInfo) (*QueueInfo, e
             return nil, fmt.Errorf("queue creation failed: %s", err)
         }
     }
+    qi.updateEffectiveACLs()
 
     qi.metrics = metrics.InitQueueMetrics(conf.Name)
     log.Logger().Debug("queue added",
@@ -105,16 +124,16 @@ func NewUnmanagedQueue(name string, leaf bool, parent *QueueInfo) (*QueueInfo, e
     qi := &QueueInfo{Name: strings.ToLower(name),
         Parent:            parent,
         isLeaf:            leaf,
-        stateMachine:      newObjectState(),
+        stateMachine:      newQueueState(),
         allocatedResource: resources.NewResource(),
     }
-    // TODO set resources and properties on unmanaged queues
-    // add the queue in the structure
+    // add the queue in the structure and apply the template of the parent
     if parent != nil {
         err := parent.AddChildQueue(qi)
         if err != nil {
             return nil, fmt.Errorf("queue creation failed: %s", err)
         }
+        qi.applyTemplate(parent.getTemplate())
     }
 
     qi.metrics = metrics.InitQueueMetrics(name)
@@ -144,6 +163,23 @@ func (qi *QueueInfo) GetAllocatedResource() *resources.Resource {
     return qi.allocatedResource
 }
 
+// Return the fair share of the queue as last calculated by the scheduler.
+// Returns nil if the fair share has not been calculated.
+func (qi *QueueInfo) GetFairShare() *resources.Resource {
+    qi.lock.RLock()
+    defer qi.lock.RUnlock()
+
+    return qi.fairShare
+}
+
+// Set the fair share of the queue, called by the scheduler for each scheduling cycle.
+func (qi *QueueInfo) SetFairShare(fairShare *resources.Resource) {
+    qi.lock.Lock()
+    defer qi.lock.Unlock()
+
+    qi.fairShare = fairShare
+}
+
 // Return if this is a leaf queue or not
 func (qi *QueueInfo) IsLeafQueue() bool {
     return qi.isLeaf
@@ -185,34 +221,51 @@ func (qi *QueueInfo) AddChildQueue(child *QueueInfo) error {
 }
 
 // Increment the allocated resources for this queue (recursively)
-// Guard against going over max resources if the
+// Guard against going over max resources: the allocation is only added if it fits in the maximum of this queue and
+// all its parents. Allocations reported by a node are always added as they are already running, even if they push
+// the queue over its maximum.
 func (qi *QueueInfo) IncAllocatedResource(alloc *resources.Resource, nodeReported bool) error {
     qi.lock.Lock()
     defer qi.lock.Unlock()
 
     // check this queue: failure stops checks if the allocation is not part of a node addition
     newAllocation := resources.Add(qi.allocatedResource, alloc)
-    if !nodeReported {
-        if qi.MaxResource != nil && !resources.FitIn(qi.MaxResource, newAllocation) {
-            return fmt.Errorf("allocation (%v) puts queue %s over maximum allocation (%v)",
-                alloc, qi.GetQueuePath(), qi.MaxResource)
-        }
+    overMax := qi.MaxResource != nil && !resources.FitIn(qi.MaxResource, newAllocation)
+    if overMax && !nodeReported {
+        return fmt.Errorf("allocation (%v) puts queue %s over maximum allocation (%v)",
+            alloc, qi.GetQueuePath(), qi.MaxResource)
     }
     // check the parent: need to pass before updating
     if qi.Parent != nil {
         if err := qi.Parent.IncAllocatedResource(alloc, nodeReported); err != nil {
             log.Logger().Error("parent queue exceeds maximum resource",
-                zap.Any("allocationId", alloc),
-                zap.Any("maxResource", qi.MaxResource),
+                zap.String("queue", qi.GetQueuePath()),
+                zap.Any("allocation", alloc),
                 zap.Error(err))
             return err
         }
     }
+    if overMax {
+        log.Logger().Warn("node reported allocation puts queue over maximum resource",
+            zap.String("queue", qi.GetQueuePath()),
+            zap.Any("allocation", alloc),
+            zap.Any("maxResource", qi.MaxResource))
+    }
     // all OK update this queue
     qi.allocatedResource = newAllocation
     return nil
 }
 
+// Is the queue using more than its maximum resources?
+// This can only happen if allocations reported by a node were added or the maximum was lowered by a configuration
+// change. No new allocations are accepted for the queue until it is back within its maximum.
+func (qi *QueueInfo) IsOverMax() bool {
+    qi.lock.RLock()
+    defer qi.lock.RUnlock()
+
+    return qi.MaxResource != nil && !resources.FitIn(qi.MaxResource, qi.allocatedResource)
+}
+
 // Decrement the allocated resources for this queue (recursively)
 // Guard against going below zero resources.
 func (qi *QueueInfo) DecAllocatedResource(alloc *resources.Resource) error {
@@ -239,6 +292,57 @@ func (qi *QueueInfo) DecAllocatedResource(alloc *resources.Resource) error {
     return nil
 }
 
+// Move allocated resources from one queue to another queue in the same hierarchy.
+// Only the queues below the common parent of the two queues are updated: the allocated resources of the common parent
+// and its parents do not change. The resources are only moved if they fit in the maximum of all target queues below the
+// common parent, nothing is updated if the check fails.
+//
+// NOTE: this call is not atomic for readers of the queues. It should only be called holding the PartitionInfo lock.
+func moveAllocatedResource(source, target *QueueInfo, alloc *resources.Resource) error {
+    parent := getCommonParent(source, target)
+    // check the source queues can release and the target queues can take the resources before updating
+    for queue := source; queue != parent; queue = queue.Parent {
+        if allocated := queue.GetAllocatedResource(); !resources.FitIn(allocated, alloc) {
+            return fmt.Errorf("moved allocation (%v) is larger than queue %s allocation (%v)",
+                alloc, queue.GetQueuePath(), allocated)
+        }
+    }
+    for queue := target; queue != parent; queue = queue.Parent {
+        maxResource := queue.GetMaxResource()
+        if newAllocation := resources.Add(queue.GetAllocatedResource(), alloc); maxResource != nil && !resources.FitIn(maxResource, newAllocation) {
+            return fmt.Errorf("moved allocation (%v) puts queue %s over maximum allocation (%v)",
+                alloc, queue.GetQueuePath(), maxResource)
+        }
+    }
+    // all OK update the queues
+    for queue := source; queue != parent; queue = queue.Parent {
+        queue.lock.Lock()
+        queue.allocatedResource = resources.Sub(queue.allocatedResource, alloc)
+        queue.lock.Unlock()
+    }
+    for queue := target; queue != parent; queue = queue.Parent {
+        queue.lock.Lock()
+        queue.allocatedResource = resources.Add(queue.allocatedResource, alloc)
+        queue.lock.Unlock()
+    }
+    return nil
+}
+
+// Get the closest queue that is a parent of, or the same as, both queues.
+// Returns nil if the queues are not part of the same hierarchy.
+func getCommonParent(left, right *QueueInfo) *QueueInfo {
+    parents := make(map[*QueueInfo]bool)
+    for queue := left; queue != nil; queue = queue.Parent {
+        parents[queue] = true
+    }
+    for queue := right; queue != nil; queue = queue.Parent {
+        if parents[queue] {
+            return queue
+        }
+    }
+    return nil
+}
+
 func (qi *QueueInfo) GetCopyOfChildren() map[string]*QueueInfo {
     qi.lock.RLock()
     defer qi.lock.RUnlock()
@@ -267,8 +371,8 @@ func (qi *QueueInfo) removeChildQueue(name string) {
 func (qi *QueueInfo) RemoveQueue() bool {
     qi.lock.Lock()
     defer qi.lock.Unlock()
-    // cannot remove a managed queue that is running
-    if qi.isManaged && qi.IsRunning() {
+    // cannot remove a managed queue that is still part of the config, even if it was stopped or drained
+    if qi.isManaged && !qi.markedForRemoval {
         return false
     }
     // cannot remove a queue that has children or allocated resources
@@ -294,6 +398,12 @@ func (qi *QueueInfo) MarkQueueForRemoval() {
     if qi.isManaged {
         log.Logger().Info("marking managed queue for deletion",
             zap.String("queue", qi.GetQueuePath()))
+        // a stopped or drained queue keeps its state when it is added back to the config
+        if !qi.markedForRemoval {
+            qi.drainedByRemoval = qi.IsRunning()
+        }
+        qi.markedForRemoval = true
+        // a stopped queue stays stopped: it is removed when empty
         if err := qi.HandleQueueEvent(Remove); err != nil {
             log.Logger().Info("failed to marking managed queue for deletion",
                 zap.String("queue", qi.GetQueuePath()),
@@ -307,6 +417,28 @@ func (qi *QueueInfo) MarkQueueForRemoval() {
     }
 }
 
+// Undo the removal of a managed queue that is added back to the config before it was removed.
+// A queue that was draining because of the removal is started again, a queue that was stopped or drained by an admin
+// keeps its state.
+func (qi *QueueInfo) unmarkQueueForRemoval() {
+    qi.lock.Lock()
+    defer qi.lock.Unlock()
+    if !qi.markedForRemoval {
+        return
+    }
+    log.Logger().Info("managed queue added back to the config",
+        zap.String("queue", qi.GetQueuePath()))
+    qi.markedForRemoval = false
+    if qi.drainedByRemoval {
+        qi.drainedByRemoval = false
+        if err := qi.HandleQueueEvent(Start); err != nil {
+            log.Logger().Info("failed to restart managed queue",
+                zap.String("queue", qi.GetQueuePath()),
+                zap.Error(err))
+        }
+    }
+}
+
 // Update an existing managed queue based on the updated configuration
 func (qi *QueueInfo) updateQueueProps(conf configs.QueueConfig) error {
     // Set the ACLs
@@ -323,6 +455,7 @@ func (qi *QueueInfo) updateQueueProps(conf configs.QueueConfig) error {
             zap.Error(err))
         return err
     }
+    qi.maxApplications = conf.MaxApplications
     // Change from unmanaged to managed
     if !qi.isManaged {
         log.Logger().Info("changed un-managed queue to managed",
@@ -335,26 +468,28 @@ func (qi *QueueInfo) updateQueueProps(conf configs.QueueConfig) error {
         qi.isLeaf = false
     }
 
-    // Load the max resources
-    maxResource, err := resources.NewResourceFromConf(conf.Resources.Max)
+    // Load the max resources: percentages are resolved against the parent or the partition
+    maxResource, err := resources.NewRelativeResourceFromConf(conf.Resources.Max)
     if err != nil {
         log.Logger().Error("parsing failed on max resources this should not happen",
             zap.Error(err))
         return err
     }
-    if len(maxResource.Resources) != 0 {
-        qi.MaxResource = maxResource
+    qi.maxConfig = maxResource
+    if !maxResource.IsEmpty() {
+        qi.MaxResource = maxResource.Resolve(qi.getParentMaxResource(), qi.getPartitionResource())
     }
 
-    // Load the guaranteed resources
-    guaranteedResource, err := resources.NewResourceFromConf(conf.Resources.Guaranteed)
+    // Load the guaranteed resources: percentages are resolved against the parent or the partition
+    guaranteedResource, err := resources.NewRelativeResourceFromConf(conf.Resources.Guaranteed)
     if err != nil {
         log.Logger().Error("parsing failed on max resources this should not happen",
             zap.Error(err))
         return err
     }
-    if len(guaranteedResource.Resources) != 0 {
-        qi.GuaranteedResource = guaranteedResource
+    qi.guaranteedConfig = guaranteedResource
+    if !guaranteedResource.IsEmpty() {
+        qi.GuaranteedResource = guaranteedResource.Resolve(qi.getParentMaxResource(), qi.getPartitionResource())
     }
 
     // Update Properties
@@ -363,6 +498,15 @@ func (qi *QueueInfo) updateQueueProps(conf configs.QueueConfig) error {
         qi.Properties = mergeProperties(qi.Parent.Properties, conf.Properties)
     }
 
+    // Load the template and (re)apply it to the unmanaged children
+    template, err := newChildTemplate(conf.ChildTemplate)
+    if err != nil {
+        log.Logger().Error("parsing child template failed this should not happen",
+            zap.Error(err))
+        return err
+    }
+    qi.setTemplate(template)
+
     return nil
 }
 
@@ -371,6 +515,10 @@ func mergeProperties(parent map[string]string, child map[string]string) map[stri
     merged := make(map[string]string)
     if parent != nil && len(parent) > 0 {
         for key, value := range parent {
+            // the weight only has a meaning compared to the siblings of the queue
+            if key == QueueWeight {
+                continue
+            }
             merged[key] = value
         }
     }
@@ -382,8 +530,8 @@ func mergeProperties(parent map[string]string, child map[string]string) map[stri
     return merged
 }
 
-// Is the queue marked for deletion and can only handle existing application requests.
-// No new applications will be accepted.
+// Is the queue draining and can only handle existing application requests.
+// No new applications will be accepted. A queue drains when it is marked for deletion or is drained by an admin.
 func (qi *QueueInfo) IsDraining() bool {
     return qi.stateMachine.Current() == Draining.String()
 }
@@ -398,25 +546,208 @@ func (qi *QueueInfo) IsStopped() bool {
     return qi.stateMachine.Current() == Stopped.String()
 }
 
-// Check if the user has access to the queue to submit an application recursively.
-// This will check the submit ACL and the admin ACL.
-func (qi *QueueInfo) CheckSubmitAccess(user security.UserGroup) bool {
+// Is the managed queue removed from the configuration and will it be removed when empty.
+func (qi *QueueInfo) IsMarkedForRemoval() bool {
     qi.lock.RLock()
-    allow := qi.submitACL.CheckAccess(user) || qi.adminACL.CheckAccess(user)
-    qi.lock.RUnlock()
-    if !allow && qi.Parent != nil {
-        allow = qi.Parent.CheckSubmitAccess(user)
+    defer qi.lock.RUnlock()
+    return qi.markedForRemoval
+}
+
+// Get the current state of the queue.
+func (qi *QueueInfo) GetState() string {
+    return qi.stateMachine.Current()
+}
+
+// Can new applications be added to the queue: the queue and all its parents must be active.
+func (qi *QueueInfo) IsAcceptingApplications() bool {
+    for queue := qi; queue != nil; queue = queue.Parent {
+        if !queue.IsRunning() {
+            return false
+        }
     }
-    return allow
+    return true
 }
 
-// Check if the user has access to the queue for admin actions recursively.
+// Is the queue or one of its parents stopped. Nothing is scheduled for a queue in a stopped hierarchy.
+func (qi *QueueInfo) IsStoppedInHierarchy() bool {
+    for queue := qi; queue != nil; queue = queue.Parent {
+        if queue.IsStopped() {
+            return true
+        }
+    }
+    return false
+}
+
+// Change the state of the queue on request of an admin:
+// - Stop: no new applications are accepted and nothing is scheduled for the queue and its children
+// - Drain: no new applications are accepted, existing applications in the queue are still scheduled
+// - Start: the queue returns to the normal active state
+// A queue that is marked for removal cannot be started. The state is not changed by a configuration reload.
+func (qi *QueueInfo) ChangeState(event SchedulingObjectEvent) error {
+    if event != Start && event != Stop && event != Drain {
+        return fmt.Errorf("unsupported state change %s for queue %s", event, qi.GetQueuePath())
+    }
+    qi.lock.Lock()
+    defer qi.lock.Unlock()
+    if event == Start && qi.markedForRemoval {
+        return fmt.Errorf("queue %s is marked for removal and cannot be started", qi.GetQueuePath())
+    }
+    if err := qi.HandleQueueEvent(event); err != nil {
+        return fmt.Errorf("state change %s for queue %s failed: %v", event, qi.GetQueuePath(), err)
+    }
+    // the admin state is kept when the queue is added back to the config
+    qi.drainedByRemoval = false
+    log.Logger().Info("queue state changed",
+        zap.String("queue", qi.GetQueuePath()),
+        zap.String("event", event.String()),
+        zap.String("state", qi.GetState()))
+    return nil
+}
+
+// Check if the user has access to the queue to submit an application.
+// This will check the effective submit ACL and the effective admin ACL: admin access also allows submitting.
+func (qi *QueueInfo) CheckSubmitAccess(user security.UserGroup) bool {
+    submitACL, adminACL := qi.GetEffectiveACLs()
+    return submitACL.CheckAccess(user) || adminACL.CheckAccess(user)
+}
+
+// Check if the user has access to the queue for admin actions.
 func (qi *QueueInfo) CheckAdminAccess(user security.UserGroup) bool {
+    _, adminACL := qi.GetEffectiveACLs()
+    return adminACL.CheckAccess(user)
+}
+
+// Get the submit and admin ACLs that are in effect for the queue.
+// The ACLs of the queue are narrowed by the effective ACLs of the parent: a queue can limit the access the parent
+// allows but never widen it. A queue without ACLs uses the effective ACLs of the parent.
+func (qi *QueueInfo) GetEffectiveACLs() (security.ACL, security.ACL) {
+    qi.lock.RLock()
+    defer qi.lock.RUnlock()
+    return qi.effectiveSubmit, qi.effectiveAdmin
+}
+
+// Calculate the effective ACLs for the queue and its children, recursively.
+// This must be called when the ACLs of the queue or one of its parents change, like on a configuration reload.
+func (qi *QueueInfo) updateEffectiveACLs() {
+    var parentSubmit, parentAdmin security.ACL
+    if qi.Parent != nil {
+        parentSubmit, parentAdmin = qi.Parent.GetEffectiveACLs()
+    }
+    qi.lock.Lock()
+    qi.effectiveSubmit = parentSubmit.Narrow(qi.submitACL)
+    qi.effectiveAdmin = parentAdmin.Narrow(qi.adminACL)
+    qi.lock.Unlock()
+    for _, child := range qi.GetCopyOfChildren() {
+        child.updateEffectiveACLs()
+    }
+}
+
+// Get the maximum number of applications allowed in the queue, 0 means unlimited.
+func (qi *QueueInfo) GetMaxApplications() uint64 {
     qi.lock.RLock()
-    allow := qi.adminACL.CheckAccess(user)
-    qi.lock.RUnlock()
-    if !allow && qi.Parent != nil {
-        allow = qi.Parent.CheckAdminAccess(user)
+    defer qi.lock.RUnlock()
+    return qi.maxApplications
+}
+
+func (qi *QueueInfo) getTemplate() *childTemplate {
+    qi.lock.RLock()
+    defer qi.lock.RUnlock()
+    return qi.template
+}
+
+// Set the template for the child queues and apply it to the existing unmanaged child queues.
+func (qi *QueueInfo) setTemplate(template *childTemplate) {
+    qi.lock.Lock()
+    qi.template = template
+    qi.lock.Unlock()
+    for _, child := range qi.GetCopyOfChildren() {
+        if !child.IsManaged() {
+            child.applyTemplate(template)
+        }
+    }
+}
+
+// Apply the template of the parent to this unmanaged queue.
+// An unmanaged parent queue passes the template on to its own children. A nil template removes all settings
+// that were applied before. The properties of the parent are always merged in, with or without a template.
+func (qi *QueueInfo) applyTemplate(template *childTemplate) {
+    qi.lock.Lock()
+    if template == nil {
+        qi.maxConfig = nil
+        qi.guaranteedConfig = nil
+        qi.MaxResource = nil
+        qi.GuaranteedResource = nil
+        qi.Properties = mergeProperties(qi.Parent.Properties, nil)
+        qi.submitACL = security.ACL{}
+        qi.adminACL = security.ACL{}
+        qi.maxApplications = 0
+    } else {
+        qi.maxConfig = template.maxResource
+        qi.guaranteedConfig = template.guaranteedResource
+        qi.MaxResource = template.maxResource.Resolve(qi.getParentMaxResource(), qi.getPartitionResource())
+        qi.GuaranteedResource = template.guaranteedResource.Resolve(qi.getParentMaxResource(), qi.getPartitionResource())
+        qi.Properties = mergeProperties(qi.Parent.Properties, template.properties)
+        qi.submitACL = template.submitACL
+        qi.adminACL = template.adminACL
+        qi.maxApplications = template.maxApplications
+    }
+    leaf := qi.isLeaf
+    qi.lock.Unlock()
+    qi.updateEffectiveACLs()
+    if !leaf {
+        qi.setTemplate(template)
+    }
+}
+
+// Get the max resources the relative resources of the queue are resolved against: the max resources of the closest
+// parent that has a max set. The max resources of the root queue are the total resources of the partition.
+func (qi *QueueInfo) getParentMaxResource() *resources.Resource {
+    for parent := qi.Parent; parent != nil; parent = parent.Parent {
+        if maxResource := parent.GetMaxResource(); maxResource != nil {
+            return maxResource
+        }
+    }
+    return nil
+}
+
+// Get the total resources of the partition the queue is part of: the max resources of the root queue.
+func (qi *QueueInfo) getPartitionResource() *resources.Resource {
+    root := qi
+    for root.Parent != nil {
+        root = root.Parent
+    }
+    return root.GetMaxResource()
+}
+
+// Get the max resources of the queue, nil if the queue has no max set.
+func (qi *QueueInfo) GetMaxResource() *resources.Resource {
+    qi.lock.RLock()
+    defer qi.lock.RUnlock()
+    return qi.MaxResource
+}
+
+// Get the guaranteed resources of the queue, nil if the queue has no guaranteed resources set.
+func (qi *QueueInfo) GetGuaranteedResource() *resources.Resource {
+    qi.lock.RLock()
+    defer qi.lock.RUnlock()
+    return qi.GuaranteedResource
+}
+
+// Resolve the relative max and guaranteed resources for the children of the queue, recursively.
+// This must be called when the max resources of the queue change, like when the partition resources change for the
+// root queue.
+func (qi *QueueInfo) updateRelativeResources() {
+    partition := qi.getPartitionResource()
+    for _, child := range qi.GetCopyOfChildren() {
+        base := child.getParentMaxResource()
+        child.lock.Lock()
+        if child.maxConfig.IsRelative() {
+            child.MaxResource = child.maxConfig.Resolve(base, partition)
+        }
+        if child.guaranteedConfig.IsRelative() {
+            child.GuaranteedResource = child.guaranteedConfig.Resolve(base, partition)
+        }
+        child.lock.Unlock()
+        child.updateRelativeResources()
     }
-    return allow
 }
\ No newline at end of file
@@ -19,6 +19,7 @@ package cache
 import (
     "github.com/cloudera/yunikorn-core/pkg/common/configs"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/common/security"
     "strconv"
     "testing"
 )
@@ -136,6 +137,56 @@ func TestAllocationCalcSub(t *testing.T) {
     }
 }
 
+func TestAllocationOverMax(t *testing.T) {
+    // create the root
+    root, err := createRootQueue()
+    if err != nil {
+        t.Fatalf("failed to create basic root queue: %v", err)
+    }
+    parent, err := createManagedQueue(root, "parent", true)
+    if err != nil {
+        t.Fatalf("failed to create parent queue: %v", err)
+    }
+    leaf, err := createManagedQueue(parent, "leaf", false)
+    if err != nil {
+        t.Fatalf("failed to create leaf queue: %v", err)
+    }
+    parent.MaxResource, _ = resources.NewResourceFromConf(map[string]string{"memory": "10"})
+    allocation, _ := resources.NewResourceFromConf(map[string]string{"memory": "6"})
+
+    err = leaf.IncAllocatedResource(allocation, false)
+    if err != nil {
+        t.Errorf("leaf queue allocation failed on increment %v", err)
+    }
+    // the parent max is enforced for the leaf and nothing is updated
+    err = leaf.IncAllocatedResource(allocation, false)
+    if err == nil {
+        t.Errorf("leaf queue allocation should have failed on the parent max")
+    }
+    if leaf.GetAllocatedResource().Resources[resources.MEMORY] != 6 || root.GetAllocatedResource().Resources[resources.MEMORY] != 6 {
+        t.Errorf("failed allocation changed the queue usage: leaf %v, root %v", leaf.GetAllocatedResource(), root.GetAllocatedResource())
+    }
+    if parent.IsOverMax() || leaf.IsOverMax() {
+        t.Errorf("queues should not be over max")
+    }
+
+    // node reported allocations are always added
+    err = leaf.IncAllocatedResource(allocation, true)
+    if err != nil {
+        t.Errorf("node reported allocation failed on increment %v", err)
+    }
+    if !parent.IsOverMax() || leaf.IsOverMax() || root.IsOverMax() {
+        t.Errorf("only the parent queue should be over max")
+    }
+    err = leaf.DecAllocatedResource(allocation)
+    if err != nil {
+        t.Errorf("leaf queue allocation failed on decrement %v", err)
+    }
+    if parent.IsOverMax() {
+        t.Errorf("parent queue should be back within max: %v", parent.GetAllocatedResource())
+    }
+}
+
 func TestManagedSubQueues(t *testing.T) {
     // create the root
     root, err := createRootQueue()
@@ -312,4 +363,129 @@ func TestGetChildQueueInfos(t *testing.T) {
     if len(root.children) != 2 {
         t.Errorf("parent queues are not added to the root queue, expected 2 children got %d", len(root.children))
     }
-}
\ No newline at end of file
+}
+
+// child queue ACLs can only narrow the access allowed by the parent
+func TestEffectiveACLs(t *testing.T) {
+    rootConf := configs.QueueConfig{
+        Name:      "root",
+        Parent:    true,
+        SubmitACL: "sue,bob dev",
+        AdminACL:  "* !contractors",
+    }
+    root, err := NewManagedQueue(rootConf, nil)
+    if err != nil {
+        t.Fatalf("failed to create root queue with ACLs: %v", err)
+    }
+    // no ACL set: inherits the parent
+    inherit, err := createManagedQueue(root, "inherit", false)
+    if err != nil {
+        t.Fatalf("failed to create managed queue: %v", err)
+    }
+    leafConf := configs.QueueConfig{
+        Name:      "narrow",
+        SubmitACL: "sue,john,!bob test,dev",
+        AdminACL:  "admin",
+    }
+    narrow, err := NewManagedQueue(leafConf, root)
+    if err != nil {
+        t.Fatalf("failed to create managed queue with ACLs: %v", err)
+    }
+
+    sue := security.UserGroup{User: "sue"}
+    bob := security.UserGroup{User: "bob"}
+    john := security.UserGroup{User: "john"}
+    dev := security.UserGroup{User: "other", Groups: []string{"dev"}}
+    admin := security.UserGroup{User: "admin"}
+    contractor := security.UserGroup{User: "admin", Groups: []string{"contractors"}}
+    if !inherit.CheckSubmitAccess(bob) || !inherit.CheckSubmitAccess(dev) {
+        t.Errorf("queue without ACLs should allow the users allowed by the parent")
+    }
+    if !narrow.CheckSubmitAccess(sue) || !narrow.CheckSubmitAccess(dev) {
+        t.Errorf("users allowed by the child and the parent should have been allowed")
+    }
+    if narrow.CheckSubmitAccess(bob) {
+        t.Errorf("user bob denied by the child should have been denied")
+    }
+    if narrow.CheckSubmitAccess(john) {
+        t.Errorf("user john not allowed by the parent should have been denied")
+    }
+    if !narrow.CheckAdminAccess(admin) || !narrow.CheckSubmitAccess(admin) {
+        t.Errorf("admin user should have admin and submit access")
+    }
+    if narrow.CheckAdminAccess(contractor) || narrow.CheckSubmitAccess(contractor) {
+        t.Errorf("deny on the parent should have been applied to the child")
+    }
+
+    submitACL, adminACL := narrow.GetEffectiveACLs()
+    if users := submitACL.AllowedUsers(); len(users) != 1 || users[0] != "sue" {
+        t.Errorf("effective submit users incorrect, expected [sue] got %v", users)
+    }
+    if groups := submitACL.AllowedGroups(); len(groups) != 1 || groups[0] != "dev" {
+        t.Errorf("effective submit groups incorrect, expected [dev] got %v", groups)
+    }
+    if adminACL.AllowsAll() || len(adminACL.DeniedGroups()) != 1 {
+        t.Errorf("effective admin ACL incorrect: %v", adminACL)
+    }
+}
+
+func TestQueueChangeState(t *testing.T) {
+    root, err := createRootQueue()
+    if err != nil {
+        t.Fatalf("failed to create basic root queue: %v", err)
+    }
+    parent, err := createManagedQueue(root, "parent", true)
+    if err != nil {
+        t.Fatalf("failed to create managed parent queue: %v", err)
+    }
+    leaf, err := createManagedQueue(parent, "leaf", false)
+    if err != nil {
+        t.Fatalf("failed to create managed leaf queue: %v", err)
+    }
+    if !leaf.IsAcceptingApplications() {
+        t.Errorf("new queue should accept applications")
+    }
+
+    // stop the parent: the leaf does not accept applications
+    if err = parent.ChangeState(Stop); err != nil || !parent.IsStopped() {
+        t.Fatalf("stopping queue failed: %v", err)
+    }
+    if leaf.IsAcceptingApplications() || !leaf.IsRunning() {
+        t.Errorf("leaf queue should not accept applications when the parent is stopped")
+    }
+    if !leaf.IsStoppedInHierarchy() {
+        t.Errorf("leaf queue should be stopped in the hierarchy when the parent is stopped")
+    }
+    // drain the leaf and start the parent
+    if err = leaf.ChangeState(Drain); err != nil || !leaf.IsDraining() {
+        t.Fatalf("draining queue failed: %v", err)
+    }
+    if err = parent.ChangeState(Start); err != nil || !parent.IsRunning() {
+        t.Fatalf("starting queue failed: %v", err)
+    }
+    if leaf.IsAcceptingApplications() {
+        t.Errorf("draining queue should not accept applications")
+    }
+    // an admin drained queue is not removed
+    if leaf.RemoveQueue() {
+        t.Errorf("drained managed queue in the config should not have been removed")
+    }
+    if err = leaf.ChangeState(Start); err != nil || !leaf.IsAcceptingApplications() {
+        t.Errorf("started queue should accept applications: %v", err)
+    }
+    if err = leaf.ChangeState(Remove); err == nil {
+        t.Errorf("remove is not an admin state change and should have failed")
+    }
+
+    // a queue marked for removal cannot be started
+    leaf.MarkQueueForRemoval()
+    if err = leaf.ChangeState(Start); err == nil {
+        t.Errorf("starting a queue marked for removal should have failed")
+    }
+    if err = leaf.ChangeState(Stop); err != nil {
+        t.Errorf("stopping a queue marked for removal should not have failed: %v", err)
+    }
+    if !leaf.RemoveQueue() {
+        t.Errorf("empty queue marked for removal should have been removed")
+    }
+}
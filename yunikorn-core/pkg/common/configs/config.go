@@ -25,6 +25,7 @@ import (
     "io/ioutil"
     "os"
     "path"
+    "time"
 )
 
 // The configuration can contain multiple partitions. Each partition contains the queue definition for a logical
@@ -37,13 +38,85 @@ type SchedulerConfig struct {
 type PartitionConfig struct {
     Name           string
     Queues         []QueueConfig
-    PlacementRules []PlacementRule           `yaml:",omitempty" json:",omitempty"`
-    Users          []User                    `yaml:",omitempty" json:",omitempty"`
-    Preemption     PartitionPreemptionConfig `yaml:",omitempty" json:",omitempty"`
+    PlacementRules []PlacementRule             `yaml:",omitempty" json:",omitempty"`
+    Users          []User                      `yaml:",omitempty" json:",omitempty"`
+    Preemption     PartitionPreemptionConfig   `yaml:",omitempty" json:",omitempty"`
+    Applications   PartitionApplicationsConfig `yaml:",omitempty" json:",omitempty"`
+    Nodes          PartitionNodesConfig        `yaml:",omitempty" json:",omitempty"`
+    UserGroups     UserGroupResolverConfig     `yaml:",omitempty" json:",omitempty"`
 }
 
+// The preemption settings for a partition:
+// - enabled flag: preemption is only run when enabled
+// - list of preemption policies to run in order, the DRF policy is used when no policies are set
+// - order in which victims are selected on a node, youngest allocation first when not set
+// - minimum time an allocation must have run before it can be preempted
+// - maximum resources preempted in one preemption cycle as a resource object, unlimited when not set
+// - grace period the RM gives preempted allocations to terminate
+// - timeout after which a preemption is cancelled if the preempted allocations have not been released
 type PartitionPreemptionConfig struct {
-    Enabled bool
+    Enabled     bool
+    Policies    []string          `yaml:",omitempty" json:",omitempty"`
+    VictimOrder string            `yaml:",omitempty" json:",omitempty"`
+    MinRuntime  time.Duration     `yaml:",omitempty" json:",omitempty"`
+    MaxPerCycle map[string]string `yaml:",omitempty" json:",omitempty"`
+    GracePeriod time.Duration     `yaml:",omitempty" json:",omitempty"`
+    Timeout     time.Duration     `yaml:",omitempty" json:",omitempty"`
+}
+
+// The application lifecycle settings for a partition:
+// - time an application can stay in the starting state before it is considered running
+// - time an application stays in the completing state before it is completed and removed
+// The defaults are used when the timeouts are not set.
+type PartitionApplicationsConfig struct {
+    StartingTimeout   time.Duration `yaml:",omitempty" json:",omitempty"`
+    CompletingTimeout time.Duration `yaml:",omitempty" json:",omitempty"`
+}
+
+// The node evaluation settings for a partition:
+// - number of nodes evaluated in parallel
+// - percentage of the nodes to find as feasible before scoring, adaptive when not set
+// - weights of the node scorers by name
+type PartitionNodesConfig struct {
+    Parallelism              int              `yaml:",omitempty" json:",omitempty"`
+    PercentageOfNodesToScore int32            `yaml:",omitempty" json:",omitempty"`
+    Scoring                  map[string]int32 `yaml:",omitempty" json:",omitempty"`
+}
+
+// The user and group resolution settings for a partition:
+// - type of resolver: none, os, ldap or file, no resolution when not set
+// - time a resolved user is cached, the default is used when not set
+// - time a failed user resolution is cached, the default is used when not set
+// - the LDAP server settings for the ldap resolver
+// - the YAML file with the users and their groups for the file resolver
+type UserGroupResolverConfig struct {
+    Resolver    string        `yaml:",omitempty" json:",omitempty"`
+    PositiveTTL time.Duration `yaml:",omitempty" json:",omitempty"`
+    NegativeTTL time.Duration `yaml:",omitempty" json:",omitempty"`
+    LDAP        LDAPConfig    `yaml:",omitempty" json:",omitempty"`
+    File        string        `yaml:",omitempty" json:",omitempty"`
+}
+
+// The LDAP server settings to resolve users and groups:
+// - URL of the server using the ldap or ldaps (TLS) scheme
+// - upgrade an ldap connection to TLS using StartTLS
+// - file with the CA certificates to verify the server, system CAs are used if not set
+// - skip verification of the server certificate (testing only)
+// - DN to bind with and the password or a file with the password, anonymous bind if the DN is not set
+// - base DN to search the users in
+// - filter to find the user entry, %s is replaced by the user name (default "(uid=%s)")
+// - attribute of the user entry with the groups, the first value is the primary group (default "memberOf")
+type LDAPConfig struct {
+    URL                string `yaml:",omitempty" json:",omitempty"`
+    StartTLS           bool   `yaml:",omitempty" json:",omitempty"`
+    CACertFile         string `yaml:",omitempty" json:",omitempty"`
+    InsecureSkipVerify bool   `yaml:",omitempty" json:",omitempty"`
+    BindDN             string `yaml:",omitempty" json:",omitempty"`
+    BindPassword       string `yaml:",omitempty" json:",omitempty"`
+    BindPasswordFile   string `yaml:",omitempty" json:",omitempty"`
+    SearchBase         string `yaml:",omitempty" json:",omitempty"`
+    UserFilter         string `yaml:",omitempty" json:",omitempty"`
+    GroupAttribute     string `yaml:",omitempty" json:",omitempty"`
 }
 
 // The queue object for each queue:
@@ -51,6 +124,7 @@ type PartitionPreemptionConfig struct {
 // - a resources object to specify resource limits on the queue
 // - a set of properties, exact definition of what can be set is not part of the yaml
 // - a list of sub or child queues
+// - a template for the child queues created by the placement rules (parent queue only)
 type QueueConfig struct {
     Name            string
     Parent          bool              `yaml:",omitempty" json:",omitempty"`
@@ -60,6 +134,27 @@ type QueueConfig struct {
     SubmitACL       string            `yaml:",omitempty" json:",omitempty"`
     MaxApplications uint64            `yaml:",omitempty" json:",omitempty"`
     Queues          []QueueConfig     `yaml:",omitempty" json:",omitempty"`
+    ChildTemplate   ChildTemplate     `yaml:",omitempty" json:",omitempty"`
+}
+
+// The template for the queues that are dynamically created below a parent queue.
+// The settings are applied to each unmanaged child queue when it is created and re-applied on a configuration reload:
+// - a resources object to specify resource limits on the child queue
+// - a set of properties, merged with the properties of the parent
+// - the admin and submit ACLs of the child queue
+// - the maximum number of applications in the child queue, unlimited when not set
+type ChildTemplate struct {
+    Resources       Resources         `yaml:",omitempty" json:",omitempty"`
+    Properties      map[string]string `yaml:",omitempty" json:",omitempty"`
+    AdminACL        string            `yaml:",omitempty" json:",omitempty"`
+    SubmitACL       string            `yaml:",omitempty" json:",omitempty"`
+    MaxApplications uint64            `yaml:",omitempty" json:",omitempty"`
+}
+
+// Return true if any of the template settings are set.
+func (ct ChildTemplate) IsSet() bool {
+    return len(ct.Resources.Guaranteed) != 0 || len(ct.Resources.Max) != 0 || len(ct.Properties) != 0 ||
+        ct.AdminACL != "" || ct.SubmitACL != "" || ct.MaxApplications != 0
 }
 
 // The resource limits to set on the queue. The definition allows for an unlimited number of types to be used.
@@ -78,12 +173,16 @@ type Resources struct {
 // - rule link to allow setting a rule to generate the parent
 // - value a generic value interpreted depending on the rule type (i.e queue name for the "fixed" rule
 // or the application label name for the "tag" rule)
+// - tag the application tag used as the value to map for the "regex" rule, the user name is used if not set
+// - target the queue name template the value is mapped to for the "regex" rule (i.e root.teams.$1)
 type PlacementRule struct {
     Name   string
     Create bool           `yaml:",omitempty" json:",omitempty"`
     Filter Filter         `yaml:",omitempty" json:",omitempty"`
     Parent *PlacementRule `yaml:",omitempty" json:",omitempty"`
     Value  string         `yaml:",omitempty" json:",omitempty"`
+    Tag    string         `yaml:",omitempty" json:",omitempty"`
+    Target string         `yaml:",omitempty" json:",omitempty"`
 }
 
 // The user and group filter for a rule.
@@ -22,7 +22,9 @@ import (
     "gopkg.in/yaml.v2"
     "io/ioutil"
     "path"
+    "strings"
     "testing"
+    "time"
 )
 
 func TestConfigSerde(t *testing.T) {
@@ -475,6 +477,186 @@ partitions:
     if err == nil {
         t.Errorf("unbalanced quotes ACL queue parsing should have failed: %v", conf)
     }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        submitacl: "/svc-[a-z+/,!bob"
+`
+    // validate the config and check after the update
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Errorf("invalid regular expression in ACL queue parsing should have failed: %v", conf)
+    }
+}
+
+func TestQueueResourceUnits(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: parent
+            queues:
+              - name: leaf
+                resources:
+                  guaranteed:
+                    memory: 10Gi
+                    vcore: 500m
+                  max:
+                    memory: 20480
+                    vcore: "2k"
+`
+    conf, err := CreateConfig(data)
+    if err != nil {
+        t.Fatalf("resources with units parsing failed: %v", err)
+    }
+    res := conf.Partitions[0].Queues[0].Queues[0].Queues[0].Resources
+    if res.Guaranteed["memory"] != "10Gi" || res.Max["vcore"] != "2k" {
+        t.Errorf("resources with units not loaded correctly: %v", res)
+    }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: parent
+            queues:
+              - name: leaf
+                resources:
+                  max:
+                    memory: 10GB
+`
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Fatalf("resources with invalid unit should have failed: %v", conf)
+    }
+    if !strings.Contains(err.Error(), "root.parent.leaf") {
+        t.Errorf("resource error should contain the queue path: %v", err)
+    }
+}
+
+func TestQueueResourcePercentages(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: parent
+            resources:
+              guaranteed:
+                memory: 10%
+              max:
+                memory: "40%"
+                vcore: 12.5%partition
+`
+    conf, err := CreateConfig(data)
+    if err != nil {
+        t.Fatalf("resources with percentages parsing failed: %v", err)
+    }
+    res := conf.Partitions[0].Queues[0].Queues[0].Resources
+    if res.Max["memory"] != "40%" || res.Max["vcore"] != "12.5%partition" || res.Guaranteed["memory"] != "10%" {
+        t.Errorf("resources with percentages not loaded correctly: %v", res)
+    }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: parent
+            resources:
+              max:
+                memory: 150%
+`
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Errorf("resources with percentage over 100 should have failed: %v", conf)
+    }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    preemption:
+      enabled: true
+      maxpercycle:
+        memory: 10%
+`
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Errorf("percentage in preemption max per cycle should have failed: %v", conf)
+    }
+}
+
+func TestQueueChildTemplate(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: users
+            parent: true
+            childtemplate:
+              resources:
+                max:
+                  memory: 100
+              properties:
+                application.sort.policy: fair
+              submitacl: "*"
+              maxapplications: 5
+`
+    conf, err := CreateConfig(data)
+    if err != nil {
+        t.Fatalf("child template parsing failed: %v", err)
+    }
+    template := conf.Partitions[0].Queues[0].Queues[0].ChildTemplate
+    if !template.IsSet() || template.Resources.Max["memory"] != "100" || template.Properties["application.sort.policy"] != "fair" ||
+        template.SubmitACL != "*" || template.MaxApplications != 5 {
+        t.Errorf("child template not loaded correctly: %v", template)
+    }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: leaf
+            childtemplate:
+              maxapplications: 5
+`
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Errorf("child template on a leaf queue should have failed: %v", conf)
+    }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: users
+            parent: true
+            childtemplate:
+              resources:
+                max:
+                  memory: text
+`
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Errorf("child template with invalid resource should have failed: %v", conf)
+    }
 }
 
 func TestPartitionPreemptionParameter(t *testing.T) {
@@ -504,6 +686,202 @@ partitions:
     }
 }
 
+func TestPartitionPreemptionSettings(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    preemption:
+      enabled: true
+      policies: [DRF, priority]
+      victimorder: lowestPriority
+      minruntime: 30s
+      maxpercycle: {memory: 1024, vcore: 10}
+`
+    conf, err := CreateConfig(data)
+    if err != nil {
+        t.Fatalf("preemption settings parsing should not have failed: %v", err)
+    }
+    preemption := conf.Partitions[0].Preemption
+    if len(preemption.Policies) != 2 || preemption.Policies[0] != "DRF" || preemption.Policies[1] != "priority" {
+        t.Errorf("preemption policies not parsed correctly: %v", preemption.Policies)
+    }
+    if preemption.VictimOrder != "lowestPriority" {
+        t.Errorf("victim order not parsed correctly: %s", preemption.VictimOrder)
+    }
+    if preemption.MinRuntime != 30*time.Second {
+        t.Errorf("minimum runtime not parsed correctly: %v", preemption.MinRuntime)
+    }
+    if preemption.MaxPerCycle["memory"] != "1024" || preemption.MaxPerCycle["vcore"] != "10" {
+        t.Errorf("maximum preempted resources not parsed correctly: %v", preemption.MaxPerCycle)
+    }
+
+    // unknown settings must fail
+    failures := map[string]string{
+        "unknown policy":        "policies: [unknown]",
+        "unknown victim order":  "victimorder: oldest",
+        "negative runtime":      "minruntime: -1s",
+        "illegal resource":      "maxpercycle: {memory: lots}",
+        "negative grace period": "graceperiod: -1s",
+        "short timeout":         "graceperiod: 1m\n      timeout: 30s",
+    }
+    for name, setting := range failures {
+        data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    preemption:
+      enabled: true
+      ` + setting + `
+`
+        conf, err = CreateConfig(data)
+        if err == nil {
+            t.Errorf("%s parsing should have failed: %v", name, conf)
+        }
+    }
+}
+
+func TestPartitionApplicationsSettings(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    applications:
+      startingtimeout: 2m
+      completingtimeout: 10s
+`
+    conf, err := CreateConfig(data)
+    if err != nil {
+        t.Fatalf("application settings parsing should not have failed: %v", err)
+    }
+    applications := conf.Partitions[0].Applications
+    if applications.StartingTimeout != 2*time.Minute {
+        t.Errorf("starting timeout not parsed correctly: %v", applications.StartingTimeout)
+    }
+    if applications.CompletingTimeout != 10*time.Second {
+        t.Errorf("completing timeout not parsed correctly: %v", applications.CompletingTimeout)
+    }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    applications:
+      completingtimeout: -1s
+`
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Errorf("negative completing timeout parsing should have failed: %v", conf)
+    }
+}
+
+func TestPartitionNodesSettings(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    nodes:
+      parallelism: 8
+      percentageofnodestoscore: 30
+      scoring:
+        leastallocated: 2
+        zonespread: 1
+`
+    conf, err := CreateConfig(data)
+    if err != nil {
+        t.Fatalf("node settings parsing should not have failed: %v", err)
+    }
+    nodes := conf.Partitions[0].Nodes
+    if nodes.Parallelism != 8 {
+        t.Errorf("parallelism not parsed correctly: %d", nodes.Parallelism)
+    }
+    if nodes.PercentageOfNodesToScore != 30 {
+        t.Errorf("percentage of nodes to score not parsed correctly: %d", nodes.PercentageOfNodesToScore)
+    }
+    if len(nodes.Scoring) != 2 || nodes.Scoring[NodeScorerLeastAllocated] != 2 || nodes.Scoring[NodeScorerZoneSpread] != 1 {
+        t.Errorf("node scorer weights not parsed correctly: %v", nodes.Scoring)
+    }
+
+    for _, setting := range []string{"parallelism: -1", "percentageofnodestoscore: 101", "percentageofnodestoscore: -5",
+        "scoring: {mostallocated: -1}"} {
+        data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    nodes:
+      ` + setting + `
+`
+        conf, err = CreateConfig(data)
+        if err == nil {
+            t.Errorf("node setting '%s' parsing should have failed: %v", setting, conf)
+        }
+    }
+}
+
+func TestPartitionUserGroupSettings(t *testing.T) {
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    usergroups:
+      resolver: ldap
+      positivettl: 10m
+      negativettl: 1m
+      ldap:
+        url: ldaps://ldap.example.com
+        binddn: cn=scheduler,dc=example,dc=com
+        bindpasswordfile: /etc/yunikorn/ldap-password
+        searchbase: dc=example,dc=com
+        groupattribute: memberOf
+`
+    conf, err := CreateConfig(data)
+    if err != nil {
+        t.Fatalf("user group settings parsing should not have failed: %v", err)
+    }
+    userGroups := conf.Partitions[0].UserGroups
+    if userGroups.Resolver != "ldap" || userGroups.PositiveTTL != 10*time.Minute || userGroups.NegativeTTL != time.Minute {
+        t.Errorf("user group settings not parsed correctly: %v", userGroups)
+    }
+    if userGroups.LDAP.URL != "ldaps://ldap.example.com" || userGroups.LDAP.SearchBase != "dc=example,dc=com" ||
+        userGroups.LDAP.BindPasswordFile != "/etc/yunikorn/ldap-password" {
+        t.Errorf("LDAP settings not parsed correctly: %v", userGroups.LDAP)
+    }
+
+    for _, setting := range []string{"resolver: unknown", "positivettl: -1s", "resolver: file",
+        "{resolver: ldap, ldap: {url: http://localhost, searchbase: dc=example}}",
+        "{resolver: ldap, ldap: {url: ldap://localhost}}",
+        "{resolver: ldap, ldap: {url: ldap://localhost, searchbase: dc=example, userfilter: (cn=admin)}}"} {
+        data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    usergroups:
+      ` + setting + `
+`
+        if strings.HasPrefix(setting, "{") {
+            data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    usergroups: ` + setting + `
+`
+        }
+        conf, err = CreateConfig(data)
+        if err == nil {
+            t.Errorf("user group setting '%s' parsing should have failed: %v", setting, conf)
+        }
+    }
+}
+
 func TestParseRule(t *testing.T) {
     data := `
 partitions:
@@ -627,6 +1005,27 @@ partitions:
         conf.Partitions[0].PlacementRules[1].Value != "Just Any value" {
         t.Errorf("incorrect values set inside the rules: %v", conf.Partitions[0].PlacementRules)
     }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    placementrules:
+      - name: regex
+        tag: namespace
+        value: team-(.*)
+        target: root.teams.$1
+`
+    // validate the config and check after the update
+    conf, err = CreateConfig(data)
+    if err != nil {
+        t.Errorf("regex rule parsing should not have failed: %v", err)
+    }
+    rule = conf.Partitions[0].PlacementRules[0]
+    if rule.Tag != "namespace" || rule.Value != "team-(.*)" || rule.Target != "root.teams.$1" {
+        t.Errorf("incorrect values set inside the regex rule: %v", rule)
+    }
 }
 
 func TestParseRuleFail(t *testing.T) {
@@ -711,6 +1110,37 @@ partitions:
     if err == nil {
         t.Errorf("user parsing filter should have failed rule parsing: %v", conf)
     }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    placementrules:
+      - name: regex
+        value: team-(.*)
+`
+    // validate the config and check after the update
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Errorf("regex rule without target should have failed rule parsing: %v", conf)
+    }
+
+    data = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+    placementrules:
+      - name: regex
+        value: team-(.*
+        target: root.teams.$1
+`
+    // validate the config and check after the update
+    conf, err = CreateConfig(data)
+    if err == nil {
+        t.Errorf("regex rule with invalid expression should have failed rule parsing: %v", conf)
+    }
 }
 
 func TestRecurseParent(t *testing.T) {
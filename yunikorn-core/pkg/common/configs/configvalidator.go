@@ -18,19 +18,51 @@ package configs
 
 import (
     "fmt"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
     "github.com/cloudera/yunikorn-core/pkg/common/security"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "go.uber.org/zap"
     "regexp"
-    "strconv"
     "strings"
 )
 
 const (
     RootQueue        = "root"
     DefaultPartition = "default"
+    DOT              = "."
 )
 
+// Preemption policies and victim orders that can be set in the partition preemption config
+const (
+    PreemptionPolicyDRF      = "drf"
+    PreemptionPolicyPriority = "priority"
+
+    VictimOrderYoungest       = "youngest"
+    VictimOrderLowestPriority = "lowestpriority"
+    VictimOrderOvershoot      = "overshoot"
+)
+
+// Built-in node scorers that can be weighted in the partition node config.
+// Scorers registered by the RM are weighted using the name of the scorer.
+const (
+    NodeScorerLeastAllocated = "leastallocated"
+    NodeScorerMostAllocated  = "mostallocated"
+    NodeScorerRackSpread     = "rackspread"
+    NodeScorerZoneSpread     = "zonespread"
+    NodeScorerImageLocality  = "imagelocality"
+)
+
+var preemptionPolicies = map[string]bool{
+    PreemptionPolicyDRF:      true,
+    PreemptionPolicyPriority: true,
+}
+
+var victimOrders = map[string]bool{
+    VictimOrderYoungest:       true,
+    VictimOrderLowestPriority: true,
+    VictimOrderOvershoot:      true,
+}
+
 // A queue can be a username with the dot replaced. Most systems allow a 32 character user name.
 // The queue name must thus allow for at least that length with the replacement of dots.
 var QueueNameRegExp = regexp.MustCompile("^[a-zA-Z0-9_-]{1,64}$")
@@ -51,25 +83,24 @@ var RuleNameRegExp = regexp.MustCompile("^[_a-zA-Z][a-zA-Z0-9_]*$")
 // Check the ACL
 func checkACL(acl string) error {
     // trim any white space
-    acl = strings.TrimSpace(acl)
+    trimmed := strings.TrimSpace(acl)
     // handle special cases: deny and wildcard
-    if len(acl) == 0 || acl == security.WildCard {
+    if len(trimmed) == 0 || trimmed == security.WildCard {
         return nil
     }
 
-    // should have no more than two groups defined
-    fields := strings.Fields(acl)
-    if len(fields) > 2 {
-        return fmt.Errorf("multiple spaces found in ACL: '%s'", acl)
+    // parse the ACL to check the user and group lists, deny entries and patterns
+    if _, err := security.NewACL(acl); err != nil {
+        return fmt.Errorf("invalid ACL '%s': %v", acl, err)
     }
     return nil
 }
 
-// Temporary convenience method: should use resource package to do this
-// currently no check for the type of resource as long as the value is OK all is OK
+// Check the quantities of a resource map: the value can have a unit which is converted based on the resource type.
+// An unknown type of resource is allowed as long as the value is OK.
 func checkResource(res map[string]string) error {
-    for _, val := range res {
-        _, err := strconv.ParseInt(val, 10, 64)
+    for name, val := range res {
+        _, err := resources.ParseQuantity(name, val)
         if err != nil {
             return fmt.Errorf("resource parsing failed: %v", err)
         }
@@ -77,25 +108,125 @@ func checkResource(res map[string]string) error {
     return nil
 }
 
+// Check the quantities of a queue resource map: next to the quantities the values can be percentages of the max
+// resources of the parent or of the partition total.
+func checkRelativeResource(res map[string]string) error {
+    for name, val := range res {
+        _, isPct, err := resources.ParsePercentage(val)
+        if err != nil {
+            return fmt.Errorf("resource parsing failed for resource %s: %v", name, err)
+        }
+        if isPct {
+            continue
+        }
+        if _, err = resources.ParseQuantity(name, val); err != nil {
+            return fmt.Errorf("resource parsing failed: %v", err)
+        }
+    }
+    return nil
+}
+
 // Check the resource configuration
 func checkResources(resource Resources) error {
     // check guaranteed resources
     if resource.Guaranteed != nil && len(resource.Guaranteed) != 0 {
-        err := checkResource(resource.Guaranteed)
+        err := checkRelativeResource(resource.Guaranteed)
         if err != nil {
-            return err
+            return fmt.Errorf("invalid guaranteed resources: %v", err)
         }
     }
     // check max resources
     if resource.Max != nil && len(resource.Max) != 0 {
-        err := checkResource(resource.Max)
+        err := checkRelativeResource(resource.Max)
         if err != nil {
-            return err
+            return fmt.Errorf("invalid max resources: %v", err)
+        }
+    }
+    return nil
+}
+
+// Check the preemption settings for correctness
+func checkPreemption(partition *PartitionConfig) error {
+    preemption := partition.Preemption
+    for _, policy := range preemption.Policies {
+        if !preemptionPolicies[strings.ToLower(policy)] {
+            return fmt.Errorf("unknown preemption policy '%s' in partition %s", policy, partition.Name)
+        }
+    }
+    if preemption.VictimOrder != "" && !victimOrders[strings.ToLower(preemption.VictimOrder)] {
+        return fmt.Errorf("unknown preemption victim order '%s' in partition %s", preemption.VictimOrder, partition.Name)
+    }
+    if preemption.MinRuntime < 0 {
+        return fmt.Errorf("negative preemption minimum runtime in partition %s", partition.Name)
+    }
+    if preemption.GracePeriod < 0 || preemption.Timeout < 0 {
+        return fmt.Errorf("negative preemption grace period or timeout in partition %s", partition.Name)
+    }
+    if preemption.Timeout != 0 && preemption.Timeout < preemption.GracePeriod {
+        return fmt.Errorf("preemption timeout must not be shorter than the grace period in partition %s", partition.Name)
+    }
+    if err := checkResource(preemption.MaxPerCycle); err != nil {
+        return fmt.Errorf("invalid preemption max per cycle in partition %s: %v", partition.Name, err)
+    }
+    return nil
+}
+
+// Check the application lifecycle settings for correctness
+func checkApplications(partition *PartitionConfig) error {
+    applications := partition.Applications
+    if applications.StartingTimeout < 0 || applications.CompletingTimeout < 0 {
+        return fmt.Errorf("negative application starting or completing timeout in partition %s", partition.Name)
+    }
+    return nil
+}
+
+// Check the node evaluation settings for correctness
+func checkNodes(partition *PartitionConfig) error {
+    nodes := partition.Nodes
+    if nodes.Parallelism < 0 {
+        return fmt.Errorf("negative node evaluation parallelism in partition %s", partition.Name)
+    }
+    if nodes.PercentageOfNodesToScore < 0 || nodes.PercentageOfNodesToScore > 100 {
+        return fmt.Errorf("percentage of nodes to score must be between 0 and 100 in partition %s", partition.Name)
+    }
+    for name, weight := range nodes.Scoring {
+        if weight < 0 {
+            return fmt.Errorf("negative weight %d for node scorer %s in partition %s", weight, name, partition.Name)
         }
     }
     return nil
 }
 
+// Check the user and group resolution settings for correctness
+// The files used by the resolvers are not checked, they are read when the resolver is created.
+func checkUserGroups(partition *PartitionConfig) error {
+    userGroups := partition.UserGroups
+    if userGroups.PositiveTTL < 0 || userGroups.NegativeTTL < 0 {
+        return fmt.Errorf("negative user group cache TTL in partition %s", partition.Name)
+    }
+    switch strings.ToLower(userGroups.Resolver) {
+    case "", security.ResolverNone, security.ResolverOS:
+    case security.ResolverLDAP:
+        ldap := userGroups.LDAP
+        if !strings.HasPrefix(ldap.URL, "ldap://") && !strings.HasPrefix(ldap.URL, "ldaps://") {
+            return fmt.Errorf("LDAP URL %s must use the ldap or ldaps scheme in partition %s", ldap.URL, partition.Name)
+        }
+        if ldap.SearchBase == "" {
+            return fmt.Errorf("LDAP search base must be set in partition %s", partition.Name)
+        }
+        if ldap.UserFilter != "" && strings.Count(ldap.UserFilter, "%s") != 1 {
+            return fmt.Errorf("LDAP user filter %s must contain the user placeholder %%s once in partition %s", ldap.UserFilter, partition.Name)
+        }
+    case security.ResolverFile:
+        if userGroups.File == "" {
+            return fmt.Errorf("user group file must be set for the file resolver in partition %s", partition.Name)
+        }
+    default:
+        return fmt.Errorf("unknown user group resolver %s in partition %s", userGroups.Resolver, partition.Name)
+    }
+    return nil
+}
+
 // Check the placement rules for correctness
 func checkPlacementRules(partition *PartitionConfig) error {
     // return if nothing defined
@@ -130,6 +261,15 @@ func checkPlacementRule(rule PlacementRule) error {
             return err
         }
     }
+    // the regex rule maps a value using the expression and target: both must be set and valid
+    if strings.EqualFold(rule.Name, "regex") {
+        if rule.Value == "" || rule.Target == "" {
+            return fmt.Errorf("regex rule must have a regular expression and target set")
+        }
+        if _, err := regexp.Compile(rule.Value); err != nil {
+            return fmt.Errorf("invalid regex rule expression %s: %v", rule.Value, err)
+        }
+    }
     // check filter if given
     if err := checkPlacementFilter(rule.Filter); err != nil {
         log.Logger().Debug("placement rule filter failed",
@@ -189,15 +329,42 @@ func checkUserDefinition(partition *PartitionConfig) error {
     return nil
 }
 
+// Check the template for the dynamically created child queues:
+// - only a parent queue can have a template
+// - the resources and ACLs must be valid
+func checkChildTemplate(queue *QueueConfig, path string) error {
+    template := queue.ChildTemplate
+    if !template.IsSet() {
+        return nil
+    }
+    if !queue.Parent && len(queue.Queues) == 0 {
+        return fmt.Errorf("child template set on leaf queue %s", path)
+    }
+    err := checkResources(template.Resources)
+    if err != nil {
+        return fmt.Errorf("invalid child template on queue %s: %v", path, err)
+    }
+    err = checkACL(template.AdminACL)
+    if err != nil {
+        return fmt.Errorf("invalid child template on queue %s: %v", path, err)
+    }
+    err = checkACL(template.SubmitACL)
+    if err != nil {
+        return fmt.Errorf("invalid child template on queue %s: %v", path, err)
+    }
+    return nil
+}
+
 // Check the queue names configured for compliance and uniqueness
 // - no duplicate names at each branched level in the tree
 // - queue name is alphanumeric (case ignore) with - and _
 // - queue name is maximum 16 char long
-func checkQueues(queue *QueueConfig, level int) error {
+// The path is the fully qualified name of the queue used in the errors.
+func checkQueues(queue *QueueConfig, path string, level int) error {
     // check the resource (if defined)
     err := checkResources(queue.Resources)
     if err != nil {
-        return err
+        return fmt.Errorf("queue %s: %v", path, err)
     }
 
     // check the ACLs (if defined)
@@ -210,6 +377,12 @@ func checkQueues(queue *QueueConfig, level int) error {
         return err
     }
 
+    // check the child template (if defined)
+    err = checkChildTemplate(queue, path)
+    if err != nil {
+        return err
+    }
+
     // check this level for name compliance and uniqueness
     queueMap := make(map[string]bool)
     for _, queue := range queue.Queues {
@@ -226,7 +399,7 @@ func checkQueues(queue *QueueConfig, level int) error {
 
     // recurse into the depth if this level passed
     for _, queue := range queue.Queues {
-        err := checkQueues(&queue, level+1)
+        err := checkQueues(&queue, path+DOT+queue.Name, level+1)
         if err != nil {
             return err
         }
@@ -281,7 +454,7 @@ func checkQueuesStructure(partition *PartitionConfig) error {
     if rootQueue.Resources.Guaranteed != nil || rootQueue.Resources.Max != nil {
         return fmt.Errorf("root queue must not have resource limits set")
     }
-    return checkQueues(&rootQueue, 1)
+    return checkQueues(&rootQueue, rootQueue.Name, 1)
 }
 
 // Check the partition configuration. Any parsing issues will return an error which means that the
@@ -324,6 +497,22 @@ func Validate(newConfig *SchedulerConfig) error {
         if err != nil {
             return err
         }
+        err = checkPreemption(&partition)
+        if err != nil {
+            return err
+        }
+        err = checkApplications(&partition)
+        if err != nil {
+            return err
+        }
+        err = checkNodes(&partition)
+        if err != nil {
+            return err
+        }
+        err = checkUserGroups(&partition)
+        if err != nil {
+            return err
+        }
         // write back the partition to keep changes
         newConfig.Partitions[i] = partition
     }
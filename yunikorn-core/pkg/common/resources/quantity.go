@@ -0,0 +1,142 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+    "fmt"
+    "math/big"
+    "regexp"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+// A resource type known to the core.
+// Values in the configuration without a unit are in the canonical unit of the type. Values with a unit, using the
+// Kubernetes quantity suffixes, are in the base unit of the type and are converted into the canonical unit:
+// - the name of the resource type
+// - the base unit a value with a suffix is expressed in (i.e. bytes for memory)
+// - the canonical unit the core uses for the type (i.e. MB for memory)
+// - the number of canonical units in one base unit
+type ResourceType struct {
+    Name          string
+    BaseUnit      string
+    CanonicalUnit string
+    Scale         *big.Rat
+}
+
+var (
+    // Kubernetes style quantity: a decimal number with an optional exponent and an optional suffix
+    quantityRegExp = regexp.MustCompile(`^([+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+))(?:[eE]([+-]?[0-9]+))?(Ki|Mi|Gi|Ti|Pi|Ei|n|u|m|k|M|G|T|P|E)?$`)
+    // multipliers for the binary and decimal suffixes
+    suffixes = map[string]*big.Rat{
+        "Ki": new(big.Rat).SetInt64(1 << 10),
+        "Mi": new(big.Rat).SetInt64(1 << 20),
+        "Gi": new(big.Rat).SetInt64(1 << 30),
+        "Ti": new(big.Rat).SetInt64(1 << 40),
+        "Pi": new(big.Rat).SetInt64(1 << 50),
+        "Ei": new(big.Rat).SetInt64(1 << 60),
+        "n":  big.NewRat(1, 1000000000),
+        "u":  big.NewRat(1, 1000000),
+        "m":  big.NewRat(1, 1000),
+        "k":  new(big.Rat).SetInt64(1e3),
+        "M":  new(big.Rat).SetInt64(1e6),
+        "G":  new(big.Rat).SetInt64(1e9),
+        "T":  new(big.Rat).SetInt64(1e12),
+        "P":  new(big.Rat).SetInt64(1e15),
+        "E":  new(big.Rat).SetInt64(1e18),
+    }
+    // registry of the known resource types
+    resourceTypes = map[string]ResourceType{
+        MEMORY: {Name: MEMORY, BaseUnit: "bytes", CanonicalUnit: "MB", Scale: big.NewRat(1, 1000000)},
+        VCORE:  {Name: VCORE, BaseUnit: "vcore", CanonicalUnit: "milli vcore", Scale: big.NewRat(1000, 1)},
+    }
+    typesLock sync.RWMutex
+)
+
+// Register a resource type, replacing the type with the same name if it was registered before.
+func RegisterResourceType(resType ResourceType) {
+    typesLock.Lock()
+    defer typesLock.Unlock()
+    resourceTypes[resType.Name] = resType
+}
+
+// Get the registered resource type. A type that is not registered uses the same base and canonical unit.
+func GetResourceType(name string) ResourceType {
+    typesLock.RLock()
+    defer typesLock.RUnlock()
+    if resType, ok := resourceTypes[name]; ok {
+        return resType
+    }
+    return ResourceType{Name: name, Scale: big.NewRat(1, 1)}
+}
+
+// Parse a quantity for the resource type and return it in the canonical unit of the type.
+// A value without a suffix, like "10240", is in the canonical unit. A value with a suffix, like "10Gi" or "500m", is
+// in the base unit and is converted. Values that do not convert into a whole number of canonical units are rounded
+// up, like Kubernetes does.
+func ParseQuantity(name string, value string) (Quantity, error) {
+    parts := quantityRegExp.FindStringSubmatch(strings.TrimSpace(value))
+    if parts == nil {
+        return 0, fmt.Errorf("invalid quantity '%s' for resource %s", value, name)
+    }
+    number, ok := new(big.Rat).SetString(parts[1])
+    if !ok {
+        return 0, fmt.Errorf("invalid quantity '%s' for resource %s", value, name)
+    }
+    if parts[2] != "" {
+        exp, err := strconv.ParseInt(parts[2], 10, 64)
+        if err != nil || exp > 30 || exp < -30 {
+            return 0, fmt.Errorf("invalid exponent in quantity '%s' for resource %s", value, name)
+        }
+        if exp >= 0 {
+            number.Mul(number, new(big.Rat).SetInt(pow10(exp)))
+        } else {
+            number.Quo(number, new(big.Rat).SetInt(pow10(-exp)))
+        }
+    }
+    if parts[3] != "" {
+        number.Mul(number, suffixes[parts[3]])
+        number.Mul(number, GetResourceType(name).Scale)
+    } else if parts[2] == "" && !number.IsInt() {
+        return 0, fmt.Errorf("quantity '%s' for resource %s without unit must be a whole number of %s",
+            value, name, canonicalUnit(name))
+    }
+    // round up to the next whole canonical unit
+    result := new(big.Int).Quo(number.Num(), number.Denom())
+    if !number.IsInt() && number.Sign() > 0 {
+        result.Add(result, big.NewInt(1))
+    }
+    if !result.IsInt64() {
+        return 0, fmt.Errorf("quantity '%s' for resource %s is out of range", value, name)
+    }
+    return Quantity(result.Int64()), nil
+}
+
+// Get the name of the canonical unit of the type for messages.
+func canonicalUnit(name string) string {
+    unit := GetResourceType(name).CanonicalUnit
+    if unit == "" {
+        return "units"
+    }
+    return unit
+}
+
+// Calculate 10 to the power of the (non negative) exponent.
+func pow10(exp int64) *big.Int {
+    return new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil)
+}
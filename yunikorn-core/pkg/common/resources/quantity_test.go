@@ -0,0 +1,81 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+    "math/big"
+    "testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+    var tests = []struct {
+        name     string
+        value    string
+        expected Quantity
+    }{
+        // no unit: canonical unit
+        {MEMORY, "10240", 10240},
+        {VCORE, "2", 2},
+        {"gpu", "4", 4},
+        {MEMORY, " 100 ", 100},
+        {MEMORY, "1e3", 1000},
+        // memory with unit is in bytes converted to MB
+        {MEMORY, "10Gi", 10738},
+        {MEMORY, "10G", 10000},
+        {MEMORY, "512Mi", 537},
+        {MEMORY, "1.5G", 1500},
+        {MEMORY, "1k", 1},
+        // vcore with unit is in vcore converted to milli vcore
+        {VCORE, "500m", 500},
+        {VCORE, "1.5k", 1500000},
+        {VCORE, "100u", 1},
+        // unknown types with unit
+        {"gpu", "2k", 2000},
+        {"gpu", "2Ki", 2048},
+    }
+    for _, test := range tests {
+        value, err := ParseQuantity(test.name, test.value)
+        if err != nil {
+            t.Errorf("parsing %s quantity '%s' failed: %v", test.name, test.value, err)
+            continue
+        }
+        if value != test.expected {
+            t.Errorf("parsing %s quantity '%s' incorrect, expected %d got %d", test.name, test.value, test.expected, value)
+        }
+    }
+
+    for _, value := range []string{"", "abc", "10GB", "1.5", "10 Gi", "Gi", "1e99", "100000000000000000000", "100000000Ei"} {
+        if _, err := ParseQuantity(MEMORY, value); err == nil {
+            t.Errorf("parsing memory quantity '%s' should have failed", value)
+        }
+    }
+}
+
+func TestRegisterResourceType(t *testing.T) {
+    if GetResourceType("disk").Scale.Cmp(big.NewRat(1, 1)) != 0 {
+        t.Errorf("unknown resource type should not be scaled")
+    }
+    RegisterResourceType(ResourceType{Name: "disk", BaseUnit: "bytes", CanonicalUnit: "GB", Scale: big.NewRat(1, 1000000000)})
+    value, err := ParseQuantity("disk", "20G")
+    if err != nil || value != 20 {
+        t.Errorf("parsing registered type quantity failed, expected 20 got %d (%v)", value, err)
+    }
+    value, err = ParseQuantity("disk", "20")
+    if err != nil || value != 20 {
+        t.Errorf("parsing registered type quantity without unit failed, expected 20 got %d (%v)", value, err)
+    }
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+)
+
+const (
+    Percent          = "%"
+    PartitionPercent = "%partition"
+)
+
+// A resource definition from the configuration that can contain quantities relative to a base resource.
+// The absolute quantities are used as is, the percentages are resolved against the parent base resource and the
+// partition percentages against the total resources of the partition.
+type RelativeResource struct {
+    Absolute             *Resource
+    Percentages          map[string]float64
+    PartitionPercentages map[string]float64
+}
+
+// Parse a percentage value like "40%" or "40%partition". Returns false if the value is not a percentage.
+// A percentage must be larger than 0 and not larger than 100.
+func ParsePercentage(value string) (float64, bool, error) {
+    value = strings.TrimSpace(value)
+    var number string
+    switch {
+    case strings.HasSuffix(value, PartitionPercent):
+        number = strings.TrimSuffix(value, PartitionPercent)
+    case strings.HasSuffix(value, Percent):
+        number = strings.TrimSuffix(value, Percent)
+    default:
+        return 0, false, nil
+    }
+    pct, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+    if err != nil {
+        return 0, true, fmt.Errorf("invalid percentage '%s': %v", value, err)
+    }
+    if pct <= 0 || pct > 100 {
+        return 0, true, fmt.Errorf("percentage '%s' must be larger than 0 and not larger than 100", value)
+    }
+    return pct, true, nil
+}
+
+// Create a relative resource from the config map, the values are either quantities or percentages.
+// The config map must have been checked before being applied. The check here is just for safety so we do not crash.
+func NewRelativeResourceFromConf(configMap map[string]string) (*RelativeResource, error) {
+    res := &RelativeResource{
+        Absolute:             NewResource(),
+        Percentages:          make(map[string]float64),
+        PartitionPercentages: make(map[string]float64),
+    }
+    for key, strVal := range configMap {
+        pct, isPct, err := ParsePercentage(strVal)
+        if err != nil {
+            return nil, err
+        }
+        if isPct {
+            if strings.HasSuffix(strings.TrimSpace(strVal), PartitionPercent) {
+                res.PartitionPercentages[key] = pct
+            } else {
+                res.Percentages[key] = pct
+            }
+            continue
+        }
+        value, err := ParseQuantity(key, strVal)
+        if err != nil {
+            return nil, err
+        }
+        res.Absolute.Resources[key] = value
+    }
+    return res, nil
+}
+
+// Return true if the resource has no absolute quantities and no percentages.
+func (r *RelativeResource) IsEmpty() bool {
+    return r == nil || (len(r.Absolute.Resources) == 0 && len(r.Percentages) == 0 && len(r.PartitionPercentages) == 0)
+}
+
+// Return true if the resource has percentages that need to be resolved.
+func (r *RelativeResource) IsRelative() bool {
+    return r != nil && (len(r.Percentages) != 0 || len(r.PartitionPercentages) != 0)
+}
+
+// Resolve the resource into an absolute resource using the parent base resource and the partition total.
+// The result is rounded down to a whole quantity. Returns nil if the resolved resource has no quantities.
+func (r *RelativeResource) Resolve(base, partition *Resource) *Resource {
+    if r.IsEmpty() {
+        return nil
+    }
+    res := NewResource()
+    for key, value := range r.Absolute.Resources {
+        res.Resources[key] = value
+    }
+    resolvePercentages(res, r.Percentages, base)
+    resolvePercentages(res, r.PartitionPercentages, partition)
+    if len(res.Resources) == 0 {
+        return nil
+    }
+    return res
+}
+
+// Add the percentages of the base to the resource.
+// Without a base, like before nodes have registered, the percentages resolve to zero until the base is known.
+// A percentage of a type that is not part of a base is skipped.
+func resolvePercentages(res *Resource, percentages map[string]float64, base *Resource) {
+    for key, pct := range percentages {
+        if base == nil || len(base.Resources) == 0 {
+            res.Resources[key] = 0
+            continue
+        }
+        if total, ok := base.Resources[key]; ok {
+            res.Resources[key] = Quantity(math.Floor(float64(total) * pct / 100))
+        }
+    }
+}
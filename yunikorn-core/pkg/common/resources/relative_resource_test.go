@@ -0,0 +1,93 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestParsePercentage(t *testing.T) {
+    pct, isPct, err := ParsePercentage("40%")
+    if err != nil || !isPct || pct != 40 {
+        t.Errorf("parsing '40%%' failed: %f, %v, %v", pct, isPct, err)
+    }
+    pct, isPct, err = ParsePercentage(" 12.5 %")
+    if err != nil || !isPct || pct != 12.5 {
+        t.Errorf("parsing ' 12.5 %%' failed: %f, %v, %v", pct, isPct, err)
+    }
+    pct, isPct, err = ParsePercentage("30%partition")
+    if err != nil || !isPct || pct != 30 {
+        t.Errorf("parsing '30%%partition' failed: %f, %v, %v", pct, isPct, err)
+    }
+    _, isPct, err = ParsePercentage("10Gi")
+    if err != nil || isPct {
+        t.Errorf("quantity should not have been parsed as a percentage: %v, %v", isPct, err)
+    }
+    for _, value := range []string{"0%", "101%", "-5%", "abc%", "%", "%partition", "120%partition"} {
+        if _, _, err = ParsePercentage(value); err == nil {
+            t.Errorf("parsing percentage '%s' should have failed", value)
+        }
+    }
+}
+
+func TestRelativeResourceResolve(t *testing.T) {
+    res, err := NewRelativeResourceFromConf(map[string]string{MEMORY: "25%", VCORE: "1k", "gpu": "50%"})
+    if err != nil {
+        t.Fatalf("creating relative resource failed: %v", err)
+    }
+    if res.IsEmpty() || !res.IsRelative() {
+        t.Errorf("relative resource flags incorrect: %v", res)
+    }
+    // no base: the percentages are zero until the base is known
+    expected := map[string]Quantity{MEMORY: 0, VCORE: 1000000, "gpu": 0}
+    if resolved := res.Resolve(nil, nil); !reflect.DeepEqual(resolved.Resources, expected) {
+        t.Errorf("resolve without base incorrect, expected %v got %v", expected, resolved.Resources)
+    }
+    if resolved := res.Resolve(NewResource(), nil); !reflect.DeepEqual(resolved.Resources, expected) {
+        t.Errorf("resolve with empty base incorrect, expected %v got %v", expected, resolved.Resources)
+    }
+    // types missing in the base are skipped, results are rounded down
+    base := NewResourceFromMap(map[string]Quantity{MEMORY: 1001, VCORE: 4000})
+    expected = map[string]Quantity{MEMORY: 250, VCORE: 1000000}
+    if resolved := res.Resolve(base, nil); !reflect.DeepEqual(resolved.Resources, expected) {
+        t.Errorf("resolve with base incorrect, expected %v got %v", expected, resolved.Resources)
+    }
+
+    // partition percentages use the partition total, not the parent base
+    res, err = NewRelativeResourceFromConf(map[string]string{MEMORY: "25%", VCORE: "10%partition"})
+    if err != nil || !res.IsRelative() {
+        t.Fatalf("creating relative resource with partition percentage failed: %v, %v", res, err)
+    }
+    partition := NewResourceFromMap(map[string]Quantity{MEMORY: 4000, VCORE: 8000})
+    expected = map[string]Quantity{MEMORY: 250, VCORE: 800}
+    if resolved := res.Resolve(base, partition); !reflect.DeepEqual(resolved.Resources, expected) {
+        t.Errorf("resolve with partition incorrect, expected %v got %v", expected, resolved.Resources)
+    }
+
+    res, err = NewRelativeResourceFromConf(map[string]string{MEMORY: "100"})
+    if err != nil || res.IsRelative() {
+        t.Errorf("absolute resource should not be relative: %v, %v", res, err)
+    }
+    res, err = NewRelativeResourceFromConf(nil)
+    if err != nil || !res.IsEmpty() || res.Resolve(base, nil) != nil {
+        t.Errorf("empty resource should resolve to nil: %v, %v", res, err)
+    }
+    if _, err = NewRelativeResourceFromConf(map[string]string{MEMORY: "200%"}); err == nil {
+        t.Errorf("creating relative resource with invalid percentage should have failed")
+    }
+}
@@ -21,7 +21,6 @@ import (
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
     "math"
     "sort"
-    "strconv"
 )
 
 // const keys
@@ -58,15 +57,15 @@ func NewResourceFromMap(m map[string]Quantity) *Resource {
 
 // Create a new resource from the config map.
 // The config map must have been checked before being applied. The check here is just for safety so we do not crash.
-// TODO support size modifiers
+// The values are parsed as quantities and converted into the canonical unit of the resource type.
 func NewResourceFromConf(configMap map[string]string) (*Resource, error) {
     res := NewResource()
     for key, strVal := range configMap {
-        intValue, err := strconv.ParseInt(strVal, 10, 64)
+        value, err := ParseQuantity(key, strVal)
         if err != nil {
             return nil, err
         }
-        res.Resources[key] = Quantity(intValue)
+        res.Resources[key] = value
     }
     return res, nil
 }
@@ -21,6 +21,7 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/log"
     "go.uber.org/zap"
     "regexp"
+    "sort"
     "strings"
 )
 
@@ -28,6 +29,7 @@ const (
     WildCard  = "*"
     Separator = ","
     Space     = " "
+    Deny      = "!"
 )
 
 // User and group regexp, must allow at least what we allow in the config checks
@@ -35,10 +37,101 @@ const (
 var userNameRegExp = regexp.MustCompile("^[_a-zA-Z][a-zA-Z0-9_.@-]*[$]?$")
 var groupRegExp = regexp.MustCompile("^[_a-zA-Z][a-zA-Z0-9_-]*$")
 
+// An access control list.
+// The users and groups in the lists can be names, wildcard patterns (glob style using * and ?) or regular expressions
+// enclosed in slashes. An entry prefixed with an exclamation mark is a deny entry. A deny entry always takes
+// precedence over an allow entry, including the wildcard.
 type ACL struct {
-    users      map[string]bool
-    groups     map[string]bool
-    allAllowed bool
+    users        principals
+    groups       principals
+    deniedUsers  principals
+    deniedGroups principals
+    allAllowed   bool
+    configured   bool
+}
+
+// A list of principals: names and patterns.
+// The pattern source is kept to be able to compare and show the pattern as it was configured.
+type principals struct {
+    names    map[string]bool
+    patterns map[string]*regexp.Regexp
+}
+
+func newPrincipals() principals {
+    return principals{
+        names:    make(map[string]bool),
+        patterns: make(map[string]*regexp.Regexp),
+    }
+}
+
+// Is the name part of the list directly or via a pattern?
+func (p principals) matches(name string) bool {
+    if p.names[name] {
+        return true
+    }
+    for _, pattern := range p.patterns {
+        if pattern.MatchString(name) {
+            return true
+        }
+    }
+    return false
+}
+
+// Is any of the names part of the list?
+func (p principals) matchesAny(names []string) bool {
+    for _, name := range names {
+        if p.matches(name) {
+            return true
+        }
+    }
+    return false
+}
+
+// The names and pattern sources in the list, sorted.
+func (p principals) list() []string {
+    result := make([]string, 0, len(p.names)+len(p.patterns))
+    for name := range p.names {
+        result = append(result, name)
+    }
+    for source := range p.patterns {
+        result = append(result, source)
+    }
+    sort.Strings(result)
+    return result
+}
+
+// Add the entries of the other list.
+func (p principals) add(other principals) {
+    for name := range other.names {
+        p.names[name] = true
+    }
+    for source, pattern := range other.patterns {
+        p.patterns[source] = pattern
+    }
+}
+
+// Add the entry to the list: a regular expression, a wildcard pattern or a name.
+// Names that are not valid are ignored, patterns that do not compile return an error.
+func (p principals) addEntry(entry string, nameRegExp *regexp.Regexp) error {
+    switch {
+    case len(entry) > 2 && strings.HasPrefix(entry, "/") && strings.HasSuffix(entry, "/"):
+        pattern, err := regexp.Compile("^(?:" + entry[1:len(entry)-1] + ")$")
+        if err != nil {
+            return fmt.Errorf("invalid regular expression %s in ACL: %v", entry, err)
+        }
+        p.patterns[entry] = pattern
+    case strings.ContainsAny(entry, "*?"):
+        glob := regexp.QuoteMeta(entry)
+        glob = strings.Replace(glob, "\\*", ".*", -1)
+        glob = strings.Replace(glob, "\\?", ".", -1)
+        p.patterns[entry] = regexp.MustCompile("^" + glob + "$")
+    case nameRegExp.MatchString(entry):
+        p.names[entry] = true
+    default:
+        log.Logger().Info("ignoring entry in ACL definition",
+            zap.String("entry", entry))
+    }
+    return nil
 }
 
 // the ACL allows all access, set the flag
@@ -48,64 +141,81 @@ func (a *ACL) setAllAllowed(part string) {
 }
 
 // set the user list in the ACL, invalid user names are ignored
-func (a *ACL) setUsers(userList []string) {
-    a.users = make(map[string]bool)
-    // list could be empty
-    if len(userList) == 0 {
-        return
-    }
-    // special case if the user list is just the wildcard
-    if len(userList) == 1 && userList[0] == WildCard {
-        log.Logger().Info("user list is wildcard, allowing all access")
-        a.allAllowed = true
-        return
-    }
-    // add all users to the map
+func (a *ACL) setUsers(userList []string) error {
+    a.users = newPrincipals()
+    a.deniedUsers = newPrincipals()
     for _, user := range userList {
-        if userNameRegExp.MatchString(user) {
-            a.users[user] = true
-        } else {
-            log.Logger().Info("ignoring user in ACL definition",
-                zap.String("user", user))
+        if user == "" {
+            continue
+        }
+        // the wildcard in the user list allows all access
+        if user == WildCard {
+            log.Logger().Info("user list contains the wildcard, allowing all access")
+            a.allAllowed = true
+            continue
+        }
+        list := &a.users
+        if strings.HasPrefix(user, Deny) {
+            list = &a.deniedUsers
+            user = strings.TrimPrefix(user, Deny)
         }
+        if err := list.addEntry(user, userNameRegExp); err != nil {
+            return err
+        }
+    }
+    // the allowed users are not needed when all are allowed
+    if a.allAllowed {
+        a.users = newPrincipals()
     }
+    return nil
 }
 
 // set the group list in the ACL, invalid group names are ignored
-func (a *ACL) setGroups(groupList []string) {
-    a.groups = make(map[string]bool)
-    // list could be empty
-    if len(groupList) == 0 {
-        return
-    }
-    // special case if the wildcard was already set
-    if a.allAllowed {
-        log.Logger().Info("ignoring group list in ACL: wildcard set")
-        return
-    }
-    if len(groupList) == 1 && groupList[0] == WildCard {
-        log.Logger().Info("group list is wildcard, allowing all access")
-        a.users = make(map[string]bool)
-        a.allAllowed = true
-        return
-    }
-    // add all groups to the map
+func (a *ACL) setGroups(groupList []string) error {
+    a.groups = newPrincipals()
+    a.deniedGroups = newPrincipals()
     for _, group := range groupList {
-        if groupRegExp.MatchString(group) {
-            a.groups[group] = true
-        } else {
-            log.Logger().Info("ignoring group in ACL",
+        if group == "" {
+            continue
+        }
+        if strings.HasPrefix(group, Deny) {
+            if err := a.deniedGroups.addEntry(strings.TrimPrefix(group, Deny), groupRegExp); err != nil {
+                return err
+            }
+            continue
+        }
+        // allowed groups are not needed if the wildcard was already set
+        if a.allAllowed {
+            log.Logger().Info("ignoring group in ACL: wildcard set",
                 zap.String("group", group))
+            continue
+        }
+        if group == WildCard {
+            log.Logger().Info("group list is wildcard, allowing all access")
+            a.users = newPrincipals()
+            a.groups = newPrincipals()
+            a.allAllowed = true
+            continue
+        }
+        if err := a.groups.addEntry(group, groupRegExp); err != nil {
+            return err
         }
     }
+    return nil
 }
 
 // create a new ACL from scratch
 func NewACL(aclStr string) (ACL, error) {
-    acl := ACL{}
+    acl := ACL{
+        users:        newPrincipals(),
+        groups:       newPrincipals(),
+        deniedUsers:  newPrincipals(),
+        deniedGroups: newPrincipals(),
+    }
     if aclStr == "" {
         return acl, nil
     }
+    acl.configured = true
     // before trimming check
     // should have no more than two groups defined
     fields := strings.Split(aclStr, Space)
@@ -114,34 +224,116 @@ func NewACL(aclStr string) (ACL, error) {
     }
     // trim and check for wildcard
     acl.setAllAllowed(aclStr)
-    // an empty ACL is a deny
-    if len(fields) == 0 {
-        return acl, nil
-    }
     // parse users and groups
-    acl.setUsers(strings.Split(fields[0], Separator))
+    if err := acl.setUsers(strings.Split(fields[0], Separator)); err != nil {
+        return acl, err
+    }
     if len(fields) == 2 {
-        acl.setGroups(strings.Split(fields[1], Separator))
+        if err := acl.setGroups(strings.Split(fields[1], Separator)); err != nil {
+            return acl, err
+        }
     }
     return acl, nil
 }
 
 // Check if the user has access
 func (a ACL) CheckAccess(userObj UserGroup) bool {
+    // deny entries take precedence over everything
+    if a.deniedUsers.matches(userObj.User) || a.deniedGroups.matchesAny(userObj.Groups) {
+        return false
+    }
     // shortcut allow all
     if a.allAllowed {
         return true
     }
-    // if the ACL is not the wildcard we have non nil lists
-    // check user access
-    if a.users[userObj.User] {
-        return true
+    // check user access, then get groups for the user and check them
+    return a.users.matches(userObj.User) || a.groups.matchesAny(userObj.Groups)
+}
+
+// Return the ACL that is in effect for a child which has this ACL as the effective ACL of its parent.
+// The child ACL can narrow the access but never widen it:
+// - an ACL that is not configured does not change the parent ACL
+// - deny entries of the parent and child are combined
+// - a user or group the child allows is only kept if the parent allows it in the same list, a pattern is only kept
+//   if the parent has the same pattern
+// - group memberships are not known when narrowing: a user the parent only allows via a group is dropped
+// - the child wildcard allows what the parent allows
+func (a ACL) Narrow(child ACL) ACL {
+    if !child.configured {
+        return a
     }
-    // get groups for the user and check them
-    for _, group := range userObj.Groups {
-        if a.groups[group] {
-            return true
+    if !a.configured {
+        return child
+    }
+    narrowed := ACL{
+        users:        newPrincipals(),
+        groups:       newPrincipals(),
+        deniedUsers:  newPrincipals(),
+        deniedGroups: newPrincipals(),
+        configured:   true,
+    }
+    narrowed.deniedUsers.add(a.deniedUsers)
+    narrowed.deniedUsers.add(child.deniedUsers)
+    narrowed.deniedGroups.add(a.deniedGroups)
+    narrowed.deniedGroups.add(child.deniedGroups)
+    switch {
+    case a.allAllowed:
+        narrowed.allAllowed = child.allAllowed
+        narrowed.users.add(child.users)
+        narrowed.groups.add(child.groups)
+    case child.allAllowed:
+        narrowed.users.add(a.users)
+        narrowed.groups.add(a.groups)
+    default:
+        narrowed.users = narrowPrincipals(a.users, child.users)
+        narrowed.groups = narrowPrincipals(a.groups, child.groups)
+    }
+    return narrowed
+}
+
+// Keep the entries of the child that are allowed by the parent.
+func narrowPrincipals(parent, child principals) principals {
+    narrowed := newPrincipals()
+    for name := range child.names {
+        if parent.matches(name) {
+            narrowed.names[name] = true
+        } else {
+            log.Logger().Debug("ACL entry not allowed by parent ACL, ignored",
+                zap.String("entry", name))
         }
     }
-    return false
+    for source, pattern := range child.patterns {
+        if _, ok := parent.patterns[source]; ok {
+            narrowed.patterns[source] = pattern
+        } else {
+            log.Logger().Debug("ACL pattern not allowed by parent ACL, ignored",
+                zap.String("entry", source))
+        }
+    }
+    return narrowed
+}
+
+// Does the ACL allow all users, apart from the denied users and groups?
+func (a ACL) AllowsAll() bool {
+    return a.allAllowed
+}
+
+// The users and user patterns the ACL allows.
+func (a ACL) AllowedUsers() []string {
+    return a.users.list()
+}
+
+// The groups and group patterns the ACL allows.
+func (a ACL) AllowedGroups() []string {
+    return a.groups.list()
+}
+
+// The users and user patterns the ACL denies.
+func (a ACL) DeniedUsers() []string {
+    return a.deniedUsers.list()
+}
+
+// The groups and group patterns the ACL denies.
+func (a ACL) DeniedGroups() []string {
+    return a.deniedGroups.list()
 }
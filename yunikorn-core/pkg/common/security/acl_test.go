@@ -86,15 +86,15 @@ func TestACLSpecialCase(t *testing.T) {
         t.Errorf("parsing passed for string: '  '")
     }
     acl, err = NewACL("dotted.user")
-    if err != nil || len(acl.users) > 0 {
+    if err != nil || len(acl.users.names) > 0 {
         t.Errorf("parsing failed for string: 'dotted.user' acl has user list: %v", acl)
     }
     acl, err = NewACL("user,user")
-    if err != nil || len(acl.users) != 1 {
+    if err != nil || len(acl.users.names) != 1 {
         t.Errorf("parsing failed for string: 'user,user' acl has incorrect user list: %v", acl)
     }
     acl, err = NewACL(" group,group")
-    if err != nil || len(acl.groups) != 1 {
+    if err != nil || len(acl.groups.names) != 1 {
         t.Errorf("parsing failed for string: 'group,group' acl has incorrect group list: %v", acl)
     }
 }
@@ -132,4 +132,73 @@ func TestACLAccess(t *testing.T) {
     assert.Assert(t, !acl.CheckAccess(user), "no user, empty ACL always deny")
     user = UserGroup{User: "user1", Groups: []string{"group1"}}
     assert.Assert(t, !acl.CheckAccess(user), "user1/group1, empty ACL always deny")
-}
\ No newline at end of file
+}
+
+func TestACLDenyAndPatterns(t *testing.T) {
+    acl, err := NewACL("*,!bob !contractors")
+    assert.NilError(t, err, "parsing failed for string: '*,!bob !contractors'")
+    assert.Assert(t, acl.CheckAccess(UserGroup{User: "sue", Groups: []string{"dev"}}), "sue should have been allowed by the wildcard")
+    assert.Assert(t, !acl.CheckAccess(UserGroup{User: "bob", Groups: []string{"dev"}}), "denied user bob should have been denied")
+    assert.Assert(t, !acl.CheckAccess(UserGroup{User: "sue", Groups: []string{"dev", "contractors"}}), "denied group should have been denied")
+
+    acl, err = NewACL("/svc-[a-z]+/,sue team-*,!team-external")
+    assert.NilError(t, err, "parsing failed for string with patterns")
+    assert.Assert(t, acl.CheckAccess(UserGroup{User: "svc-build"}), "regex user should have been allowed")
+    assert.Assert(t, !acl.CheckAccess(UserGroup{User: "svc-build1"}), "regex must match the whole user name")
+    assert.Assert(t, !acl.CheckAccess(UserGroup{User: "my-svc-build"}), "regex must match the whole user name")
+    assert.Assert(t, acl.CheckAccess(UserGroup{User: "bob", Groups: []string{"team-alpha"}}), "wildcard group should have been allowed")
+    assert.Assert(t, !acl.CheckAccess(UserGroup{User: "bob", Groups: []string{"team-external"}}), "denied group should take precedence over the wildcard group")
+    assert.Assert(t, !acl.CheckAccess(UserGroup{User: "bob", Groups: []string{"teams"}}), "group not matching the wildcard should have been denied")
+    assert.DeepEqual(t, acl.AllowedUsers(), []string{"/svc-[a-z]+/", "sue"})
+    assert.DeepEqual(t, acl.AllowedGroups(), []string{"team-*"})
+    assert.DeepEqual(t, acl.DeniedGroups(), []string{"team-external"})
+
+    _, err = NewACL("/svc-[a-z+/")
+    assert.Assert(t, err != nil, "invalid regular expression should have failed")
+}
+
+func TestACLNarrow(t *testing.T) {
+    parent, err := NewACL("sue,bob,/svc-.*/ dev,ops")
+    assert.NilError(t, err, "parsing failed for parent ACL")
+    unset, err := NewACL("")
+    assert.NilError(t, err, "parsing failed for empty ACL")
+
+    // an ACL that is not configured does not change anything
+    narrowed := parent.Narrow(unset)
+    assert.DeepEqual(t, narrowed.AllowedUsers(), parent.AllowedUsers())
+    narrowed = unset.Narrow(parent)
+    assert.DeepEqual(t, narrowed.AllowedGroups(), parent.AllowedGroups())
+
+    // the child can narrow but not widen
+    child, err := NewACL("sue,john,/svc-.*/,/admin-.*/ dev,test")
+    assert.NilError(t, err, "parsing failed for child ACL")
+    narrowed = parent.Narrow(child)
+    assert.DeepEqual(t, narrowed.AllowedUsers(), []string{"/svc-.*/", "sue"})
+    assert.DeepEqual(t, narrowed.AllowedGroups(), []string{"dev"})
+    assert.Assert(t, !narrowed.CheckAccess(UserGroup{User: "john"}), "john not allowed by the parent should have been denied")
+    assert.Assert(t, !narrowed.CheckAccess(UserGroup{User: "bob"}), "bob not allowed by the child should have been denied")
+    // users and groups are narrowed separately: a user the parent only allows via a group is dropped
+    child, err = NewACL("alice")
+    assert.NilError(t, err, "parsing failed for child ACL")
+    narrowed = parent.Narrow(child)
+    assert.Assert(t, !narrowed.CheckAccess(UserGroup{User: "alice", Groups: []string{"dev"}}), "alice only allowed via a parent group should have been denied")
+
+    // the child wildcard allows what the parent allows, the child deny is added
+    child, err = NewACL("*,!sue")
+    assert.NilError(t, err, "parsing failed for child ACL")
+    narrowed = parent.Narrow(child)
+    assert.Assert(t, !narrowed.AllowsAll(), "child wildcard should not widen the parent")
+    assert.Assert(t, narrowed.CheckAccess(UserGroup{User: "bob"}), "bob allowed by the parent should have been allowed")
+    assert.Assert(t, !narrowed.CheckAccess(UserGroup{User: "sue"}), "sue denied by the child should have been denied")
+    assert.Assert(t, !narrowed.CheckAccess(UserGroup{User: "john"}), "john not allowed by the parent should have been denied")
+
+    // the parent deny is kept
+    parent, err = NewACL("* !contractors")
+    assert.NilError(t, err, "parsing failed for parent ACL")
+    child, err = NewACL("john dev")
+    assert.NilError(t, err, "parsing failed for child ACL")
+    narrowed = parent.Narrow(child)
+    assert.Assert(t, narrowed.CheckAccess(UserGroup{User: "john"}), "john should have been allowed")
+    assert.Assert(t, !narrowed.CheckAccess(UserGroup{User: "john", Groups: []string{"contractors"}}), "parent deny should have been kept")
+    assert.DeepEqual(t, narrowed.DeniedGroups(), []string{"contractors"})
+}
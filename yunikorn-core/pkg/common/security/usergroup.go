@@ -21,31 +21,63 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/log"
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
     "go.uber.org/zap"
-    "os/user"
     "sync"
     "time"
 )
 
 const (
-    negcache        = 30  // time to cache failures for lookups in seconds
-    poscache        = 300 // time to cache a positive lookup in seconds
-    cleanerInterval = 60  // default cleaner interval
+    DefaultNegativeTTL = 30 * time.Second  // time to cache failures for lookups
+    DefaultPositiveTTL = 300 * time.Second // time to cache a positive lookup
+    cleanerInterval    = 60                // default cleaner interval
+)
+
+// The types of resolvers that can be configured
+const (
+    ResolverNone = "none"
+    ResolverOS   = "os"
+    ResolverLDAP = "ldap"
+    ResolverFile = "file"
+    resolverTest = "test"
 )
 
 // global variables
-var now time.Time            // One clock to access
-var instance *UserGroupCache // The instance of the cache
-var once sync.Once           // Make sure we can only create the cache once
+var caches = make(map[ResolverConfig]*UserGroupCache) // The caches by resolver configuration
+var cachesLock sync.Mutex                             // Make sure we only create one cache per configuration
+
+// A resolver finds the groups a user is a member of.
+// The primary group of the user must be the first group in the list. An error must be returned if the user cannot
+// be resolved, the groups that were resolved before the failure may be returned with the error.
+type Resolver interface {
+    Groups(userName string) ([]string, error)
+}
+
+// The configuration of the user group cache:
+// - the type of resolver, no resolution when empty
+// - the time a resolved user is cached, the default is used when not set
+// - the time a failed user resolution is cached, the default is used when not set
+// - the LDAP settings for the LDAP resolver
+// - the file with the users and their groups for the file resolver
+type ResolverConfig struct {
+    Type        string
+    PositiveTTL time.Duration
+    NegativeTTL time.Duration
+    LDAP        LDAPConfig
+    File        string
+}
 
 // Cache for the user entries.
+// The cache is shared by all users of the same configuration and counts them: the cache is stopped and removed when
+// the last user releases it.
 type UserGroupCache struct {
-    lock     sync.RWMutex
-    interval time.Duration
-    ugs      map[string]*UserGroup
-    // methods that allow mocking of the class or extending to use non OS solutions
-    lookup        func(userName string) (*user.User, error)
-    lookupGroupId func(gid string) (*user.Group, error)
-    groupIds      func(osUser *user.User) ([]string, error)
+    lock        sync.RWMutex
+    conf        ResolverConfig
+    users       int
+    stop        chan struct{}
+    interval    time.Duration
+    positiveTTL time.Duration
+    negativeTTL time.Duration
+    ugs         map[string]*UserGroup
+    resolver    Resolver
 }
 
 // The structure of the entry in the cache.
@@ -56,51 +88,134 @@ type UserGroup struct {
     resolved int64
 }
 
-// Get the resolver for the user and group info.
-// Current setup allows three resolvers:
+// Get the cache for the named resolver with the default settings.
+// Current setup allows three resolvers by name:
 // * NO resolver: default, no user or group resolution just return the info (k8s use case)
 // * OS resolver: uses the OS libraries to resolve user and group memberships
 // * Test resolver: fake resolution for testing
-// TODO need to make this fully configurable and look at reflection etc
+// The LDAP and file resolvers need settings and can only be created from a configuration.
 func GetUserGroupCache(resolver string) *UserGroupCache {
-    once.Do(func() {
-        switch resolver {
-        case "test":
-            log.Logger().Info("creating test user group resolver")
-            instance = GetUserGroupCacheTest()
-        case "os":
-            log.Logger().Info("creating OS user group resolver")
-            instance = GetUserGroupCacheOS()
-        default:
-            log.Logger().Info("creating UserGroupCache without resolver")
-            instance = GetUserGroupNoResolve()
-        }
-        instance.ugs = make(map[string]*UserGroup)
-        log.Logger().Info("starting UserGroupCache cleaner",
-            zap.String("cleanerInterval", instance.interval.String()))
-        go instance.run()
-    })
-    return instance
+    cache, err := GetUserGroupCacheFromConfig(ResolverConfig{Type: resolver})
+    if err != nil {
+        log.Logger().Error("creating UserGroupCache failed, falling back to no resolver",
+            zap.String("resolver", resolver),
+            zap.Error(err))
+        cache, _ = GetUserGroupCacheFromConfig(ResolverConfig{})
+    }
+    return cache
 }
 
-// Run the cleanup in a separate routine
+// Get the cache for the resolver configuration.
+// The cache is shared by all callers that use the same configuration, it is created on first use. A caller that no
+// longer uses the cache, for instance after a configuration change, must release it.
+func GetUserGroupCacheFromConfig(conf ResolverConfig) (*UserGroupCache, error) {
+    cachesLock.Lock()
+    defer cachesLock.Unlock()
+    if cache, ok := caches[conf]; ok {
+        cache.users++
+        return cache, nil
+    }
+    cache := &UserGroupCache{
+        conf:        conf,
+        users:       1,
+        stop:        make(chan struct{}),
+        interval:    cleanerInterval * time.Second,
+        positiveTTL: conf.PositiveTTL,
+        negativeTTL: conf.NegativeTTL,
+        ugs:         make(map[string]*UserGroup),
+    }
+    if cache.positiveTTL <= 0 {
+        cache.positiveTTL = DefaultPositiveTTL
+    }
+    if cache.negativeTTL <= 0 {
+        cache.negativeTTL = DefaultNegativeTTL
+    }
+    var err error
+    switch conf.Type {
+    case resolverTest:
+        log.Logger().Info("creating test user group resolver")
+        cache.resolver = newTestResolver()
+        // cleaner runs every second
+        cache.interval = time.Second
+    case ResolverOS:
+        log.Logger().Info("creating OS user group resolver")
+        cache.resolver = newOSResolver()
+    case ResolverLDAP:
+        log.Logger().Info("creating LDAP user group resolver",
+            zap.String("url", conf.LDAP.URL))
+        cache.resolver, err = newLDAPResolver(conf.LDAP)
+    case ResolverFile:
+        log.Logger().Info("creating file user group resolver",
+            zap.String("file", conf.File))
+        // cached entries are outdated when the file changes
+        cache.resolver, err = newFileResolver(conf.File, cache.resetCache, cache.stop)
+    case "", ResolverNone:
+        log.Logger().Info("creating UserGroupCache without resolver")
+        cache.resolver = newNoResolver()
+    default:
+        err = fmt.Errorf("unknown user group resolver type %s", conf.Type)
+    }
+    if err != nil {
+        return nil, err
+    }
+    caches[conf] = cache
+    log.Logger().Info("starting UserGroupCache cleaner",
+        zap.String("cleanerInterval", cache.interval.String()),
+        zap.Duration("positiveTTL", cache.positiveTTL),
+        zap.Duration("negativeTTL", cache.negativeTTL))
+    go cache.run()
+    return cache, nil
+}
+
+// Release the cache: the cache and its resolver are stopped when the last user of the cache releases it.
+// The cache must not be used after it has been released.
+func (c *UserGroupCache) Release() {
+    cachesLock.Lock()
+    defer cachesLock.Unlock()
+    c.users--
+    if c.users > 0 {
+        return
+    }
+    if caches[c.conf] == c {
+        delete(caches, c.conf)
+    }
+    log.Logger().Info("stopping UserGroupCache",
+        zap.String("resolver", c.conf.Type))
+    close(c.stop)
+}
+
+// Get the number of users of the cache that have not released it.
+func (c *UserGroupCache) GetUserCount() int {
+    cachesLock.Lock()
+    defer cachesLock.Unlock()
+    return c.users
+}
+
+// Run the cleanup in a separate routine until the cache is stopped
 func (c *UserGroupCache) run() {
+    ticker := time.NewTicker(c.interval)
+    defer ticker.Stop()
     for {
-        time.Sleep(instance.interval)
-        runStart := time.Now()
-        c.cleanUpCache()
-        log.Logger().Debug("time consumed cleaning the UserGroupCache",
-            zap.String("duration", time.Since(runStart).String()))
+        select {
+        case <-c.stop:
+            return
+        case <-ticker.C:
+            runStart := time.Now()
+            c.cleanUpCache()
+            log.Logger().Debug("time consumed cleaning the UserGroupCache",
+                zap.String("duration", time.Since(runStart).String()))
+        }
     }
 }
 
 // Do the real work for the cache cleanup
 func (c *UserGroupCache) cleanUpCache() {
-    oldest := now.Unix() - poscache
-    oldestFailed := now.Unix() - negcache
+    now := time.Now()
+    oldest := now.Add(-c.positiveTTL).Unix()
+    oldestFailed := now.Add(-c.negativeTTL).Unix()
     // clean up the cache so we do not grow out of bounds
-    instance.lock.Lock()
-    defer instance.lock.Unlock()
+    c.lock.Lock()
+    defer c.lock.Unlock()
     // walk over the entries in the map and delete the expired ones, cleanup based on the resolved time.
     // Negative cached entries will expire quicker
     for key, val := range c.ugs {
@@ -110,11 +225,20 @@ func (c *UserGroupCache) cleanUpCache() {
     }
 }
 
-// reset the cached content, test use only
+// Has the cached entry expired: the cleaner only runs periodically, an entry can expire before it is cleaned up.
+func (c *UserGroupCache) isExpired(ug *UserGroup, now time.Time) bool {
+    ttl := c.positiveTTL
+    if ug.failed {
+        ttl = c.negativeTTL
+    }
+    return ug.resolved < now.Add(-ttl).Unix()
+}
+
+// reset the cached content
 func (c *UserGroupCache) resetCache() {
     log.Logger().Debug("UserGroupCache reset")
-    instance.lock.Lock()
-    defer instance.lock.Unlock()
+    c.lock.Lock()
+    defer c.lock.Unlock()
     c.ugs = make(map[string]*UserGroup)
 }
 
@@ -127,8 +251,8 @@ func (c *UserGroupCache) ConvertUGI(ugi *si.UserGroupInformation) (UserGroup, er
     if ugi.Groups == nil  || len(ugi.Groups) == 0 {
         return c.GetUserGroup(ugi.User)
     }
-    // If groups are already present we should just convert
-    newUG := UserGroup{User: ugi.User}
+    // If groups are already present we should just convert, the entry expires as a resolved entry
+    newUG := UserGroup{User: ugi.User, resolved: time.Now().Unix()}
     for _, group := range ugi.Groups {
         newUG.Groups = append(newUG.Groups, group)
     }
@@ -146,49 +270,33 @@ func (c *UserGroupCache) GetUserGroup(userName string) (UserGroup, error) {
     if userName == "" {
         return UserGroup{}, fmt.Errorf("empty user cannot resolve")
     }
-    // look in the cache before resolving
+    // look in the cache before resolving, expired entries are resolved again
     c.lock.RLock()
     ug, ok := c.ugs[userName]
     c.lock.RUnlock()
-    // return if this was not a negative cache that has not timed out
-    if ok && !ug.failed {
-        return *ug, nil
-    }
-    // nothing returned so create a new one
-    if ug == nil {
-        ug = &UserGroup{
-            User: userName,
+    if ok && !c.isExpired(ug, time.Now()) {
+        // return if this was not a negative cache that has not timed out
+        if !ug.failed {
+            return *ug, nil
         }
-    }
-    // if we failed before we could get an object back, return the existing one with an error
-    if ug.failed {
+        // if we failed before we could get an object back, return the existing one with an error
         return *ug, fmt.Errorf("user resolution failed, cached data returned: %v", time.Unix(ug.resolved, 0))
     }
+    // nothing returned or expired so create a new one, the cached entry is not changed
+    ug = &UserGroup{
+        User: userName,
+    }
     // resolve if we do not have it in the cache
-    // find the user first, then resolve the groups
-    var osUser *user.User
-    var err = error(nil)
-    osUser, err = c.lookup(userName)
+    var err error
+    ug.Groups, err = c.resolver.Groups(userName)
     if err != nil {
-        log.Logger().Error("Error resolving user: does not exist",
+        log.Logger().Error("Error resolving user and groups",
             zap.String("userName", userName),
             zap.Error(err))
         ug.failed = true
     }
-    // skip group resolution if we did not find the user
-    if osUser != nil {
-        // groups are updated in the object directly
-        err = ug.resolveGroups(osUser, c)
-        // log a failure and continue
-        if err != nil {
-            log.Logger().Error("Error resolving groups for user",
-                zap.String("userName", userName),
-                zap.Error(err))
-            ug.failed = true
-        }
-    }
     // all resolved (or not) but use this time stamp
-    ug.resolved = now.Unix()
+    ug.resolved = time.Now().Unix()
 
     // add it to the cache, even if we fail negative cache is also good to know
     c.lock.Lock()
@@ -196,36 +304,3 @@ func (c *UserGroupCache) GetUserGroup(userName string) (UserGroup, error) {
     c.ugs[userName] = ug
     return *ug, err
 }
-
-// Resolve the groups for the user if the user exists
-func (ug *UserGroup) resolveGroups(osUser *user.User, c *UserGroupCache) error {
-    var groupName *user.Group
-    var err = error(nil)
-    // resolve the primary group and add it first
-    groupName, err = c.lookupGroupId(osUser.Gid)
-    if err != nil {
-        ug.Groups = append(ug.Groups, osUser.Gid)
-    } else {
-        ug.Groups = append(ug.Groups, groupName.Name)
-    }
-    var gids []string
-    // resolve the group IDs for the user
-    gids, err = c.groupIds(osUser)
-    if err != nil {
-        return err
-    }
-    // we have a list hide the failure to resolve some of the groups and just add them as IDs
-    for _, gid := range gids {
-        // skip the primary group if it is in the list
-        if gid == osUser.Gid {
-            continue
-        }
-        groupName, err = c.lookupGroupId(gid)
-        if err != nil {
-            ug.Groups = append(ug.Groups, gid)
-        } else {
-            ug.Groups = append(ug.Groups, groupName.Name)
-        }
-    }
-    return nil
-}
@@ -0,0 +1,138 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+    "fmt"
+    "github.com/cloudera/yunikorn-core/pkg/log"
+    "go.uber.org/zap"
+    "gopkg.in/yaml.v2"
+    "io/ioutil"
+    "os"
+    "sync"
+    "time"
+)
+
+// interval at which the file is checked for changes
+const fileCheckInterval = 10 * time.Second
+
+// The content of the static user file: the groups of each user, primary group first.
+//   users:
+//     alice:
+//       - developers
+//       - admins
+type userFile struct {
+    Users map[string][]string
+}
+
+// A resolver that reads the users and their groups from a static YAML file.
+// The file is checked for changes and reloaded when it is changed. If the changed file cannot be loaded the current
+// content is kept.
+type fileResolver struct {
+    path     string
+    users    map[string][]string
+    modTime  time.Time
+    size     int64
+    onChange func()
+    stop     <-chan struct{}
+    lock     sync.RWMutex
+}
+
+// Create the resolver and load the file, the change function is called each time the file is reloaded.
+// The file is checked for changes until the stop channel is closed.
+func newFileResolver(path string, onChange func(), stop <-chan struct{}) (Resolver, error) {
+    if path == "" {
+        return nil, fmt.Errorf("file resolver must have a file set")
+    }
+    r := &fileResolver{
+        path:     path,
+        onChange: onChange,
+        stop:     stop,
+    }
+    if _, err := r.reload(); err != nil {
+        return nil, err
+    }
+    go r.watch()
+    return r, nil
+}
+
+func (r *fileResolver) Groups(userName string) ([]string, error) {
+    r.lock.RLock()
+    defer r.lock.RUnlock()
+    groups, ok := r.users[userName]
+    if !ok {
+        return nil, fmt.Errorf("user %s not found in file %s", userName, r.path)
+    }
+    return append([]string(nil), groups...), nil
+}
+
+// Check the file for changes until the resolver is stopped.
+func (r *fileResolver) watch() {
+    ticker := time.NewTicker(fileCheckInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-r.stop:
+            return
+        case <-ticker.C:
+        }
+        changed, err := r.reload()
+        if err != nil {
+            log.Logger().Error("reloading user group file failed, keeping current content",
+                zap.String("file", r.path),
+                zap.Error(err))
+            continue
+        }
+        if changed && r.onChange != nil {
+            r.onChange()
+        }
+    }
+}
+
+// Load the file if it changed since the last load, returns true if the content was replaced.
+func (r *fileResolver) reload() (bool, error) {
+    info, err := os.Stat(r.path)
+    if err != nil {
+        return false, err
+    }
+    r.lock.RLock()
+    unchanged := r.users != nil && info.ModTime().Equal(r.modTime) && info.Size() == r.size
+    r.lock.RUnlock()
+    if unchanged {
+        return false, nil
+    }
+    content, err := ioutil.ReadFile(r.path)
+    if err != nil {
+        return false, err
+    }
+    var file userFile
+    if err = yaml.UnmarshalStrict(content, &file); err != nil {
+        return false, fmt.Errorf("user group file %s cannot be parsed: %v", r.path, err)
+    }
+    if file.Users == nil {
+        file.Users = make(map[string][]string)
+    }
+    r.lock.Lock()
+    defer r.lock.Unlock()
+    r.users = file.Users
+    r.modTime = info.ModTime()
+    r.size = info.Size()
+    log.Logger().Info("user group file loaded",
+        zap.String("file", r.path),
+        zap.Int("users", len(r.users)))
+    return true, nil
+}
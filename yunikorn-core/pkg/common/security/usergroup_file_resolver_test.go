@@ -0,0 +1,121 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestFileResolver(t *testing.T) {
+    dir, err := ioutil.TempDir("", "usergroup")
+    if err != nil {
+        t.Fatalf("temp dir create failed: %v", err)
+    }
+    defer os.RemoveAll(dir)
+    path := filepath.Join(dir, "users.yaml")
+
+    stop := make(chan struct{})
+    defer close(stop)
+    if _, err = newFileResolver(path, nil, stop); err == nil {
+        t.Error("file resolver with missing file should have failed")
+    }
+    if err = ioutil.WriteFile(path, []byte("users:\n  alice: [developers, admins]\n"), 0644); err != nil {
+        t.Fatalf("user file write failed: %v", err)
+    }
+    changes := 0
+    resolver, err := newFileResolver(path, func() { changes++ }, stop)
+    if err != nil {
+        t.Fatalf("file resolver create failed: %v", err)
+    }
+    groups, err := resolver.Groups("alice")
+    if err != nil || len(groups) != 2 || groups[0] != "developers" {
+        t.Errorf("user alice not resolved correctly: %v, error: %v", groups, err)
+    }
+    if _, err = resolver.Groups("bob"); err == nil {
+        t.Error("unknown user should have failed")
+    }
+
+    // unchanged file is not reloaded
+    fileResolver := resolver.(*fileResolver)
+    changed, err := fileResolver.reload()
+    if changed || err != nil {
+        t.Errorf("unchanged file should not have been reloaded, changed %t, error: %v", changed, err)
+    }
+    // changed file is reloaded
+    if err = ioutil.WriteFile(path, []byte("users:\n  bob: [operators]\n"), 0644); err != nil {
+        t.Fatalf("user file write failed: %v", err)
+    }
+    // make sure the modification time changes on file systems with a coarse resolution
+    later := time.Now().Add(time.Minute)
+    if err = os.Chtimes(path, later, later); err != nil {
+        t.Fatalf("user file time change failed: %v", err)
+    }
+    changed, err = fileResolver.reload()
+    if !changed || err != nil {
+        t.Errorf("changed file should have been reloaded, changed %t, error: %v", changed, err)
+    }
+    groups, err = resolver.Groups("bob")
+    if err != nil || len(groups) != 1 || groups[0] != "operators" {
+        t.Errorf("user bob not resolved correctly after reload: %v, error: %v", groups, err)
+    }
+    if _, err = resolver.Groups("alice"); err == nil {
+        t.Error("removed user alice should have failed after reload")
+    }
+    // broken file keeps the current content
+    if err = ioutil.WriteFile(path, []byte("users: [broken"), 0644); err != nil {
+        t.Fatalf("user file write failed: %v", err)
+    }
+    later = later.Add(time.Minute)
+    if err = os.Chtimes(path, later, later); err != nil {
+        t.Fatalf("user file time change failed: %v", err)
+    }
+    if _, err = fileResolver.reload(); err == nil {
+        t.Error("broken file should have failed to load")
+    }
+    if groups, err = resolver.Groups("bob"); err != nil || len(groups) != 1 {
+        t.Errorf("content not kept after a failed reload: %v, error: %v", groups, err)
+    }
+}
+
+func TestUserGroupCacheFromConfig(t *testing.T) {
+    if _, err := GetUserGroupCacheFromConfig(ResolverConfig{Type: "unknown"}); err == nil {
+        t.Error("unknown resolver type should have failed")
+    }
+    conf := ResolverConfig{Type: ResolverNone, PositiveTTL: time.Minute, NegativeTTL: time.Second}
+    cache, err := GetUserGroupCacheFromConfig(conf)
+    if err != nil {
+        t.Fatalf("cache create failed: %v", err)
+    }
+    if cache.positiveTTL != time.Minute || cache.negativeTTL != time.Second {
+        t.Errorf("cache TTLs not set from the config: %v, %v", cache.positiveTTL, cache.negativeTTL)
+    }
+    other, err := GetUserGroupCacheFromConfig(conf)
+    if err != nil || other != cache {
+        t.Errorf("cache not shared for the same config, error: %v", err)
+    }
+    other, err = GetUserGroupCacheFromConfig(ResolverConfig{})
+    if err != nil || other == cache {
+        t.Errorf("cache shared for a different config, error: %v", err)
+    }
+    if other.positiveTTL != DefaultPositiveTTL || other.negativeTTL != DefaultNegativeTTL {
+        t.Errorf("cache default TTLs not set: %v, %v", other.positiveTTL, other.negativeTTL)
+    }
+}
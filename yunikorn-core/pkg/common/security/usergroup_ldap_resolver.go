@@ -0,0 +1,187 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+    "crypto/tls"
+    "crypto/x509"
+    "fmt"
+    "gopkg.in/ldap.v3"
+    "io/ioutil"
+    "net/url"
+    "strings"
+)
+
+const (
+    DefaultLDAPUserFilter     = "(uid=%s)"
+    DefaultLDAPGroupAttribute = "memberOf"
+)
+
+// The settings for the LDAP resolver:
+// - URL of the server, ldap:// or ldaps:// (TLS)
+// - upgrade a plain ldap:// connection to TLS using StartTLS
+// - file with the PEM encoded CA certificates to verify the server certificate, system CAs are used if not set
+// - skip verification of the server certificate (testing only)
+// - DN and password to bind with, the password can also be read from a file, anonymous bind if the DN is not set
+// - base DN to search for users
+// - filter to find the user entry, %s is replaced with the escaped user name
+// - attribute of the user entry that lists the groups, the first value is the primary group
+type LDAPConfig struct {
+    URL                string
+    StartTLS           bool
+    CACertFile         string
+    InsecureSkipVerify bool
+    BindDN             string
+    BindPassword       string
+    BindPasswordFile   string
+    SearchBase         string
+    UserFilter         string
+    GroupAttribute     string
+}
+
+// The part of the LDAP connection used by the resolver, allows replacing the server in tests.
+type ldapConn interface {
+    Bind(username, password string) error
+    Search(request *ldap.SearchRequest) (*ldap.SearchResult, error)
+    Close()
+}
+
+// A resolver that finds the user entry in an LDAP directory and takes the groups from an attribute of the entry.
+// A new connection is made for each lookup: lookups are cached by the user group cache.
+type ldapResolver struct {
+    conf      LDAPConfig
+    tlsConfig *tls.Config
+    dial      func() (ldapConn, error)
+}
+
+func newLDAPResolver(conf LDAPConfig) (Resolver, error) {
+    if conf.SearchBase == "" {
+        return nil, fmt.Errorf("LDAP resolver must have a search base set")
+    }
+    if conf.UserFilter == "" {
+        conf.UserFilter = DefaultLDAPUserFilter
+    }
+    if conf.GroupAttribute == "" {
+        conf.GroupAttribute = DefaultLDAPGroupAttribute
+    }
+    serverURL, err := url.Parse(conf.URL)
+    if err != nil {
+        return nil, fmt.Errorf("LDAP resolver URL is not valid: %v", err)
+    }
+    if serverURL.Scheme != "ldap" && serverURL.Scheme != "ldaps" {
+        return nil, fmt.Errorf("LDAP resolver URL must use the ldap or ldaps scheme: %s", conf.URL)
+    }
+    if conf.BindPasswordFile != "" {
+        var password []byte
+        if password, err = ioutil.ReadFile(conf.BindPasswordFile); err != nil {
+            return nil, fmt.Errorf("LDAP resolver bind password file cannot be read: %v", err)
+        }
+        conf.BindPassword = strings.TrimSpace(string(password))
+    }
+    r := &ldapResolver{
+        conf: conf,
+        tlsConfig: &tls.Config{
+            ServerName:         serverURL.Hostname(),
+            InsecureSkipVerify: conf.InsecureSkipVerify,
+        },
+    }
+    if conf.CACertFile != "" {
+        var caCerts []byte
+        if caCerts, err = ioutil.ReadFile(conf.CACertFile); err != nil {
+            return nil, fmt.Errorf("LDAP resolver CA certificate file cannot be read: %v", err)
+        }
+        r.tlsConfig.RootCAs = x509.NewCertPool()
+        if !r.tlsConfig.RootCAs.AppendCertsFromPEM(caCerts) {
+            return nil, fmt.Errorf("LDAP resolver CA certificate file does not contain PEM certificates: %s", conf.CACertFile)
+        }
+    }
+    r.dial = r.dialServer
+    return r, nil
+}
+
+// Connect to the server, upgrading the connection to TLS when requested.
+func (r *ldapResolver) dialServer() (ldapConn, error) {
+    var conn *ldap.Conn
+    var err error
+    if strings.HasPrefix(r.conf.URL, "ldaps://") {
+        conn, err = ldap.DialTLS("tcp", hostPort(r.conf.URL, ldap.DefaultLdapsPort), r.tlsConfig)
+    } else {
+        conn, err = ldap.Dial("tcp", hostPort(r.conf.URL, ldap.DefaultLdapPort))
+    }
+    if err != nil {
+        return nil, err
+    }
+    if r.conf.StartTLS {
+        if err = conn.StartTLS(r.tlsConfig); err != nil {
+            conn.Close()
+            return nil, err
+        }
+    }
+    return conn, nil
+}
+
+// Get the host and port from the URL, the URL was validated when the resolver was created.
+func hostPort(serverURL, defaultPort string) string {
+    parsed, _ := url.Parse(serverURL)
+    if parsed.Port() == "" {
+        return parsed.Hostname() + ":" + defaultPort
+    }
+    return parsed.Host
+}
+
+// Search the user entry and return the groups from the group attribute.
+func (r *ldapResolver) Groups(userName string) ([]string, error) {
+    conn, err := r.dial()
+    if err != nil {
+        return nil, fmt.Errorf("LDAP connect failed: %v", err)
+    }
+    defer conn.Close()
+    if r.conf.BindDN != "" {
+        if err = conn.Bind(r.conf.BindDN, r.conf.BindPassword); err != nil {
+            return nil, fmt.Errorf("LDAP bind failed: %v", err)
+        }
+    }
+    request := ldap.NewSearchRequest(r.conf.SearchBase,
+        ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+        fmt.Sprintf(r.conf.UserFilter, ldap.EscapeFilter(userName)),
+        []string{r.conf.GroupAttribute},
+        nil)
+    result, err := conn.Search(request)
+    if err != nil {
+        return nil, fmt.Errorf("LDAP search failed: %v", err)
+    }
+    if len(result.Entries) != 1 {
+        return nil, fmt.Errorf("LDAP search for user %s returned %d entries, expected 1", userName, len(result.Entries))
+    }
+    var groups []string
+    for _, value := range result.Entries[0].GetAttributeValues(r.conf.GroupAttribute) {
+        groups = append(groups, groupName(value))
+    }
+    return groups, nil
+}
+
+// Get the group name from an attribute value: for a DN the value of the first part is the name,
+// for example cn=developers,ou=groups,dc=example,dc=com is the group developers.
+func groupName(value string) string {
+    if !strings.Contains(value, "=") {
+        return value
+    }
+    if dn, err := ldap.ParseDN(value); err == nil && len(dn.RDNs) > 0 && len(dn.RDNs[0].Attributes) > 0 {
+        return dn.RDNs[0].Attributes[0].Value
+    }
+    return value
+}
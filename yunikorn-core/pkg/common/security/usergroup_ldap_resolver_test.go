@@ -0,0 +1,142 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+    "fmt"
+    "gopkg.in/ldap.v3"
+    "testing"
+)
+
+// A local stand-in for an LDAP server: users are found with the default user filter.
+type fakeLDAPConn struct {
+    bindDN   string
+    password string
+    bound    bool
+    closed   bool
+    users    map[string]*ldap.Entry
+}
+
+func (c *fakeLDAPConn) Bind(username, password string) error {
+    if username != c.bindDN || password != c.password {
+        return ldap.NewError(ldap.LDAPResultInvalidCredentials, fmt.Errorf("invalid credentials"))
+    }
+    c.bound = true
+    return nil
+}
+
+func (c *fakeLDAPConn) Search(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+    if c.bindDN != "" && !c.bound {
+        return nil, ldap.NewError(ldap.LDAPResultInsufficientAccessRights, fmt.Errorf("not bound"))
+    }
+    result := &ldap.SearchResult{}
+    for name, entry := range c.users {
+        if request.Filter == fmt.Sprintf(DefaultLDAPUserFilter, name) && request.BaseDN == "dc=example,dc=com" {
+            result.Entries = append(result.Entries, entry)
+        }
+    }
+    return result, nil
+}
+
+func (c *fakeLDAPConn) Close() {
+    c.closed = true
+}
+
+func TestLDAPResolverConfig(t *testing.T) {
+    if _, err := newLDAPResolver(LDAPConfig{URL: "ldap://localhost"}); err == nil {
+        t.Error("LDAP resolver without search base should have failed")
+    }
+    if _, err := newLDAPResolver(LDAPConfig{URL: "http://localhost", SearchBase: "dc=example,dc=com"}); err == nil {
+        t.Error("LDAP resolver with http URL should have failed")
+    }
+    if _, err := newLDAPResolver(LDAPConfig{URL: "ldaps://localhost", SearchBase: "dc=example,dc=com", CACertFile: "/unknown/ca.pem"}); err == nil {
+        t.Error("LDAP resolver with missing CA file should have failed")
+    }
+    resolver, err := newLDAPResolver(LDAPConfig{URL: "ldaps://ldap.example.com:1636", SearchBase: "dc=example,dc=com"})
+    if err != nil {
+        t.Fatalf("LDAP resolver create failed: %v", err)
+    }
+    ldapResolver := resolver.(*ldapResolver)
+    if ldapResolver.conf.UserFilter != DefaultLDAPUserFilter || ldapResolver.conf.GroupAttribute != DefaultLDAPGroupAttribute {
+        t.Errorf("LDAP resolver defaults not set: %v", ldapResolver.conf)
+    }
+    if ldapResolver.tlsConfig.ServerName != "ldap.example.com" {
+        t.Errorf("LDAP resolver TLS server name not set: %s", ldapResolver.tlsConfig.ServerName)
+    }
+    if hostPort("ldaps://ldap.example.com:1636", ldap.DefaultLdapsPort) != "ldap.example.com:1636" ||
+        hostPort("ldap://ldap.example.com", ldap.DefaultLdapPort) != "ldap.example.com:389" {
+        t.Error("LDAP host and port not resolved correctly from the URL")
+    }
+}
+
+func TestLDAPResolverGroups(t *testing.T) {
+    conn := &fakeLDAPConn{
+        bindDN:   "cn=scheduler,dc=example,dc=com",
+        password: "secret",
+        users: map[string]*ldap.Entry{
+            "alice": ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+                DefaultLDAPGroupAttribute: {"cn=developers,ou=groups,dc=example,dc=com", "admins"},
+            }),
+            "bob": ldap.NewEntry("uid=bob,ou=people,dc=example,dc=com", map[string][]string{}),
+        },
+    }
+    resolver, err := newLDAPResolver(LDAPConfig{
+        URL:          "ldap://localhost",
+        SearchBase:   "dc=example,dc=com",
+        BindDN:       "cn=scheduler,dc=example,dc=com",
+        BindPassword: "secret",
+    })
+    if err != nil {
+        t.Fatalf("LDAP resolver create failed: %v", err)
+    }
+    resolver.(*ldapResolver).dial = func() (ldapConn, error) {
+        conn.bound = false
+        conn.closed = false
+        return conn, nil
+    }
+
+    groups, err := resolver.Groups("alice")
+    if err != nil || len(groups) != 2 || groups[0] != "developers" || groups[1] != "admins" {
+        t.Errorf("user alice not resolved correctly: %v, error: %v", groups, err)
+    }
+    if !conn.closed {
+        t.Error("LDAP connection not closed after the lookup")
+    }
+    groups, err = resolver.Groups("bob")
+    if err != nil || len(groups) != 0 {
+        t.Errorf("user bob without groups not resolved correctly: %v, error: %v", groups, err)
+    }
+    // unknown user and special characters in the user name are escaped and not found
+    if _, err = resolver.Groups("unknown"); err == nil {
+        t.Error("unknown user should have failed")
+    }
+    if _, err = resolver.Groups("alice)(uid=*"); err == nil {
+        t.Error("user name with filter characters should have failed")
+    }
+    // wrong password fails the bind
+    conn.password = "changed"
+    if _, err = resolver.Groups("alice"); err == nil {
+        t.Error("lookup with invalid bind credentials should have failed")
+    }
+    // connection failure
+    resolver.(*ldapResolver).dial = func() (ldapConn, error) {
+        return nil, fmt.Errorf("connection refused")
+    }
+    if _, err = resolver.Groups("alice"); err == nil {
+        t.Error("lookup without a connection should have failed")
+    }
+}
@@ -18,16 +18,13 @@ package security
 
 import (
     "os/user"
-    "time"
 )
 
-// Get the cache without a resolver.
+// Get the resolver that does not resolve.
 // In k8shim we currently have internal users to K8s which might not resolve against anything.
 // Just echo the object in the correct format based on the user passed in.
-func GetUserGroupNoResolve() *UserGroupCache {
-    return &UserGroupCache{
-        ugs:           map[string]*UserGroup{},
-        interval:      cleanerInterval * time.Second,
+func newNoResolver() Resolver {
+    return &lookupResolver{
         lookup:        noLookupUser,
         lookupGroupId: noLookupGroupId,
         groupIds:      noLookupGroupIds,
@@ -18,14 +18,19 @@ package security
 
 import (
     "os/user"
-    "time"
 )
 
-// Get the cache and use that to resolve all user requests
-func GetUserGroupCacheOS() *UserGroupCache {
-    return &UserGroupCache{
-        ugs:           map[string]*UserGroup{},
-        interval:      cleanerInterval * time.Second,
+// A resolver that uses OS style lookups to resolve users and groups.
+// The functions allow mocking of the lookups or extending to use non OS solutions.
+type lookupResolver struct {
+    lookup        func(userName string) (*user.User, error)
+    lookupGroupId func(gid string) (*user.Group, error)
+    groupIds      func(osUser *user.User) ([]string, error)
+}
+
+// Get the resolver that uses the OS libraries to resolve all user requests
+func newOSResolver() Resolver {
+    return &lookupResolver{
         lookup:        user.Lookup,
         lookupGroupId: user.LookupGroupId,
         groupIds:      wrappedGroupIds,
@@ -37,3 +42,43 @@ func wrappedGroupIds(osUser *user.User) ([]string, error) {
     return osUser.GroupIds()
 }
 
+// Find the user first, then resolve the groups.
+func (r *lookupResolver) Groups(userName string) ([]string, error) {
+    osUser, err := r.lookup(userName)
+    if err != nil {
+        return nil, err
+    }
+    return r.resolveGroups(osUser)
+}
+
+// Resolve the groups for the user if the user exists
+func (r *lookupResolver) resolveGroups(osUser *user.User) ([]string, error) {
+    var groups []string
+    // resolve the primary group and add it first
+    groupName, err := r.lookupGroupId(osUser.Gid)
+    if err != nil {
+        groups = append(groups, osUser.Gid)
+    } else {
+        groups = append(groups, groupName.Name)
+    }
+    var gids []string
+    // resolve the group IDs for the user
+    gids, err = r.groupIds(osUser)
+    if err != nil {
+        return groups, err
+    }
+    // we have a list hide the failure to resolve some of the groups and just add them as IDs
+    for _, gid := range gids {
+        // skip the primary group if it is in the list
+        if gid == osUser.Gid {
+            continue
+        }
+        groupName, err = r.lookupGroupId(gid)
+        if err != nil {
+            groups = append(groups, gid)
+        } else {
+            groups = append(groups, groupName.Name)
+        }
+    }
+    return groups, nil
+}
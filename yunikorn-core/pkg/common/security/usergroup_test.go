@@ -19,6 +19,7 @@ package security
 import (
     "strings"
     "testing"
+    "time"
 )
 
 func TestGetUserGroupCache(t *testing.T) {
@@ -163,7 +164,7 @@ func TestCacheCleanUp(t *testing.T) {
         t.Error("User 'testuser1' not resolved as a success")
     }
     // expire the successful lookup
-    ug.resolved -= 2 * poscache
+    ug.resolved -= 2 * int64(testCache.positiveTTL.Seconds())
 
     // resolve a non existing user
     _, err = testCache.GetUserGroup("unknown")
@@ -175,10 +176,79 @@ func TestCacheCleanUp(t *testing.T) {
         t.Error("User 'unknown' not resolved as a failure")
     }
     // expire the failed lookup
-    ug.resolved -= 2 * negcache
+    ug.resolved -= 2 * int64(testCache.negativeTTL.Seconds())
 
     testCache.cleanUpCache()
     if len(testCache.ugs) != 1 {
         t.Errorf("Cache not cleaned up : %v", testCache.ugs)
     }
 }
+
+func TestUserGroupExpiry(t *testing.T) {
+    testCache, err := GetUserGroupCacheFromConfig(ResolverConfig{Type: resolverTest, PositiveTTL: 10 * time.Second, NegativeTTL: 5 * time.Second})
+    if err != nil {
+        t.Fatalf("cache create failed: %v", err)
+    }
+    defer testCache.Release()
+
+    ug, err := testCache.GetUserGroup("testuser1")
+    if err != nil {
+        t.Fatalf("Lookup should not have failed: testuser1")
+    }
+    // entries younger than the TTL are returned from the cache
+    cachedUG := testCache.ugs["testuser1"]
+    cachedUG.resolved -= 5
+    ug, _ = testCache.GetUserGroup("testuser1")
+    if ug.resolved != cachedUG.resolved {
+        t.Errorf("User 'testuser1' not returned from cache, resolution time differs: %d got %d", ug.resolved, cachedUG.resolved)
+    }
+    // entries older than the TTL are resolved again before the cleaner removes them
+    cachedUG.resolved -= 10
+    ug, _ = testCache.GetUserGroup("testuser1")
+    if ug.resolved == cachedUG.resolved || len(ug.Groups) != 2 {
+        t.Errorf("Expired user 'testuser1' not resolved again: %v", ug)
+    }
+
+    // failures expire after the negative TTL
+    _, err = testCache.GetUserGroup("unknown")
+    if err == nil {
+        t.Error("Lookup should have failed: unknown user")
+    }
+    failedUG := testCache.ugs["unknown"]
+    failedUG.resolved -= 6
+    ug, err = testCache.GetUserGroup("unknown")
+    if err == nil || ug.resolved == failedUG.resolved {
+        t.Errorf("Expired failure for user 'unknown' not resolved again: %v, error: %v", ug, err)
+    }
+}
+
+func TestReleaseUserGroupCache(t *testing.T) {
+    conf := ResolverConfig{Type: resolverTest, PositiveTTL: 20 * time.Second}
+    cache1, err := GetUserGroupCacheFromConfig(conf)
+    if err != nil {
+        t.Fatalf("cache create failed: %v", err)
+    }
+    cache2, err := GetUserGroupCacheFromConfig(conf)
+    if err != nil || cache1 != cache2 {
+        t.Fatalf("cache with the same configuration should be shared, error: %v", err)
+    }
+    // the cache keeps running while it is used
+    cache1.Release()
+    select {
+    case <-cache1.stop:
+        t.Fatal("cache stopped while still in use")
+    default:
+    }
+    // the last release stops the cache and removes it
+    cache2.Release()
+    select {
+    case <-cache1.stop:
+    default:
+        t.Fatal("cache not stopped after the last release")
+    }
+    cache3, err := GetUserGroupCacheFromConfig(conf)
+    if err != nil || cache3 == cache1 {
+        t.Fatalf("stopped cache should have been replaced, error: %v", err)
+    }
+    cache3.Release()
+}
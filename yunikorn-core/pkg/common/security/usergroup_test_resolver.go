@@ -20,15 +20,11 @@ import (
     "fmt"
     "os/user"
     "strconv"
-    "time"
 )
 
-// Get the cache with a test resolver
-// cleaner runs every second
-func GetUserGroupCacheTest() *UserGroupCache {
-    return &UserGroupCache{
-        ugs:           map[string]*UserGroup{},
-        interval:      time.Second,
+// Get the test resolver
+func newTestResolver() Resolver {
+    return &lookupResolver{
         lookup:        lookup,
         lookupGroupId: lookupGroupId,
         groupIds:      groupIds,
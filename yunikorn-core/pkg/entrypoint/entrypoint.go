@@ -28,13 +28,27 @@ import (
 type StartupOptions struct {
     manualScheduleFlag bool
     startWebAppFlag bool
+    scheduleLoopConfig scheduler.ScheduleLoopConfig
+    webAuthConfig webservice.AuthConfig
 }
 
 func StartAllServices() *ServiceContext {
+    return StartAllServicesWithScheduleLoopConfig(scheduler.DefaultScheduleLoopConfig())
+}
+
+// Start all services with the given settings for the scheduling loop.
+func StartAllServicesWithScheduleLoopConfig(loopConfig scheduler.ScheduleLoopConfig) *ServiceContext {
+    return StartAllServicesWithConfig(loopConfig, webservice.AuthConfig{})
+}
+
+// Start all services with the given settings for the scheduling loop and the authentication of the web service.
+func StartAllServicesWithConfig(loopConfig scheduler.ScheduleLoopConfig, webAuthConfig webservice.AuthConfig) *ServiceContext {
     return startAllServicesWithParameters(
         StartupOptions{
             manualScheduleFlag: false,
             startWebAppFlag:    true,
+            scheduleLoopConfig: loopConfig,
+            webAuthConfig:      webAuthConfig,
         })
 }
 
@@ -50,6 +64,7 @@ func StartAllServicesWithManualScheduler() *ServiceContext {
 func startAllServicesWithParameters(opts StartupOptions) *ServiceContext {
     cache, metrics := cache.NewClusterInfo()
     scheduler := scheduler.NewScheduler(cache, metrics)
+    scheduler.SetScheduleLoopConfig(opts.scheduleLoopConfig)
     proxy := rmproxy.NewRMProxy()
 
     eventHandler := handler.EventHandlers{
@@ -70,7 +85,7 @@ func startAllServicesWithParameters(opts StartupOptions) *ServiceContext {
     }
 
     if opts.startWebAppFlag {
-        webapp := webservice.NewWebApp(cache)
+        webapp := webservice.NewWebApp(cache, opts.webAuthConfig)
         webapp.StartWebApp()
         context.WebApp = webapp
     }
@@ -34,6 +34,9 @@ type ServiceContext struct {
 
 func (s *ServiceContext) StopAll() {
 	// TODO implement stop for services
+	if s.Cache != nil {
+		s.Cache.StopService()
+	}
 	if s.WebApp != nil {
 		if err := s.WebApp.StopWebApp(); err != nil {
 			log.Logger().Error("failed to stop web-app",
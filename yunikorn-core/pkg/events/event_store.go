@@ -0,0 +1,133 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+    "sync"
+    "time"
+)
+
+// number of events kept in the store, the oldest events are dropped first
+const DefaultEventStoreSize = 10000
+
+// singleton instance of the EventStore
+var instance *EventStore
+var once sync.Once
+
+// A listener is called for every event recorded in the store, including repeating events.
+// The listener is called outside the store lock but on the routine recording the event: it must not block.
+type Listener interface {
+    EventRecorded(event Event)
+}
+
+// A bounded in-memory store for events.
+// The events are kept in a ring buffer: when the store is full the oldest event is overwritten.
+type EventStore struct {
+    events   []*Event           // ring buffer of events
+    next     int                // position in the ring buffer the next event is stored
+    latest   map[string]*Event  // last event stored per object to collapse repeating events
+    listener Listener           // listener to pass recorded events on to
+    lock     sync.RWMutex
+}
+
+// Gets singleton instance of the EventStore
+func GetEventStore() *EventStore {
+    once.Do(func() {
+        instance = NewEventStore(DefaultEventStoreSize)
+    })
+    return instance
+}
+
+// Create a new store that keeps at most the given number of events.
+func NewEventStore(size int) *EventStore {
+    if size < 1 {
+        size = 1
+    }
+    return &EventStore{
+        events: make([]*Event, size),
+        latest: make(map[string]*Event),
+    }
+}
+
+// Record an event in the singleton store.
+func Record(event *Event) {
+    GetEventStore().Record(event)
+}
+
+// Set the listener for the store, replaces the current listener.
+// Setting the listener to nil removes the listener.
+func (s *EventStore) SetListener(listener Listener) {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+
+    s.listener = listener
+}
+
+// Record an event in the store and pass it on to the listener.
+// If the last event for the same object is a repeat of this event the existing event is updated.
+func (s *EventStore) Record(event *Event) {
+    stored := *event
+    stored.FirstTime = time.Now()
+    stored.LastTime = stored.FirstTime
+    stored.Count = 1
+
+    s.lock.Lock()
+    listener := s.listener
+    s.recordInternal(&stored)
+    s.lock.Unlock()
+
+    if listener != nil {
+        listener.EventRecorded(stored)
+    }
+}
+
+// Store the event, must be called while holding the lock.
+func (s *EventStore) recordInternal(stored *Event) {
+    key := stored.objectKey()
+    if last, ok := s.latest[key]; ok && stored.isRepeatOf(last) {
+        last.LastTime = stored.LastTime
+        last.Count++
+        return
+    }
+    // drop the oldest event, it is no longer the latest for its object
+    if old := s.events[s.next]; old != nil && s.latest[old.objectKey()] == old {
+        delete(s.latest, old.objectKey())
+    }
+    s.events[s.next] = stored
+    s.latest[key] = stored
+    s.next = (s.next + 1) % len(s.events)
+}
+
+// Get a copy of all events related to the object in the partition, oldest first.
+// All events are returned if the object type is empty, an empty partition returns the events of all partitions.
+func (s *EventStore) GetEvents(partition, objectType, objectId string) []Event {
+    s.lock.RLock()
+    defer s.lock.RUnlock()
+
+    result := make([]Event, 0)
+    size := len(s.events)
+    for i := 0; i < size; i++ {
+        event := s.events[(s.next+i)%size]
+        if event == nil {
+            continue
+        }
+        if event.matches(partition, objectType, objectId) {
+            result = append(result, *event)
+        }
+    }
+    return result
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+    "gotest.tools/assert"
+    "testing"
+)
+
+func TestRecordAndFilter(t *testing.T) {
+    store := NewEventStore(10)
+    store.Record(&Event{ObjectType: ObjectApplication, ObjectId: "app-1", ApplicationId: "app-1", QueueName: "root.a",
+        Reason: ApplicationAccepted})
+    store.Record(&Event{ObjectType: ObjectAsk, ObjectId: "ask-1", ApplicationId: "app-1", QueueName: "root.a",
+        NodeId: "node-1", Reason: PredicateFailed, Message: "node selector"})
+    store.Record(&Event{ObjectType: ObjectApplication, ObjectId: "app-2", ApplicationId: "app-2", QueueName: "root.b",
+        Reason: ApplicationRejected, Message: "queue does not exist"})
+
+    assert.Equal(t, len(store.GetEvents("", "", "")), 3)
+    // ask events are returned for the application, queue and node of the ask
+    appEvents := store.GetEvents("", ObjectApplication, "app-1")
+    assert.Equal(t, len(appEvents), 2)
+    assert.Equal(t, appEvents[0].Reason, ApplicationAccepted)
+    assert.Equal(t, appEvents[1].Reason, PredicateFailed)
+    assert.Equal(t, len(store.GetEvents("", ObjectAsk, "ask-1")), 1)
+    assert.Equal(t, len(store.GetEvents("", ObjectNode, "node-1")), 1)
+    assert.Equal(t, len(store.GetEvents("", ObjectQueue, "root.b")), 1)
+    assert.Equal(t, len(store.GetEvents("", ObjectApplication, "unknown")), 0)
+}
+
+func TestFilterPartition(t *testing.T) {
+    store := NewEventStore(10)
+    store.Record(&Event{ObjectType: ObjectApplication, ObjectId: "app-1", Partition: "[rm:123]default",
+        ApplicationId: "app-1", QueueName: "root.a", Reason: ApplicationAccepted})
+    store.Record(&Event{ObjectType: ObjectApplication, ObjectId: "app-1", Partition: "[rm:123]gpu",
+        ApplicationId: "app-1", QueueName: "root.a", Reason: ApplicationAccepted})
+    store.Record(&Event{ObjectType: ObjectAsk, ObjectId: "ask-1", Partition: "[rm:123]gpu",
+        ApplicationId: "app-2", QueueName: "root.b", Reason: AskSkippedHeadroom})
+
+    // the same application in two partitions are two objects: the events are not repeats
+    assert.Equal(t, len(store.GetEvents("", ObjectApplication, "app-1")), 2)
+    appEvents := store.GetEvents("[rm:123]default", ObjectApplication, "app-1")
+    assert.Equal(t, len(appEvents), 1)
+    assert.Equal(t, appEvents[0].Partition, "[rm:123]default")
+    assert.Equal(t, appEvents[0].Count, 1)
+    assert.Equal(t, len(store.GetEvents("[rm:123]default", ObjectQueue, "root.b")), 0)
+    assert.Equal(t, len(store.GetEvents("[rm:123]gpu", ObjectQueue, "root.b")), 1)
+    // all events of the partition
+    assert.Equal(t, len(store.GetEvents("[rm:123]gpu", "", "")), 2)
+    assert.Equal(t, len(store.GetEvents("[rm:123]unknown", "", "")), 0)
+}
+
+func TestRepeatingEvents(t *testing.T) {
+    store := NewEventStore(10)
+    event := &Event{ObjectType: ObjectAsk, ObjectId: "ask-1", ApplicationId: "app-1", Reason: AskSkippedHeadroom}
+    store.Record(event)
+    store.Record(event)
+    store.Record(event)
+    events := store.GetEvents("", "", "")
+    assert.Equal(t, len(events), 1)
+    assert.Equal(t, events[0].Count, 3)
+    assert.Assert(t, !events[0].LastTime.Before(events[0].FirstTime), "last time should not be before the first time")
+
+    // a different event in between breaks the repeat
+    store.Record(&Event{ObjectType: ObjectAsk, ObjectId: "ask-1", ApplicationId: "app-1", NodeId: "node-1", Reason: PredicateFailed})
+    store.Record(event)
+    events = store.GetEvents("", "", "")
+    assert.Equal(t, len(events), 3)
+    assert.Equal(t, events[2].Count, 1)
+}
+
+func TestBoundedStore(t *testing.T) {
+    store := NewEventStore(2)
+    store.Record(&Event{ObjectType: ObjectApplication, ObjectId: "app-1", ApplicationId: "app-1", Reason: ApplicationAccepted})
+    store.Record(&Event{ObjectType: ObjectApplication, ObjectId: "app-2", ApplicationId: "app-2", Reason: ApplicationAccepted})
+    store.Record(&Event{ObjectType: ObjectApplication, ObjectId: "app-3", ApplicationId: "app-3", Reason: ApplicationAccepted})
+    events := store.GetEvents("", "", "")
+    assert.Equal(t, len(events), 2)
+    assert.Equal(t, events[0].ObjectId, "app-2")
+    assert.Equal(t, events[1].ObjectId, "app-3")
+
+    // the dropped event is not updated when it repeats
+    store.Record(&Event{ObjectType: ObjectApplication, ObjectId: "app-1", ApplicationId: "app-1", Reason: ApplicationAccepted})
+    events = store.GetEvents("", ObjectApplication, "app-1")
+    assert.Equal(t, len(events), 1)
+    assert.Equal(t, events[0].Count, 1)
+}
+
+func TestParseObject(t *testing.T) {
+    objectType, objectId, err := ParseObject("app:application-1")
+    assert.NilError(t, err)
+    assert.Equal(t, objectType, ObjectApplication)
+    assert.Equal(t, objectId, "application-1")
+    objectType, objectId, err = ParseObject("alloc:uuid-1")
+    assert.NilError(t, err)
+    assert.Equal(t, objectType, ObjectAllocation)
+    assert.Equal(t, objectId, "uuid-1")
+    // the id can contain a colon
+    objectType, objectId, err = ParseObject("queue:[rm:123]root.a")
+    assert.NilError(t, err)
+    assert.Equal(t, objectType, ObjectQueue)
+    assert.Equal(t, objectId, "[rm:123]root.a")
+
+    for _, object := range []string{"", "app", "app:", "job:1"} {
+        _, _, err = ParseObject(object)
+        assert.Assert(t, err != nil, "parsing '%s' should have failed", object)
+    }
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+    "fmt"
+    "strings"
+    "time"
+)
+
+// The types of objects events are recorded for
+const (
+    ObjectApplication = "app"
+    ObjectAsk         = "ask"
+    ObjectAllocation  = "alloc"
+    ObjectNode        = "node"
+    ObjectQueue       = "queue"
+)
+
+// The reasons for recording an event
+const (
+    ApplicationAccepted     = "ApplicationAccepted"
+    ApplicationRejected     = "ApplicationRejected"
+    ApplicationStateChanged = "ApplicationStateChanged"
+    ApplicationMoved        = "ApplicationMoved"
+    AskSkippedHeadroom      = "AskSkippedHeadroom"
+    PredicateFailed         = "PredicateFailed"
+    AllocationRejected      = "AllocationRejected"
+    AllocationPreempted     = "AllocationPreempted"
+    QueueOverMax            = "QueueOverMax"
+)
+
+// A structured event for an object in the scheduler.
+// The object the event is about is identified by the type and id. The application, queue and node are set
+// when they are related to the object and allow finding all events for them: an ask event for instance is also
+// returned when the events for the application of the ask are requested.
+// Repeating events for the same object are recorded once: the count and last time are updated.
+// The partition links the event to the RM the object belongs to.
+type Event struct {
+    ObjectType    string
+    ObjectId      string
+    Partition     string
+    ApplicationId string
+    QueueName     string
+    NodeId        string
+    Reason        string
+    Message       string
+    FirstTime     time.Time
+    LastTime      time.Time
+    Count         int
+}
+
+// Key of the object the event is recorded for, the same id in different partitions is a different object.
+func (e *Event) objectKey() string {
+    return e.Partition + "/" + e.ObjectType + ":" + e.ObjectId
+}
+
+// Is the event the same as the other event apart from the time and count?
+func (e *Event) isRepeatOf(other *Event) bool {
+    return e.Reason == other.Reason && e.Message == other.Message && e.NodeId == other.NodeId &&
+        e.QueueName == other.QueueName && e.ApplicationId == other.ApplicationId
+}
+
+// Is the event related to the object in the partition?
+// An empty partition matches all partitions, an empty object type matches all objects.
+func (e *Event) matches(partition, objectType, objectId string) bool {
+    if partition != "" && e.Partition != partition {
+        return false
+    }
+    switch objectType {
+    case "":
+        return true
+    case ObjectApplication:
+        return e.ApplicationId == objectId
+    case ObjectQueue:
+        return e.QueueName == objectId
+    case ObjectNode:
+        return e.NodeId == objectId
+    default:
+        return e.ObjectType == objectType && e.ObjectId == objectId
+    }
+}
+
+// Parse an object reference in the form type:id.
+// The id can contain a colon, only the first colon separates the type from the id.
+func ParseObject(object string) (string, string, error) {
+    parts := strings.SplitN(object, ":", 2)
+    if len(parts) != 2 || parts[1] == "" {
+        return "", "", fmt.Errorf("object reference '%s' must have the form type:id", object)
+    }
+    switch parts[0] {
+    case ObjectApplication, ObjectAsk, ObjectAllocation, ObjectNode, ObjectQueue:
+        return parts[0], parts[1], nil
+    default:
+        return "", "", fmt.Errorf("unknown object type '%s' in object reference '%s'", parts[0], object)
+    }
+}
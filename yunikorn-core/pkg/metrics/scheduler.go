@@ -77,6 +77,10 @@ type CoreSchedulerMetrics interface {
 	SubTotalApplicationsCompleted(value int)
 	SetTotalApplicationsCompleted(value int)
 
+	// Metrics Ops related to the number of applications by state
+	IncApplicationsInState(state string)
+	DecApplicationsInState(state string)
+
 	// Metrics Ops related to ActiveNodes
 	IncActiveNodes()
 	AddActiveNodes(value int)
@@ -93,6 +97,14 @@ type CoreSchedulerMetrics interface {
 
 	//latency change
 	ObserveSchedulingLatency(start time.Time)
+
+	// Metrics Ops related to the scheduling cycles
+	ObserveSchedulingCycle(start time.Time)
+	ObserveSchedulingBatchSize(size int)
+
+	// Metrics Ops related to the fair share of the queues
+	SetQueueFairShare(partition string, queue string, resource string, value float64)
+	RemoveQueueFairShare(partition string, queue string, resource string)
 }
 
 // All core metrics variables to be declared in this struct
@@ -106,9 +118,13 @@ type SchedulerMetrics struct  {
 	totalApplicationsRejected prometheus.Counter
 	totalApplicationsRunning prometheus.Gauge
 	totalApplicationsCompleted prometheus.Gauge
+	applicationsByState *prometheus.GaugeVec
 	activeNodes prometheus.Gauge
 	failedNodes prometheus.Gauge
 	schedulingLatency prometheus.Histogram
+	schedulingCycle prometheus.Histogram
+	schedulingBatchSize prometheus.Histogram
+	queueFairShare *prometheus.GaugeVec
 }
 
 // Gets singleton instance of SchedulerMetrics
@@ -156,6 +172,12 @@ func initSchedulerMetrics() *SchedulerMetrics {
 			Name:      "completed_apps",
 			Help:      "completed apps",
 		})
+	s.applicationsByState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: SchedulerSubsystem,
+			Name:      "apps_by_state",
+			Help:      "Number of applications, by the application state.",
+		}, []string{"state"})
 
 	// Nodes
 	s.activeNodes = prometheus.NewGauge(
@@ -179,14 +201,40 @@ func initSchedulerMetrics() *SchedulerMetrics {
 			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
 		},
 	)
+	s.schedulingCycle = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Subsystem: SchedulerSubsystem,
+			Name:      "scheduling_cycle_duration_seconds",
+			Help:      "duration of a scheduling cycle over all partitions in seconds",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+		},
+	)
+	s.schedulingBatchSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Subsystem: SchedulerSubsystem,
+			Name:      "scheduling_batch_size",
+			Help:      "number of asks considered in a scheduling cycle",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		},
+	)
+	s.queueFairShare = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: SchedulerSubsystem,
+			Name:      "queue_fair_share",
+			Help:      "Fair share of the queue, by partition, queue path and resource type.",
+		}, []string{"partition", "queue", "resource"})
 	var metricsList = []prometheus.Collector{
 		s.scheduleAllocations,
 		s.scheduleApplications,
 		s.schedulingLatency,
+		s.schedulingCycle,
+		s.schedulingBatchSize,
 		s.totalApplicationsRunning,
 		s.totalApplicationsCompleted,
+		s.applicationsByState,
 		s.activeNodes,
 		s.failedNodes,
+		s.queueFairShare,
 	}
 
 	// Register the metrics.
@@ -225,6 +273,23 @@ func (m *SchedulerMetrics) ObserveSchedulingLatency(start time.Time) {
 	m.schedulingLatency.Observe(SinceInSeconds(start))
 }
 
+func (m *SchedulerMetrics) ObserveSchedulingCycle(start time.Time) {
+	m.schedulingCycle.Observe(SinceInSeconds(start))
+}
+
+func (m *SchedulerMetrics) ObserveSchedulingBatchSize(size int) {
+	m.schedulingBatchSize.Observe(float64(size))
+}
+
+// Metrics Ops related to the fair share of the queues
+func (m *SchedulerMetrics) SetQueueFairShare(partition string, queue string, resource string, value float64) {
+	m.queueFairShare.With(prometheus.Labels{"partition": partition, "queue": queue, "resource": resource}).Set(value)
+}
+
+func (m *SchedulerMetrics) RemoveQueueFairShare(partition string, queue string, resource string) {
+	m.queueFairShare.Delete(prometheus.Labels{"partition": partition, "queue": queue, "resource": resource})
+}
+
 // Define and implement all the metrics ops for Prometheus.
 // Metrics Ops related to allocationScheduleSuccesses
 func (m *SchedulerMetrics) IncScheduledAllocationSuccesses() {
@@ -313,6 +378,15 @@ func (m *SchedulerMetrics) SetTotalApplicationsCompleted(value int) {
 	m.totalApplicationsCompleted.Set(float64(value))
 }
 
+// Metrics Ops related to applicationsByState
+func (m *SchedulerMetrics) IncApplicationsInState(state string) {
+	m.applicationsByState.With(prometheus.Labels{"state": state}).Inc()
+}
+
+func (m *SchedulerMetrics) DecApplicationsInState(state string) {
+	m.applicationsByState.With(prometheus.Labels{"state": state}).Dec()
+}
+
 // Metrics Ops related to ActiveNodes
 func (m *SchedulerMetrics) IncActiveNodes() {
 	m.activeNodes.Inc()
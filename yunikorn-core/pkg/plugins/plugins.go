@@ -24,10 +24,15 @@ import (
 var plugins SchedulerPlugins
 
 func init() {
-	plugins = SchedulerPlugins{}
+	plugins = SchedulerPlugins{
+		nodeScorerPlugins: make(map[string]NodeScorerPlugin),
+	}
 }
 
 func RegisterSchedulerPlugin(plugin interface{}) {
+	plugins.lock.Lock()
+	defer plugins.lock.Unlock()
+
 	registered := false
 	if t, ok := plugin.(PredicatesPlugin); ok {
 		log.Logger().Debug("register scheduler plugin",
@@ -47,19 +52,39 @@ func RegisterSchedulerPlugin(plugin interface{}) {
 		plugins.reconcilePlugin = t
 		registered = true
 	}
+	if t, ok := plugin.(NodeScorerPlugin); ok {
+		log.Logger().Debug("register scheduler plugin",
+			zap.String("type", "NodeScorerPlugin"),
+			zap.String("name", t.ScorerName()))
+		plugins.nodeScorerPlugins[t.ScorerName()] = t
+		registered = true
+	}
 	if !registered {
 		log.Logger().Debug("no scheduler plugin implemented, none registered")
 	}
 }
 
 func GetPredicatesPlugin() PredicatesPlugin {
+	plugins.lock.RLock()
+	defer plugins.lock.RUnlock()
 	return plugins.predicatesPlugin
 }
 
 func GetVolumesPlugin() VolumesPlugin {
+	plugins.lock.RLock()
+	defer plugins.lock.RUnlock()
 	return plugins.volumesPlugin
 }
 
 func GetReconcilePlugin() ReconcilePlugin {
+	plugins.lock.RLock()
+	defer plugins.lock.RUnlock()
 	return plugins.reconcilePlugin
 }
+
+// Get the node scorer plugin registered with the name, nil if no scorer with the name is registered.
+func GetNodeScorerPlugin(name string) NodeScorerPlugin {
+	plugins.lock.RLock()
+	defer plugins.lock.RUnlock()
+	return plugins.nodeScorerPlugins[name]
+}
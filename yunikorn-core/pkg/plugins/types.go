@@ -16,12 +16,18 @@ limitations under the License.
 
 package plugins
 
-import "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+import (
+	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+	"sync"
+)
 
 type SchedulerPlugins struct {
-	predicatesPlugin PredicatesPlugin
-	volumesPlugin    VolumesPlugin
-	reconcilePlugin  ReconcilePlugin
+	predicatesPlugin  PredicatesPlugin
+	volumesPlugin     VolumesPlugin
+	reconcilePlugin   ReconcilePlugin
+	nodeScorerPlugins map[string]NodeScorerPlugin // scorers by name, a scorer replaces the scorer with the same name
+
+	lock sync.RWMutex
 }
 
 // RM side implements this API when it can provide plugin for predicates.
@@ -33,8 +39,14 @@ type PredicatesPlugin interface {
 
 // RM side implements this API when it can provide plugin for volumes.
 type VolumesPlugin interface {
-	// Bind the volumes after allocation has been confirmed
-	//VolumesBind(allocationId string, node string) error
+	// Assume the volumes of an allocation on a node. This is called when the node is selected
+	// for the allocation, before the allocation is proposed. An error means the volumes cannot be
+	// assumed and the node cannot be used for the allocation.
+	VolumesAssume(allocationKey string, nodeId string) error
+	// Bind the volumes of an allocation after it has been accepted, before the allocation is
+	// confirmed to the RM. An error means the allocation is rejected and scheduled again,
+	// the RM side must roll back everything it assumed for the allocation.
+	VolumesBind(allocationKey string, nodeId string) error
 }
 
 type ReconcilePlugin interface {
@@ -43,3 +55,12 @@ type ReconcilePlugin interface {
 	// to scheduler cache (shim-side), such as assumed allocations.
 	ReSyncSchedulerCache(args *si.ReSyncSchedulerCacheArgs) error
 }
+
+// RM side implements this API when it can provide extra scores for the nodes an allocation fits on.
+type NodeScorerPlugin interface {
+	// Name of the scorer, the weight of the scores is configured in the partition using this name.
+	ScorerName() string
+	// Score the nodes for an allocation: a higher score means a better node. Scores must be between
+	// 0 and 100, nodes that are not in the returned map get a score of 0.
+	ScoreNodes(allocationKey string, nodeIds []string) map[string]int64
+}
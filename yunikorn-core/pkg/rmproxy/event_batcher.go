@@ -0,0 +1,129 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rmproxy
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/common"
+    "github.com/cloudera/yunikorn-core/pkg/events"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "sync"
+    "time"
+)
+
+const (
+    // interval at which the batched events are sent to the RMs
+    eventBatchInterval = time.Second
+    // minimum time between two batches of events for the same object
+    eventObjectInterval = 10 * time.Second
+)
+
+// Conversion of the object types of the core events to the RM event types
+var eventRecordTypes = map[string]si.EventRecord_Type{
+    events.ObjectAsk:         si.EventRecord_REQUEST,
+    events.ObjectAllocation:  si.EventRecord_ALLOCATION,
+    events.ObjectApplication: si.EventRecord_APP,
+    events.ObjectNode:        si.EventRecord_NODE,
+    events.ObjectQueue:       si.EventRecord_QUEUE,
+}
+
+// An event waiting to be sent to the RM
+type pendingEvent struct {
+    rmId      string
+    objectKey string
+    record    *si.EventRecord
+}
+
+// Collects the events for the RMs and decides when they are sent.
+// Repeating events are combined into one record with a count. Events for an object are sent at most once per
+// object interval: events that come in before the interval has passed are held back and combined.
+type eventBatcher struct {
+    pending  map[string]*pendingEvent // pending events by object, reason and message
+    lastSent map[string]time.Time     // time the events for an object were last sent
+    lock     sync.Mutex
+}
+
+func newEventBatcher() *eventBatcher {
+    return &eventBatcher{
+        pending:  make(map[string]*pendingEvent),
+        lastSent: make(map[string]time.Time),
+    }
+}
+
+// Add an event from the core to the batch.
+// Events that cannot be linked to an RM are dropped.
+func (b *eventBatcher) add(event events.Event) {
+    recordType, ok := eventRecordTypes[event.ObjectType]
+    if !ok || event.Partition == "" {
+        return
+    }
+    rmId := common.GetRMIdFromPartitionName(event.Partition)
+    if rmId == "" {
+        return
+    }
+    objectKey := rmId + "/" + event.ObjectType + ":" + event.ObjectId
+    key := objectKey + "/" + event.Reason + "/" + event.Message
+
+    b.lock.Lock()
+    defer b.lock.Unlock()
+
+    if pending, ok := b.pending[key]; ok {
+        pending.record.Count += int32(event.Count)
+        pending.record.TimestampNano = event.LastTime.UnixNano()
+        return
+    }
+    b.pending[key] = &pendingEvent{
+        rmId:      rmId,
+        objectKey: objectKey,
+        record: &si.EventRecord{
+            Type:          recordType,
+            ObjectId:      event.ObjectId,
+            ApplicationId: event.ApplicationId,
+            Reason:        event.Reason,
+            Message:       event.Message,
+            Count:         int32(event.Count),
+            TimestampNano: event.LastTime.UnixNano(),
+        },
+    }
+}
+
+// Get the events that can be sent by RM: all pending events for objects that have not had events sent within the
+// object interval. The events that are returned are removed from the batch.
+func (b *eventBatcher) flush(now time.Time) map[string][]*si.EventRecord {
+    b.lock.Lock()
+    defer b.lock.Unlock()
+
+    // forget objects that can be sent again to keep the tracking bounded
+    for objectKey, sent := range b.lastSent {
+        if now.Sub(sent) >= eventObjectInterval {
+            delete(b.lastSent, objectKey)
+        }
+    }
+    records := make(map[string][]*si.EventRecord)
+    sentObjects := make(map[string]bool)
+    for key, pending := range b.pending {
+        if _, ok := b.lastSent[pending.objectKey]; ok {
+            continue
+        }
+        records[pending.rmId] = append(records[pending.rmId], pending.record)
+        sentObjects[pending.objectKey] = true
+        delete(b.pending, key)
+    }
+    for objectKey := range sentObjects {
+        b.lastSent[objectKey] = now
+    }
+    return records
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rmproxy
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/events"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "gotest.tools/assert"
+    "testing"
+    "time"
+)
+
+func newTestEvent(objectType, objectId, partition, reason string) events.Event {
+    return events.Event{
+        ObjectType:    objectType,
+        ObjectId:      objectId,
+        Partition:     partition,
+        ApplicationId: "app-1",
+        Reason:        reason,
+        LastTime:      time.Now(),
+        Count:         1,
+    }
+}
+
+func TestEventBatching(t *testing.T) {
+    batcher := newEventBatcher()
+    // repeating events are combined
+    batcher.add(newTestEvent(events.ObjectAsk, "ask-1", "[rm-1]default", events.PredicateFailed))
+    batcher.add(newTestEvent(events.ObjectAsk, "ask-1", "[rm-1]default", events.PredicateFailed))
+    batcher.add(newTestEvent(events.ObjectApplication, "app-1", "[rm-1]default", events.ApplicationAccepted))
+    batcher.add(newTestEvent(events.ObjectNode, "node-1", "[rm-2]default", events.PredicateFailed))
+    // events without a partition cannot be sent
+    batcher.add(newTestEvent(events.ObjectAsk, "ask-2", "", events.PredicateFailed))
+
+    now := time.Now()
+    records := batcher.flush(now)
+    assert.Equal(t, len(records), 2)
+    assert.Equal(t, len(records["rm-1"]), 2)
+    assert.Equal(t, len(records["rm-2"]), 1)
+    assert.Equal(t, records["rm-2"][0].Type, si.EventRecord_NODE)
+    for _, record := range records["rm-1"] {
+        if record.Type == si.EventRecord_REQUEST {
+            assert.Equal(t, record.ObjectId, "ask-1")
+            assert.Equal(t, record.Count, int32(2))
+        } else {
+            assert.Equal(t, record.Type, si.EventRecord_APP)
+            assert.Equal(t, record.Count, int32(1))
+        }
+    }
+    assert.Equal(t, len(batcher.flush(now)), 0)
+}
+
+func TestEventRateLimit(t *testing.T) {
+    batcher := newEventBatcher()
+    batcher.add(newTestEvent(events.ObjectAsk, "ask-1", "[rm-1]default", events.PredicateFailed))
+    now := time.Now()
+    assert.Equal(t, len(batcher.flush(now)["rm-1"]), 1)
+
+    // the object was just sent: new events are held back
+    batcher.add(newTestEvent(events.ObjectAsk, "ask-1", "[rm-1]default", events.PredicateFailed))
+    batcher.add(newTestEvent(events.ObjectAsk, "ask-1", "[rm-1]default", events.AskSkippedHeadroom))
+    batcher.add(newTestEvent(events.ObjectAsk, "ask-2", "[rm-1]default", events.PredicateFailed))
+    records := batcher.flush(now.Add(eventBatchInterval))
+    assert.Equal(t, len(records["rm-1"]), 1)
+    assert.Equal(t, records["rm-1"][0].ObjectId, "ask-2")
+
+    // after the object interval the held back events are sent
+    records = batcher.flush(now.Add(eventObjectInterval))
+    assert.Equal(t, len(records["rm-1"]), 2)
+    assert.Equal(t, len(batcher.pending), 0)
+}
@@ -27,6 +27,9 @@ type RMApplicationUpdateEvent struct {
     RMId                 string
     AcceptedApplications []*si.AcceptedApplication
     RejectedApplications []*si.RejectedApplication
+    MovedApplications    []*si.MovedApplication
+    RejectedMoves        []*si.RejectedApplicationMove
+    RemovedApplications  []*si.RemovedApplication
 }
 
 type RMRejectedAllocationAskEvent struct {
@@ -44,3 +47,8 @@ type RMNodeUpdateEvent struct {
     AcceptedNodes []*si.AcceptedNode
     RejectedNodes []*si.RejectedNode
 }
+
+type RMSchedulerEventsEvent struct {
+    RMId   string
+    Events []*si.EventRecord
+}
@@ -24,6 +24,7 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/common"
     "github.com/cloudera/yunikorn-core/pkg/common/commonevents"
     "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/events"
     "github.com/cloudera/yunikorn-core/pkg/handler"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "github.com/cloudera/yunikorn-core/pkg/plugins"
@@ -48,6 +49,9 @@ type RMProxy struct {
     // it is used to determine if configs need to be reloaded
     rmIdToConfigWatcher map[string]*configs.ConfigWatcher
 
+    // scheduler events waiting to be sent to the RMs
+    eventBatcher *eventBatcher
+
     lock sync.RWMutex
 }
 
@@ -76,6 +80,7 @@ func NewRMProxy() *RMProxy {
         rmIdToCallback:      make(map[string]api.ResourceManagerCallback),
         rmIdToConfigWatcher: make(map[string]*configs.ConfigWatcher),
         pendingRMEvents:     make(chan interface{}, 1024*1024),
+        eventBatcher:        newEventBatcher(),
     }
     return rm
 }
@@ -84,6 +89,30 @@ func (m *RMProxy) StartService(handlers handler.EventHandlers) {
     m.EventHandlers = handlers
 
     go m.handleRMEvents()
+
+    events.GetEventStore().SetListener(m)
+    go m.sendSchedulerEvents()
+}
+
+// Pass the events recorded in the scheduler on to the RM in batches.
+func (m *RMProxy) EventRecorded(event events.Event) {
+    m.eventBatcher.add(event)
+}
+
+// Periodically queue the batched scheduler events for the registered RMs.
+func (m *RMProxy) sendSchedulerEvents() {
+    for {
+        time.Sleep(eventBatchInterval)
+        for rmId, records := range m.eventBatcher.flush(time.Now()) {
+            if m.GetResourceManagerCallback(rmId) == nil {
+                continue
+            }
+            enqueueAndCheckFull(m.pendingRMEvents, &rmevent.RMSchedulerEventsEvent{
+                RMId:   rmId,
+                Events: records,
+            })
+        }
+    }
 }
 
 func (m *RMProxy) handleRMRecvUpdateResponseError(rmId string, err error) {
@@ -118,12 +147,16 @@ func (m *RMProxy) processAllocationUpdateEvent(event *rmevent.RMNewAllocationsEv
 }
 
 func (m *RMProxy) processApplicationUpdateEvent(event *rmevent.RMApplicationUpdateEvent) {
-    if len(event.RejectedApplications) == 0 && len(event.AcceptedApplications) == 0 {
+    if len(event.RejectedApplications) == 0 && len(event.AcceptedApplications) == 0 &&
+        len(event.MovedApplications) == 0 && len(event.RejectedMoves) == 0 && len(event.RemovedApplications) == 0 {
         return
     }
     response := &si.UpdateResponse{
-        RejectedApplications: event.RejectedApplications,
-        AcceptedApplications: event.AcceptedApplications,
+        RejectedApplications:     event.RejectedApplications,
+        AcceptedApplications:     event.AcceptedApplications,
+        MovedApplications:        event.MovedApplications,
+        RejectedApplicationMoves: event.RejectedMoves,
+        RemovedApplications:      event.RemovedApplications,
     }
 
     m.processUpdateResponse(event.RMId, response)
@@ -163,6 +196,17 @@ func (m *RMProxy) processRMNodeUpdateEvent(event *rmevent.RMNodeUpdateEvent) {
     m.processUpdateResponse(event.RMId, response)
 }
 
+func (m *RMProxy) processSchedulerEventsEvent(event *rmevent.RMSchedulerEventsEvent) {
+    if len(event.Events) == 0 {
+        return
+    }
+    response := &si.UpdateResponse{
+        Events: event.Events,
+    }
+
+    m.processUpdateResponse(event.RMId, response)
+}
+
 func (m *RMProxy) handleRMEvents() {
     for {
         ev := <-m.pendingRMEvents
@@ -177,6 +221,8 @@ func (m *RMProxy) handleRMEvents() {
             m.processUpdatePartitionConfigsEvent(v)
         case *rmevent.RMNodeUpdateEvent:
             m.processRMNodeUpdateEvent(v)
+        case *rmevent.RMSchedulerEventsEvent:
+            m.processSchedulerEventsEvent(v)
         default:
             panic(fmt.Sprintf("%s is not an acceptable type for RM event.", reflect.TypeOf(v).String()))
         }
@@ -287,6 +333,13 @@ func normalizeUpdateRequestByRMId(request *si.UpdateRequest) {
         }
     }
 
+    // Update Move apps
+    if len(request.MoveApplications) > 0 {
+        for _, app := range request.MoveApplications {
+            app.PartitionName = common.GetNormalizedPartitionName(app.PartitionName, request.RmId)
+        }
+    }
+
     // Update releases
     if request.Releases != nil {
         if len(request.Releases.AllocationsToRelease) > 0 {
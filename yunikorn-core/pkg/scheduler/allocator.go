@@ -18,7 +18,9 @@ package scheduler
 
 import (
     "context"
+    "github.com/cloudera/yunikorn-core/pkg/cache"
     "github.com/cloudera/yunikorn-core/pkg/common"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "github.com/cloudera/yunikorn-core/pkg/plugins"
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
@@ -36,12 +38,18 @@ func (m *Scheduler) SingleStepScheduleAllocTest(nAlloc int) {
     })
 }
 
-func (m *Scheduler) singleStepSchedule(nAlloc int, preemptionParam *preemptionParameters) {
+// Run one scheduling step over all partitions, returns the number of asks that were considered and the
+// number of allocations that were proposed.
+func (m *Scheduler) singleStepSchedule(nAlloc int, preemptionParam *preemptionParameters) (int, int) {
     if !preemptionParam.crossQueuePreemption {
         m.step++
     }
 
-    for partition, partitionContext := range m.clusterSchedulingContext.getPartitionMapClone() {
+    batchSize := 0
+    allocated := 0
+
+    partitions := m.clusterSchedulingContext.getPartitionMapClone()
+    for partition, partitionContext := range partitions {
         totalPartitionResource := m.clusterInfo.GetTotalPartitionResource(partition)
         if totalPartitionResource == nil {
             continue
@@ -52,11 +60,12 @@ func (m *Scheduler) singleStepSchedule(nAlloc int, preemptionParam *preemptionPa
         // - According to resource usage, find next N allocation Requests, N could be
         //   mini-batch because we don't want the process takes too long. And this
         //   runs as single thread.
-        // - According to mini-batched allocation request. Try to allocate. This step
-        //   can be done as multiple thread.
+        // - According to mini-batched allocation request. Try to allocate. The nodes
+        //   for each request are evaluated in parallel.
         // - For asks cannot be assigned, we will do preemption. Again it is done using
         //   single-thread.
         candidates := m.findAllocationAsks(totalPartitionResource, partitionContext, nAlloc, m.step, preemptionParam /* it is allocation phase */)
+        batchSize += len(candidates)
 
         // Try to allocate from candidates, returns allocation proposal as well as failed allocation
         // ask candidates. (For preemption).
@@ -69,20 +78,14 @@ func (m *Scheduler) singleStepSchedule(nAlloc int, preemptionParam *preemptionPa
                 if alloc == nil {
                     continue
                 }
-
-                proposal := newSingleAllocationProposal(alloc)
-                err := m.updateSchedulingRequestPendingAskByDelta(proposal.AllocationProposals[0], -1)
-                if err == nil {
-                    m.eventHandlers.CacheEventHandler.HandleEvent(newSingleAllocationProposal(alloc))
+                if m.proposeAllocation(alloc) {
                     confirmedAllocations = append(confirmedAllocations, alloc)
-                } else {
-                    log.Logger().Error("failed to send allocation proposal",
-                        zap.Error(err))
                 }
             }
         }
 
         nAlloc -= len(confirmedAllocations)
+        allocated += len(confirmedAllocations)
 
         // Update missed opportunities
         m.handleFailedToAllocationAllocations(confirmedAllocations, candidates, preemptionParam)
@@ -90,19 +93,51 @@ func (m *Scheduler) singleStepSchedule(nAlloc int, preemptionParam *preemptionPa
         // Update  metrics
         m.metrics.ObserveSchedulingLatency(schedulingStart)
     }
+    m.deleteRemovedPartitionFairShares(partitions)
+    return batchSize, allocated
+}
+
+// Propose the allocation to the cache and decrease the pending ask, returns true if the allocation was proposed.
+// An allocation that needs other allocations to be preempted first is proposed after the RM has released them.
+func (m *Scheduler) proposeAllocation(alloc *SchedulingAllocation) bool {
+    proposal := newSingleAllocationProposal(alloc)
+    if err := m.updateSchedulingRequestPendingAskByDelta(proposal.AllocationProposals[0], -1); err != nil {
+        log.Logger().Error("failed to send allocation proposal",
+            zap.Error(err))
+        return false
+    }
+    if len(alloc.Releases) > 0 {
+        m.startPreemption(alloc)
+    } else {
+        m.eventHandlers.CacheEventHandler.HandleEvent(proposal)
+    }
+    return true
 }
 
+// Allocate the candidate on the best feasible node.
+// The nodes are evaluated in parallel, the feasible nodes are ordered by their score and the allocation is made on
+// the first node in that order. Without scorers the evaluation order is used. If the resources on a feasible node
+// were taken by another allocation since the evaluation the next node is used.
 func (m *Scheduler) regularAllocate(nodes []*SchedulingNode, candidate *SchedulingAllocationAsk) *SchedulingAllocation {
+    parallelism := cache.DefaultNodeEvaluationParallelism
+    var percentage int32
+    var weights map[string]int32
+    if partitionInfo := m.clusterInfo.GetPartition(candidate.PartitionName); partitionInfo != nil {
+        parallelism = partitionInfo.GetNodeEvaluationParallelism()
+        percentage = partitionInfo.GetPercentageOfNodesToScore()
+        weights = partitionInfo.GetNodeScorerWeights()
+    }
     nNodes := len(nodes)
     startIdx := rand.Intn(nNodes)
-    for i := 0; i < len(nodes); i++ {
-        idx := (i + startIdx) % nNodes
-        node := nodes[idx]
-        if !node.CheckAllocateConditions(candidate.AskProto.AllocationKey) {
-            // skip the node if conditions can not be satisfied
-            continue
-        }
-        if node.CheckAndAllocateResource(candidate.AllocatedResource, false /* preemptionPhase */) {
+    feasibleNodes := findFeasibleNodes(nodes, startIdx, candidate, parallelism, numFeasibleNodesToFind(nNodes, percentage))
+    feasibleNodes = sortNodesByScore(weights, candidate, feasibleNodes, nodes)
+    for _, node := range feasibleNodes {
+        if node.CheckAndAllocateResource(candidate.AllocatedResource) {
+            // assume the volumes on the node, if that fails the node cannot be used for the allocation
+            if !node.AssumeVolumes(candidate.AskProto.AllocationKey) {
+                node.DeallocateResource(candidate.AllocatedResource)
+                continue
+            }
             // before deciding on an allocation, call the reconcile plugin to sync scheduler cache
             // between core and shim if necessary. This is useful when running multiple allocations
             // in parallel and need to handle inter container affinity and anti-affinity.
@@ -135,23 +170,37 @@ func (m *Scheduler) allocate(nodes []*SchedulingNode, candidate *SchedulingAlloc
     }
 }
 
-// Do mini batch allocation
-func (m *Scheduler) tryBatchAllocation(partition string, candidates []*SchedulingAllocationAsk,
-    preemptionParam *preemptionParameters) ([]*SchedulingAllocation, []*SchedulingAllocationAsk) {
-    // copy list of node since we going to go through node list a couple of times
+// Get a sorted list of scheduling nodes for the partition.
+// The list is a copy since we going to go through node list a couple of times.
+func (m *Scheduler) getSchedulingNodes(partition string) []*SchedulingNode {
     nodeList := m.clusterInfo.GetPartition(partition).CopyNodeInfos()
-    if len(nodeList) <= 0 {
-        // When we don't have node, do nothing
-        return make([]*SchedulingAllocation, 0), candidates
-    }
 
+    // the victims of pending preemptions are preempting, the preemptors are allocating
+    allocating, preempting := m.pendingPreemptions.getNodeResources(partition)
     schedulingNodeList := make([]*SchedulingNode, len(nodeList))
     for idx, v := range nodeList {
         schedulingNodeList[idx] = NewSchedulingNode(v)
+        if nodeAllocating, ok := allocating[v.NodeId]; ok {
+            resources.AddTo(schedulingNodeList[idx].AllocatingResource, nodeAllocating)
+        }
+        if nodePreempting, ok := preempting[v.NodeId]; ok {
+            resources.AddTo(schedulingNodeList[idx].PreemptingResource, nodePreempting)
+        }
     }
     // Sort by MAX_AVAILABLE resources.
     // TODO, this should be configurable.
     SortNodes(schedulingNodeList, MaxAvailableResources)
+    return schedulingNodeList
+}
+
+// Do mini batch allocation
+func (m *Scheduler) tryBatchAllocation(partition string, candidates []*SchedulingAllocationAsk,
+    preemptionParam *preemptionParameters) ([]*SchedulingAllocation, []*SchedulingAllocationAsk) {
+    schedulingNodeList := m.getSchedulingNodes(partition)
+    if len(schedulingNodeList) <= 0 {
+        // When we don't have node, do nothing
+        return make([]*SchedulingAllocation, 0), candidates
+    }
 
     ctx, cancel := context.WithCancel(context.Background())
 
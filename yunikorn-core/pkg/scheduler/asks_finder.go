@@ -17,7 +17,9 @@ limitations under the License.
 package scheduler
 
 import (
+    "fmt"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/events"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "go.uber.org/zap"
 )
@@ -39,6 +41,9 @@ func (m *Scheduler) findAllocationAsks(partitionTotalResource *resources.Resourc
     // Reset may allocations
     m.resetMayAllocations(partitionContext)
 
+    // Update the fair share of the queues used to sort the queues
+    m.updateFairShares(partitionContext, partitionTotalResource)
+
     selectedAsksByAllocationKey := make(map[string]int32, 0)
 
     // Repeatedly go to queue hierarchy, find next allocation ask, until we find N allocations
@@ -129,7 +134,13 @@ func (m *Scheduler) findMayAllocationFromApplication(schedulingRequests *Schedul
         }
 
         // Only sort request if its resource fits headroom
-        if v.PendingRepeatAsk-selectedPendingAskByAllocationKey[v.AskProto.AllocationKey] > 0 && resources.FitIn(headroom, v.AllocatedResource) {
+        if v.PendingRepeatAsk-selectedPendingAskByAllocationKey[v.AskProto.AllocationKey] > 0 {
+            if !resources.FitIn(headroom, v.AllocatedResource) {
+                if !preemptionParameters.crossQueuePreemption {
+                    recordAskSkippedHeadroom(v)
+                }
+                continue
+            }
             if bestAsk == nil || v.NormalizedPriority > bestAsk.NormalizedPriority {
                 bestAsk = v
             }
@@ -143,6 +154,19 @@ func (m *Scheduler) findMayAllocationFromApplication(schedulingRequests *Schedul
     return bestAsk
 }
 
+// Record the event for an ask that does not fit in the headroom of its queue or one of the parent queues.
+func recordAskSkippedHeadroom(ask *SchedulingAllocationAsk) {
+    events.Record(&events.Event{
+        ObjectType:    events.ObjectAsk,
+        ObjectId:      ask.AskProto.AllocationKey,
+        Partition:     ask.PartitionName,
+        ApplicationId: ask.ApplicationId,
+        QueueName:     ask.QueueName,
+        Reason:        events.AskSkippedHeadroom,
+        Message:       fmt.Sprintf("ask %s does not fit in the headroom of queue %s", ask.AllocatedResource, ask.QueueName),
+    })
+}
+
 func getHeadroomOfQueue(parentHeadroom *resources.Resource, queueMaxLimit *resources.Resource, queue *SchedulingQueue,
     preemptionParameters* preemptionParameters) *resources.Resource {
     // When cross-queue preemption is enabled, don't calculate headroom of non-leaf queues.
@@ -185,7 +209,7 @@ func (m *Scheduler) findNextAllocationAskCandidate(
     selectedPendingAskByAllocationKey map[string]int32,
     preemptionParameters *preemptionParameters) *SchedulingAllocationAsk {
     for _, queue := range sortedQueueCandidates {
-        // skip stopped queues: running and draining queues are allowed
+        // skip stopped queues: running and draining queues are allowed, a draining queue only has existing applications
         if queue.isStopped() {
             log.Logger().Debug("skip non-running queue",
                 zap.String("queueName", queue.Name))
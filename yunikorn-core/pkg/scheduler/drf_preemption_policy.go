@@ -20,6 +20,7 @@ import (
     "fmt"
     "github.com/cloudera/yunikorn-core/pkg/cache"
     "github.com/cloudera/yunikorn-core/pkg/common/commonevents"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
 )
@@ -28,6 +29,10 @@ import (
 type DRFPreemptionPolicy struct {
 }
 
+func (m *DRFPreemptionPolicy) Name() string {
+    return configs.PreemptionPolicyDRF
+}
+
 func (m *DRFPreemptionPolicy) DoPreemption(scheduler *Scheduler) {
     // First calculate ideal resource
     calculateIdealResources(scheduler)
@@ -121,7 +126,7 @@ func trySurgicalPreemptionOnNode(preemptionPartitionCtx *preemptionPartitionCont
     resourceToPreempt = resources.ComponentWiseMax(resourceToPreempt, resources.Zero)
 
     // If allocated resource can fit in the node, and no headroom shortage of preemptor queue, we can directly get it allocated. (lucky!)
-    if node.CheckAndAllocateResource(candidate.AllocatedResource, true /* preemptionPhase */) {
+    if node.CheckAndAllocateResource(candidate.AllocatedResource) {
         return &singleNodePreemptResult{
             node:                  node,
             toReleaseAllocations:  make(map[string]*cache.AllocationInfo),
@@ -132,15 +137,11 @@ func trySurgicalPreemptionOnNode(preemptionPartitionCtx *preemptionPartitionCont
     toReleaseAllocations := make(map[string]*cache.AllocationInfo)
     totalReleasedResource := resources.NewResource()
 
-    // Otherwise, try to do preemption, list all allocations on the node.
-    // Fixme: this operation has too many copies, should avoid for better perf
-    for _, alloc := range node.NodeInfo.GetAllAllocations() {
-        queueName := alloc.AllocationProto.QueueName
-        // Try to do preemption.
-        preemptQueue := preemptionPartitionCtx.leafQueues[queueName]
-        if nil == preemptQueue {
-            continue
-        }
+    // Otherwise, try to do preemption, list all allocations on the node that can be preempted
+    // in the order they should be preempted.
+    for _, victim := range getPreemptionVictims(preemptionPartitionCtx, node) {
+        alloc := victim.alloc
+        preemptQueue := victim.queue
 
         // Skip when the queue has <= 0 preempt-able resource
         if resources.Comp(preemptionPartitionCtx.partitionTotalResource, preemptQueue.resources.preemptable, resources.Zero) <= 0 {
@@ -155,6 +156,11 @@ func trySurgicalPreemptionOnNode(preemptionPartitionCtx *preemptionPartitionCont
             continue
         }
 
+        // Stay within the maximum that can be preempted in one cycle
+        if !preemptionPartitionCtx.canPreempt(resources.Add(totalReleasedResource, alloc.AllocatedResource)) {
+            continue
+        }
+
         // Add one more check, to make sure that preempted resource will be used by candidate queue.
         // When this check fails it means preempted container doesn't make a positive contribution towards preemptor queue and its parents' headroom shortages. (
         // How much headroom needed to allocate candidate).
@@ -179,7 +185,7 @@ func trySurgicalPreemptionOnNode(preemptionPartitionCtx *preemptionPartitionCont
 
 func crossQueuePreemptionAllocate(preemptionPartitionContext *preemptionPartitionContext, nodes []*SchedulingNode, candidate *SchedulingAllocationAsk,
     preemptionParam *preemptionParameters) *SchedulingAllocation {
-    if preemptionPartitionContext == nil {
+    if preemptionPartitionContext == nil || !preemptionPartitionContext.hasPolicy(configs.PreemptionPolicyDRF) {
         return nil
     }
 
@@ -227,25 +233,32 @@ func createPreemptionAndAllocationProposal(preemptionPartitionContext *preemptio
     // We will get this allocation by preempting resources.
     allocation := NewSchedulingAllocation(candidate, nodeToAllocate.NodeId)
     allocation.Releases = make([]*commonevents.ReleaseAllocation, 0)
+    allocation.ReleasedResources = make(map[string]*resources.Resource)
 
     // And add releases
+    totalReleasedResource := resources.NewResource()
     for _, pr := range preemptionResults {
         for uuid, alloc := range pr.toReleaseAllocations {
 
             allocation.Releases = append(allocation.Releases, commonevents.NewReleaseAllocation(uuid, alloc.ApplicationId, nodeToAllocate.NodeInfo.Partition,
                 fmt.Sprintf("Preempt allocation=%s for ask=%s", alloc, candidate.AskProto.AllocationKey), si.AllocationReleaseResponse_PREEMPTED_BY_SCHEDULER))
+            allocation.ReleasedResources[uuid] = alloc.AllocatedResource
+            preemptionPartitionContext.selected[uuid] = true
 
             // Update metrics of preempt queue
             preemptQueue := preemptionPartitionContext.leafQueues[alloc.AllocationProto.QueueName]
             resources.AddTo(preemptQueue.resources.markedPreemptedResource, alloc.AllocatedResource)
             preemptQueue.resources.preemptable = resources.SubEliminateNegative(preemptQueue.resources.preemptable, alloc.AllocatedResource)
         }
-        resources.AddTo(pr.node.PreemptingResource, pr.totalReleasedResource)
+        resources.AddTo(totalReleasedResource, pr.totalReleasedResource)
+        resources.AddTo(preemptionPartitionContext.preempted, pr.totalReleasedResource)
     }
 
     // Update metrics
-    // For node, update allocating and preempting resources
+    // For node, update allocating and preempting resources: the victims only offset the allocating candidate,
+    // the rest of the victims stays unavailable until the RM released them.
     resources.AddTo(nodeToAllocate.AllocatingResource, candidate.AllocatedResource)
+    resources.AddTo(nodeToAllocate.PreemptingResource, resources.ComponentWiseMin(candidate.AllocatedResource, totalReleasedResource))
 
     return allocation
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package scheduler
 
 import (
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
     "math"
 )
@@ -36,6 +37,9 @@ type queuePreemptCalcResourceByType struct {
 func calculateIdealResources(scheduler *Scheduler) {
     // for each partition
     for partitionName, preemptionPartitionContext := range scheduler.preemptionContext.partitions {
+        if !preemptionPartitionContext.hasPolicy(configs.PreemptionPolicyDRF) {
+            continue
+        }
         calculateIdealResourcesForPartition(scheduler, partitionName, preemptionPartitionContext)
     }
 }
@@ -152,7 +156,8 @@ func calculateIdealAllocation(resourceType string, totalAvailable resources.Quan
         queueCalc.resources.ideal.Resources[resourceType] = 0
     }
 
-    for len(queueResources) > 0 && totalAvailable > 0 {
+    // stop when all queues are satisfied, resources can be left over
+    for len(satisfiedQueues) < len(queueResources) && totalAvailable > 0 {
         for queue, queueCalc := range queueResources {
             // Ignore satisfied queues
             if satisfiedQueues[queue] {
@@ -0,0 +1,194 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "math"
+    "sort"
+)
+
+// The fair share of a queue is the part of the fair share of its parent the queue should get based on the demand of
+// the queue and its siblings. The fair share of the root queue is the total resource of the partition.
+// The fair share is calculated per resource type for the children of a parent:
+// - the demand of a queue is the allocated plus the pending resources, limited by the max resources of the queue
+// - the guaranteed resources, limited by the demand, are assigned first. If the guarantees of the children do not fit in
+//   the fair share of the parent they are scaled down proportionally.
+// - the rest of the fair share of the parent is divided between the children that have demand left based on the weight
+//   of the queues, no queue gets more than its demand.
+// The fair share is instantaneous: it changes with the demand and is recalculated for each scheduling cycle.
+
+// Temp object for the calculation of one resource type for one queue.
+type fairShareCalc struct {
+    queue      *SchedulingQueue
+    weight     float64
+    guaranteed float64
+    demand     float64
+    share      float64
+}
+
+// Update the fair share of all queues in the partition.
+func (m *Scheduler) updateFairShares(partitionContext *PartitionSchedulingContext, partitionTotalResource *resources.Resource) {
+    // lock the partition
+    partitionContext.lock.Lock()
+    defer partitionContext.lock.Unlock()
+
+    calculateFairShares(partitionContext.Root, partitionTotalResource)
+    recorded := make(map[string]map[string]bool)
+    m.recordFairShares(partitionContext.Name, partitionContext.Root, recorded)
+    // the queues or resource types that were recorded before but not now have been removed
+    m.deleteFairShares(partitionContext.Name, recorded)
+    m.recordedFairShares[partitionContext.Name] = recorded
+}
+
+// Expose the fair share of the queue and its children in the metrics.
+// The resource types recorded are tracked by queue path to allow removing them from the metrics.
+func (m *Scheduler) recordFairShares(partitionName string, queue *SchedulingQueue, recorded map[string]map[string]bool) {
+    if fairShare := queue.CachedQueueInfo.GetFairShare(); fairShare != nil {
+        queuePath := queue.CachedQueueInfo.GetQueuePath()
+        recorded[queuePath] = make(map[string]bool)
+        for resourceType, quantity := range fairShare.Resources {
+            m.metrics.SetQueueFairShare(partitionName, queuePath, resourceType, float64(quantity))
+            recorded[queuePath][resourceType] = true
+        }
+    }
+    for _, child := range queue.GetCopyOfChildren() {
+        m.recordFairShares(partitionName, child, recorded)
+    }
+}
+
+// Remove the fair shares that were recorded for the partition and are not part of the current fair shares.
+func (m *Scheduler) deleteFairShares(partitionName string, current map[string]map[string]bool) {
+    for queuePath, resourceTypes := range m.recordedFairShares[partitionName] {
+        for resourceType := range resourceTypes {
+            if !current[queuePath][resourceType] {
+                m.metrics.RemoveQueueFairShare(partitionName, queuePath, resourceType)
+            }
+        }
+    }
+}
+
+// Remove the fair shares recorded for partitions that are no longer part of the scheduler.
+func (m *Scheduler) deleteRemovedPartitionFairShares(partitions map[string]*PartitionSchedulingContext) {
+    for partitionName := range m.recordedFairShares {
+        if _, ok := partitions[partitionName]; !ok {
+            m.deleteFairShares(partitionName, nil)
+            delete(m.recordedFairShares, partitionName)
+        }
+    }
+}
+
+// Set the fair share for the queue and calculate the fair share of the children recursively.
+func calculateFairShares(queue *SchedulingQueue, fairShare *resources.Resource) {
+    queue.CachedQueueInfo.SetFairShare(fairShare)
+    children := queue.GetCopyOfChildren()
+    if len(children) == 0 {
+        return
+    }
+    // nothing to divide: clear the fair share of the children
+    if fairShare == nil {
+        for _, child := range children {
+            calculateFairShares(child, nil)
+        }
+        return
+    }
+    childShares := make(map[*SchedulingQueue]*resources.Resource)
+    for _, child := range children {
+        childShares[child] = resources.NewResource()
+    }
+    for resourceType, total := range fairShare.Resources {
+        calcs := make([]*fairShareCalc, 0, len(children))
+        for _, child := range children {
+            calcs = append(calcs, newFairShareCalc(child, resourceType))
+        }
+        divideFairShare(float64(total), calcs)
+        for _, calc := range calcs {
+            childShares[calc.queue].Resources[resourceType] = resources.Quantity(math.Floor(calc.share))
+        }
+    }
+    for child, share := range childShares {
+        calculateFairShares(child, share)
+    }
+}
+
+func newFairShareCalc(queue *SchedulingQueue, resourceType string) *fairShareCalc {
+    info := queue.CachedQueueInfo
+    demand := info.GetAllocatedResource().Resources[resourceType]
+    // nothing is scheduled for a stopped queue: the pending resources are not part of the demand
+    if !queue.isStopped() {
+        demand += queue.GetPendingResource().Resources[resourceType]
+    }
+    if maxResource := info.GetMaxResource(); maxResource != nil {
+        demand = resources.MinQuantity(demand, maxResource.Resources[resourceType])
+    }
+    calc := &fairShareCalc{
+        queue:  queue,
+        weight: queue.Weight,
+        demand: float64(demand),
+    }
+    if guaranteed := info.GetGuaranteedResource(); guaranteed != nil {
+        calc.guaranteed = float64(guaranteed.Resources[resourceType])
+    }
+    return calc
+}
+
+// Divide the total over the queues: the guaranteed resources first and the rest based on the weight of the queues.
+// No queue gets more than its demand, the total is not completely divided if the demand is lower than the total.
+func divideFairShare(total float64, calcs []*fairShareCalc) {
+    guaranteed := float64(0)
+    for _, calc := range calcs {
+        calc.share = math.Min(calc.guaranteed, calc.demand)
+        guaranteed += calc.share
+    }
+    // the guarantees do not fit: scale them down
+    if guaranteed >= total {
+        if guaranteed > 0 {
+            for _, calc := range calcs {
+                calc.share = calc.share * total / guaranteed
+            }
+        }
+        return
+    }
+
+    // divide the rest based on the weights: the queues with the lowest demand left per weight are satisfied first,
+    // the queues that are left divide what remains based on their weight
+    remaining := total - guaranteed
+    active := make([]*fairShareCalc, 0, len(calcs))
+    totalWeight := float64(0)
+    for _, calc := range calcs {
+        if calc.share < calc.demand {
+            active = append(active, calc)
+            totalWeight += calc.weight
+        }
+    }
+    sort.SliceStable(active, func(i, j int) bool {
+        return (active[i].demand-active[i].share)/active[i].weight < (active[j].demand-active[j].share)/active[j].weight
+    })
+    for i, calc := range active {
+        need := calc.demand - calc.share
+        if need <= remaining*calc.weight/totalWeight {
+            calc.share = calc.demand
+            remaining -= need
+            totalWeight -= calc.weight
+            continue
+        }
+        for _, left := range active[i:] {
+            left.share += remaining * left.weight / totalWeight
+        }
+        return
+    }
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/metrics"
+    "gotest.tools/assert"
+    "testing"
+)
+
+// metrics that track the fair share label sets, all other calls are not expected in the fair share tests
+type fairShareMetrics struct {
+    metrics.CoreSchedulerMetrics
+    fairShares map[string]float64
+}
+
+func (m *fairShareMetrics) SetQueueFairShare(partition string, queue string, resource string, value float64) {
+    m.fairShares[partition+"/"+queue+"/"+resource] = value
+}
+
+func (m *fairShareMetrics) RemoveQueueFairShare(partition string, queue string, resource string) {
+    delete(m.fairShares, partition+"/"+queue+"/"+resource)
+}
+
+// create a leaf queue with the weight, guaranteed and max resources set and pending resources
+func createFairShareQueue(t *testing.T, parent *SchedulingQueue, name string, weight float64, guaranteed, max, pending map[string]resources.Quantity) *SchedulingQueue {
+    leaf, err := createManagedQueue(parent, name, false)
+    assert.NilError(t, err, "failed to create queue %s", name)
+    leaf.Weight = weight
+    if guaranteed != nil {
+        leaf.CachedQueueInfo.GuaranteedResource = resources.NewResourceFromMap(guaranteed)
+    }
+    if max != nil {
+        leaf.CachedQueueInfo.MaxResource = resources.NewResourceFromMap(max)
+    }
+    if pending != nil {
+        leaf.IncPendingResource(resources.NewResourceFromMap(pending))
+    }
+    return leaf
+}
+
+func TestDivideFairShare(t *testing.T) {
+    // no demand: nothing is divided
+    calcs := []*fairShareCalc{{weight: 1}, {weight: 1}}
+    divideFairShare(100, calcs)
+    assert.Equal(t, calcs[0].share, float64(0))
+    assert.Equal(t, calcs[1].share, float64(0))
+
+    // demand above the total: divided based on the weights
+    calcs = []*fairShareCalc{{weight: 1, demand: 100}, {weight: 3, demand: 100}}
+    divideFairShare(100, calcs)
+    assert.Equal(t, calcs[0].share, float64(25))
+    assert.Equal(t, calcs[1].share, float64(75))
+
+    // low demand is satisfied, the rest goes to the other queues
+    calcs = []*fairShareCalc{{weight: 1, demand: 10}, {weight: 1, demand: 100}, {weight: 2, demand: 100}}
+    divideFairShare(100, calcs)
+    assert.Equal(t, calcs[0].share, float64(10))
+    assert.Equal(t, calcs[1].share, float64(30))
+    assert.Equal(t, calcs[2].share, float64(60))
+
+    // guarantees first then the weights
+    calcs = []*fairShareCalc{{weight: 1, guaranteed: 60, demand: 100}, {weight: 1, demand: 100}}
+    divideFairShare(100, calcs)
+    assert.Equal(t, calcs[0].share, float64(80))
+    assert.Equal(t, calcs[1].share, float64(20))
+
+    // guarantees above the total are scaled down, limited by the demand
+    calcs = []*fairShareCalc{{weight: 1, guaranteed: 100, demand: 100}, {weight: 1, guaranteed: 100, demand: 50}}
+    divideFairShare(100, calcs)
+    assert.Equal(t, int(calcs[0].share), 66)
+    assert.Equal(t, int(calcs[1].share), 33)
+}
+
+func TestCalculateFairShares(t *testing.T) {
+    root, err := createRootQueue()
+    assert.NilError(t, err, "failed to create root queue")
+    parent, err := createManagedQueue(root, "parent", true)
+    assert.NilError(t, err, "failed to create parent queue")
+    parent.Weight = 3
+    leaf1 := createFairShareQueue(t, parent, "leaf1", 1, nil, nil, map[string]resources.Quantity{"memory": 100, "vcore": 10})
+    leaf2 := createFairShareQueue(t, parent, "leaf2", 1, map[string]resources.Quantity{"memory": 40}, nil, map[string]resources.Quantity{"memory": 100, "vcore": 10})
+    leaf3 := createFairShareQueue(t, root, "leaf3", 1, nil, map[string]resources.Quantity{"memory": 10, "vcore": 100}, map[string]resources.Quantity{"memory": 100, "vcore": 100})
+
+    calculateFairShares(root, resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 100, "vcore": 100}))
+    assert.Assert(t, resources.Equals(root.CachedQueueInfo.GetFairShare(), resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 100, "vcore": 100})))
+    // memory: leaf3 is limited by its max, vcore: the parent demand is only 20
+    assert.Assert(t, resources.Equals(leaf3.CachedQueueInfo.GetFairShare(), resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 10, "vcore": 80})))
+    assert.Assert(t, resources.Equals(parent.CachedQueueInfo.GetFairShare(), resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 90, "vcore": 20})))
+    // memory: the guarantee of leaf2 first, the rest divided equally
+    assert.Assert(t, resources.Equals(leaf1.CachedQueueInfo.GetFairShare(), resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 25, "vcore": 10})))
+    assert.Assert(t, resources.Equals(leaf2.CachedQueueInfo.GetFairShare(), resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 65, "vcore": 10})))
+
+    // stopped queues only keep what they have allocated
+    err = leaf3.CachedQueueInfo.HandleQueueEvent(cache.Stop)
+    assert.NilError(t, err, "failed to stop queue")
+    calculateFairShares(root, resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 100, "vcore": 100}))
+    assert.Assert(t, resources.IsZero(leaf3.CachedQueueInfo.GetFairShare()))
+    assert.Assert(t, resources.Equals(parent.CachedQueueInfo.GetFairShare(), resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 100, "vcore": 20})))
+}
+
+func TestSortQueueFairShare(t *testing.T) {
+    root, err := createRootQueue()
+    assert.NilError(t, err, "failed to create root queue")
+    leaf1 := createFairShareQueue(t, root, "leaf1", 1, nil, nil, map[string]resources.Quantity{"memory": 100})
+    leaf2 := createFairShareQueue(t, root, "leaf2", 3, nil, nil, map[string]resources.Quantity{"memory": 100})
+    calculateFairShares(root, resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 100}))
+
+    // same usage: the queue with the larger fair share is further below its share
+    leaf1.ProposingResource = resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 20})
+    leaf2.ProposingResource = resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 20})
+    queues := []*SchedulingQueue{leaf1, leaf2}
+    SortQueue(queues, FairSortPolicy)
+    assert.Equal(t, queues[0], leaf2)
+    // leaf2 uses more of its share than leaf1
+    leaf2.ProposingResource = resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 70})
+    SortQueue(queues, FairSortPolicy)
+    assert.Equal(t, queues[0], leaf1)
+}
+
+func TestRecordFairShares(t *testing.T) {
+    root, err := createRootQueue()
+    assert.NilError(t, err, "failed to create root queue")
+    createFairShareQueue(t, root, "leaf1", 1, nil, nil, map[string]resources.Quantity{"memory": 100})
+    createFairShareQueue(t, root, "leaf2", 1, nil, nil, map[string]resources.Quantity{"memory": 100})
+    recorder := &fairShareMetrics{fairShares: make(map[string]float64)}
+    m := NewScheduler(nil, recorder)
+    partitionContext := &PartitionSchedulingContext{Name: "default", Root: root}
+
+    m.updateFairShares(partitionContext, resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 100}))
+    assert.Equal(t, len(recorder.fairShares), 3)
+    assert.Equal(t, recorder.fairShares["default/root.leaf1/memory"], float64(50))
+
+    // the label sets of a removed queue are deleted
+    root.removeChildQueue("leaf2")
+    m.updateFairShares(partitionContext, resources.NewResourceFromMap(map[string]resources.Quantity{"memory": 100}))
+    assert.Equal(t, len(recorder.fairShares), 2)
+    _, ok := recorder.fairShares["default/root.leaf2/memory"]
+    assert.Assert(t, !ok, "fair share of the removed queue should have been deleted")
+    assert.Equal(t, recorder.fairShares["default/root.leaf1/memory"], float64(100))
+
+    // all label sets of a removed partition are deleted
+    m.deleteRemovedPartitionFairShares(map[string]*PartitionSchedulingContext{})
+    assert.Equal(t, len(recorder.fairShares), 0)
+    assert.Equal(t, len(m.recordedFairShares), 0)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "context"
+    "fmt"
+    "github.com/cloudera/yunikorn-core/pkg/common"
+    "github.com/cloudera/yunikorn-core/pkg/events"
+    "sync/atomic"
+)
+
+const (
+    // partitions with fewer nodes always evaluate all nodes, larger partitions find at least this number of nodes
+    minFeasibleNodesToFind = 100
+    // lower bound for the percentage of nodes to score derived from the size of the partition
+    minFeasibleNodesPercentageToFind = 5
+)
+
+// Get the number of feasible nodes to find before the evaluation of the nodes for an allocation stops.
+// If the percentage is not configured it is derived from the size of the partition: starting at 50% it goes
+// down with the number of nodes to 5% for partitions with 5625 nodes or more.
+func numFeasibleNodesToFind(numAllNodes int, percentage int32) int {
+    if numAllNodes < minFeasibleNodesToFind || percentage >= 100 {
+        return numAllNodes
+    }
+    if percentage <= 0 {
+        percentage = int32(50 - numAllNodes/125)
+        if percentage < minFeasibleNodesPercentageToFind {
+            percentage = minFeasibleNodesPercentageToFind
+        }
+    }
+    numNodes := numAllNodes * int(percentage) / 100
+    if numNodes < minFeasibleNodesToFind {
+        return minFeasibleNodesToFind
+    }
+    return numNodes
+}
+
+// Find the nodes the candidate fits on and that pass the allocation conditions.
+// The nodes are evaluated in parallel by the given number of workers, starting at the start index and wrapping around.
+// The evaluation stops when the number of feasible nodes to find is reached, more nodes can be returned as the
+// workers finish the nodes they are evaluating. The feasible nodes are returned in evaluation order, independent
+// of the order in which the workers found them.
+// Nodes that fail the allocation conditions are recorded as one event for the candidate, not one event per node.
+func findFeasibleNodes(nodes []*SchedulingNode, startIdx int, candidate *SchedulingAllocationAsk, parallelism int, numToFind int) []*SchedulingNode {
+    nNodes := len(nodes)
+    if nNodes == 0 {
+        return nil
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+
+    // feasibility by position in the evaluation order, every worker only sets its own positions
+    feasible := make([]bool, nNodes)
+    var found int32
+    var predicatesFailed int32
+    checkNode := func(i int) {
+        node := nodes[(i+startIdx)%nNodes]
+        // the resource check is cheap compared to the predicates: check it first
+        if !node.CheckResource(candidate.AllocatedResource) {
+            return
+        }
+        if !node.CheckAllocateConditions(candidate) {
+            atomic.AddInt32(&predicatesFailed, 1)
+            return
+        }
+        feasible[i] = true
+        if atomic.AddInt32(&found, 1) >= int32(numToFind) {
+            cancel()
+        }
+    }
+    common.ParallelizeUntil(ctx, parallelism, nNodes, checkNode)
+
+    if failed := atomic.LoadInt32(&predicatesFailed); failed > 0 {
+        events.Record(&events.Event{
+            ObjectType:    events.ObjectAsk,
+            ObjectId:      candidate.AskProto.AllocationKey,
+            Partition:     candidate.PartitionName,
+            ApplicationId: candidate.ApplicationId,
+            QueueName:     candidate.QueueName,
+            Reason:        events.PredicateFailed,
+            Message:       fmt.Sprintf("predicates failed on %d nodes", failed),
+        })
+    }
+
+    feasibleNodes := make([]*SchedulingNode, 0, atomic.LoadInt32(&found))
+    for i, ok := range feasible {
+        if ok {
+            feasibleNodes = append(feasibleNodes, nodes[(i+startIdx)%nNodes])
+        }
+    }
+    return feasibleNodes
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "fmt"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/events"
+    "github.com/cloudera/yunikorn-core/pkg/plugins"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "gotest.tools/assert"
+    "testing"
+)
+
+func newTestSchedulingNodes(available []int) []*SchedulingNode {
+    nodes := make([]*SchedulingNode, len(available))
+    for i, memory := range available {
+        nodes[i] = &SchedulingNode{
+            NodeId:                  fmt.Sprintf("node-%d", i),
+            AllocatingResource:      resources.NewResource(),
+            PreemptingResource:      resources.NewResource(),
+            CachedAvailableResource: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: resources.Quantity(memory)}),
+        }
+    }
+    return nodes
+}
+
+// predicates plugin that fails the nodes in the set
+type fakePredicatesPlugin struct {
+    failNodes map[string]bool
+}
+
+func (f *fakePredicatesPlugin) Predicates(args *si.PredicatesArgs) error {
+    if f.failNodes[args.NodeId] {
+        return fmt.Errorf("predicates failed on node %s", args.NodeId)
+    }
+    return nil
+}
+
+func TestNumFeasibleNodesToFind(t *testing.T) {
+    // small partitions evaluate all nodes
+    assert.Equal(t, numFeasibleNodesToFind(10, 0), 10)
+    assert.Equal(t, numFeasibleNodesToFind(99, 10), 99)
+    // configured percentage with the minimum number of nodes
+    assert.Equal(t, numFeasibleNodesToFind(1000, 30), 300)
+    assert.Equal(t, numFeasibleNodesToFind(1000, 5), 100)
+    assert.Equal(t, numFeasibleNodesToFind(1000, 100), 1000)
+    // derived percentage: 50% minus 1% per 125 nodes with a lower bound of 5%
+    assert.Equal(t, numFeasibleNodesToFind(250, 0), 120)
+    assert.Equal(t, numFeasibleNodesToFind(3000, 0), 780)
+    assert.Equal(t, numFeasibleNodesToFind(10000, 0), 500)
+}
+
+func TestFindFeasibleNodes(t *testing.T) {
+    nodes := newTestSchedulingNodes([]int{5, 20, 5, 20, 20, 5})
+    candidate := &SchedulingAllocationAsk{
+        AskProto:          &si.AllocationAsk{AllocationKey: "ask-1"},
+        AllocatedResource: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 10}),
+    }
+    for _, parallelism := range []int{1, 4} {
+        // all feasible nodes in evaluation order
+        feasible := findFeasibleNodes(nodes, 2, candidate, parallelism, len(nodes))
+        assert.Equal(t, len(feasible), 3)
+        assert.Equal(t, feasible[0].NodeId, "node-3")
+        assert.Equal(t, feasible[1].NodeId, "node-4")
+        assert.Equal(t, feasible[2].NodeId, "node-1")
+    }
+    // a single worker stops at the number of nodes to find
+    feasible := findFeasibleNodes(nodes, 0, candidate, 1, 1)
+    assert.Equal(t, len(feasible), 1)
+    assert.Equal(t, feasible[0].NodeId, "node-1")
+
+    // allocating resources makes a node infeasible
+    assert.Assert(t, nodes[1].CheckAndAllocateResource(candidate.AllocatedResource))
+    assert.Assert(t, nodes[1].CheckAndAllocateResource(candidate.AllocatedResource))
+    assert.Assert(t, !nodes[1].CheckAndAllocateResource(candidate.AllocatedResource))
+    feasible = findFeasibleNodes(nodes, 0, candidate, 4, len(nodes))
+    assert.Equal(t, len(feasible), 2)
+    assert.Equal(t, feasible[0].NodeId, "node-3")
+    assert.Equal(t, len(findFeasibleNodes(nil, 0, candidate, 4, 1)), 0)
+}
+
+func TestFindFeasibleNodesPredicateFailures(t *testing.T) {
+    predicates := &fakePredicatesPlugin{failNodes: map[string]bool{"node-0": true, "node-2": true, "node-3": true}}
+    plugins.RegisterSchedulerPlugin(predicates)
+    // leave a plugin behind that passes all nodes
+    defer func() { predicates.failNodes = nil }()
+
+    nodes := newTestSchedulingNodes([]int{20, 20, 20, 20})
+    candidate := &SchedulingAllocationAsk{
+        AskProto:          &si.AllocationAsk{AllocationKey: "ask-predicates"},
+        ApplicationId:     "app-predicates",
+        AllocatedResource: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 10}),
+    }
+    for i := 0; i < 3; i++ {
+        feasible := findFeasibleNodes(nodes, 0, candidate, 4, len(nodes))
+        assert.Equal(t, len(feasible), 1)
+        assert.Equal(t, feasible[0].NodeId, "node-1")
+    }
+
+    // one event per evaluation that repeats, not one event per failed node
+    askEvents := events.GetEventStore().GetEvents("", events.ObjectAsk, "ask-predicates")
+    assert.Equal(t, len(askEvents), 1)
+    assert.Equal(t, askEvents[0].Reason, events.PredicateFailed)
+    assert.Equal(t, askEvents[0].Message, "predicates failed on 3 nodes")
+    assert.Equal(t, askEvents[0].NodeId, "")
+    assert.Equal(t, askEvents[0].Count, 3)
+}
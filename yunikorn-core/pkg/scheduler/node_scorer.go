@@ -0,0 +1,231 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/api"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/log"
+    "github.com/cloudera/yunikorn-core/pkg/plugins"
+    "go.uber.org/zap"
+    "sort"
+    "strings"
+)
+
+// Highest score a scorer can give a node
+const MaxNodeScore int64 = 100
+
+// A scorer rates the nodes an ask is feasible on, a higher score means a better node for the ask.
+type nodeScorer interface {
+    // Score the feasible nodes for the ask, the scores are returned in the order of the feasible nodes and must be
+    // between 0 and MaxNodeScore. All nodes of the partition are passed in for scorers that look beyond the node.
+    scoreNodes(ask *SchedulingAllocationAsk, feasibleNodes []*SchedulingNode, allNodes []*SchedulingNode) []int64
+}
+
+var nodeScorers = map[string]nodeScorer{
+    configs.NodeScorerLeastAllocated: &allocatedScorer{mostAllocated: false},
+    configs.NodeScorerMostAllocated:  &allocatedScorer{mostAllocated: true},
+    configs.NodeScorerRackSpread:     &spreadScorer{attribute: api.RACKNAME},
+    configs.NodeScorerZoneSpread:     &spreadScorer{attribute: api.FAILURE_DOMAIN_ZONE},
+    configs.NodeScorerImageLocality:  &imageLocalityScorer{},
+}
+
+// Get the scorer by name: the built-in scorers or a scorer registered by the RM.
+func getNodeScorer(name string) nodeScorer {
+    if scorer, ok := nodeScorers[name]; ok {
+        return scorer
+    }
+    if plugin := plugins.GetNodeScorerPlugin(name); plugin != nil {
+        return &pluginScorer{plugin: plugin}
+    }
+    return nil
+}
+
+// Order the feasible nodes by the sum of the weighted scores of the scorers, highest score first.
+// Nodes with the same score keep the evaluation order. Without weights the nodes are not scored.
+func sortNodesByScore(weights map[string]int32, ask *SchedulingAllocationAsk, feasibleNodes []*SchedulingNode,
+    allNodes []*SchedulingNode) []*SchedulingNode {
+    if len(weights) == 0 || len(feasibleNodes) < 2 {
+        return feasibleNodes
+    }
+    totals := make([]int64, len(feasibleNodes))
+    for name, weight := range weights {
+        if weight == 0 {
+            continue
+        }
+        scorer := getNodeScorer(name)
+        if scorer == nil {
+            log.Logger().Debug("node scorer not found",
+                zap.String("scorer", name))
+            continue
+        }
+        for i, score := range scorer.scoreNodes(ask, feasibleNodes, allNodes) {
+            totals[i] += int64(weight) * score
+        }
+    }
+    order := make([]int, len(feasibleNodes))
+    for i := range order {
+        order[i] = i
+    }
+    sort.SliceStable(order, func(i, j int) bool {
+        return totals[order[i]] > totals[order[j]]
+    })
+    sorted := make([]*SchedulingNode, len(feasibleNodes))
+    for i, idx := range order {
+        sorted[i] = feasibleNodes[idx]
+    }
+    return sorted
+}
+
+// Scores the nodes on the share of the node resources that is free after the ask is allocated.
+// Least allocated prefers empty nodes to spread the load, most allocated prefers full nodes to pack the load.
+// The score is the average over all resource types of the node.
+type allocatedScorer struct {
+    mostAllocated bool
+}
+
+func (s *allocatedScorer) scoreNodes(ask *SchedulingAllocationAsk, feasibleNodes []*SchedulingNode, allNodes []*SchedulingNode) []int64 {
+    scores := make([]int64, len(feasibleNodes))
+    for i, node := range feasibleNodes {
+        node.lock.RLock()
+        free := resources.Sub(node.getAvailableResource(), node.AllocatingResource)
+        node.lock.RUnlock()
+        resources.SubFrom(free, ask.AllocatedResource)
+
+        var sum int64
+        var count int64
+        for name, total := range node.NodeInfo.TotalResource.Resources {
+            if total <= 0 {
+                continue
+            }
+            freeShare := int64(free.Resources[name]) * MaxNodeScore / int64(total)
+            if freeShare < 0 {
+                freeShare = 0
+            }
+            sum += freeShare
+            count++
+        }
+        if count == 0 {
+            continue
+        }
+        scores[i] = sum / count
+        if s.mostAllocated {
+            scores[i] = MaxNodeScore - scores[i]
+        }
+    }
+    return scores
+}
+
+// Scores the nodes on the number of allocations of the application in the domain of the node, given by the
+// node attribute. Nodes in the domain with the fewest allocations of the application get the highest score.
+// Nodes without the attribute get a score of 0. The allocations on a node are counted once per scheduling cycle.
+type spreadScorer struct {
+    attribute string
+}
+
+func (s *spreadScorer) scoreNodes(ask *SchedulingAllocationAsk, feasibleNodes []*SchedulingNode, allNodes []*SchedulingNode) []int64 {
+    // count the allocations of the application by domain over all nodes
+    counts := make(map[string]int64)
+    var maxCount int64
+    for _, node := range allNodes {
+        domain := node.NodeInfo.GetAttribute(s.attribute)
+        if domain == "" {
+            continue
+        }
+        counts[domain] += node.getApplicationAllocationCount(ask.ApplicationId)
+        if counts[domain] > maxCount {
+            maxCount = counts[domain]
+        }
+    }
+    scores := make([]int64, len(feasibleNodes))
+    for i, node := range feasibleNodes {
+        domain := node.NodeInfo.GetAttribute(s.attribute)
+        if domain == "" {
+            continue
+        }
+        if maxCount == 0 {
+            scores[i] = MaxNodeScore
+            continue
+        }
+        scores[i] = MaxNodeScore * (maxCount - counts[domain]) / maxCount
+    }
+    return scores
+}
+
+// Scores the nodes on the share of the container images of the ask that are present on the node.
+// The images of the ask are set in the ask tags, the images on the node in the node attributes. Both are comma
+// separated lists.
+type imageLocalityScorer struct {
+}
+
+func (s *imageLocalityScorer) scoreNodes(ask *SchedulingAllocationAsk, feasibleNodes []*SchedulingNode, allNodes []*SchedulingNode) []int64 {
+    scores := make([]int64, len(feasibleNodes))
+    images := splitList(ask.AskProto.Tags[api.CONTAINER_IMAGE])
+    if len(images) == 0 {
+        return scores
+    }
+    for i, node := range feasibleNodes {
+        localImages := make(map[string]bool)
+        for _, image := range splitList(node.NodeInfo.GetAttribute(api.LOCAL_IMAGES)) {
+            localImages[image] = true
+        }
+        var present int64
+        for _, image := range images {
+            if localImages[image] {
+                present++
+            }
+        }
+        scores[i] = MaxNodeScore * present / int64(len(images))
+    }
+    return scores
+}
+
+// Split a comma separated list, empty entries are dropped.
+func splitList(list string) []string {
+    result := make([]string, 0)
+    for _, entry := range strings.Split(list, ",") {
+        if entry = strings.TrimSpace(entry); entry != "" {
+            result = append(result, entry)
+        }
+    }
+    return result
+}
+
+// Scores the nodes using the scorer plugin registered by the RM. Scores outside the valid range are capped.
+type pluginScorer struct {
+    plugin plugins.NodeScorerPlugin
+}
+
+func (s *pluginScorer) scoreNodes(ask *SchedulingAllocationAsk, feasibleNodes []*SchedulingNode, allNodes []*SchedulingNode) []int64 {
+    nodeIds := make([]string, len(feasibleNodes))
+    for i, node := range feasibleNodes {
+        nodeIds[i] = node.NodeId
+    }
+    pluginScores := s.plugin.ScoreNodes(ask.AskProto.AllocationKey, nodeIds)
+    scores := make([]int64, len(feasibleNodes))
+    for i, nodeId := range nodeIds {
+        score := pluginScores[nodeId]
+        if score < 0 {
+            score = 0
+        } else if score > MaxNodeScore {
+            score = MaxNodeScore
+        }
+        scores[i] = score
+    }
+    return scores
+}
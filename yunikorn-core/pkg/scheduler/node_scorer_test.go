@@ -0,0 +1,154 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/api"
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/plugins"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "gotest.tools/assert"
+    "testing"
+)
+
+func newScoringTestNode(t *testing.T, nodeId string, allocated []int, attributes map[string]string) *SchedulingNode {
+    info, err := cache.NewNodeInfo(&si.NewNodeInfo{
+        NodeId:     nodeId,
+        Attributes: attributes,
+        SchedulableResource: &si.Resource{
+            Resources: map[string]*si.Quantity{resources.MEMORY: {Value: 100}},
+        },
+    })
+    assert.NilError(t, err)
+    for i, memory := range allocated {
+        info.AddAllocation(&cache.AllocationInfo{
+            AllocationProto:   &si.Allocation{Uuid: nodeId + "-" + string(rune('a'+i))},
+            ApplicationId:     "app-1",
+            AllocatedResource: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: resources.Quantity(memory)}),
+        })
+    }
+    return NewSchedulingNode(info)
+}
+
+func newScoringTestAsk(tags map[string]string) *SchedulingAllocationAsk {
+    return &SchedulingAllocationAsk{
+        AskProto:          &si.AllocationAsk{AllocationKey: "ask-1", Tags: tags},
+        ApplicationId:     "app-1",
+        AllocatedResource: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 10}),
+    }
+}
+
+func getNodeOrder(nodes []*SchedulingNode) []string {
+    order := make([]string, len(nodes))
+    for i, node := range nodes {
+        order[i] = node.NodeId
+    }
+    return order
+}
+
+func TestAllocatedScorers(t *testing.T) {
+    nodes := []*SchedulingNode{
+        newScoringTestNode(t, "node-1", []int{80}, nil),
+        newScoringTestNode(t, "node-2", []int{20}, nil),
+    }
+    ask := newScoringTestAsk(nil)
+    assert.DeepEqual(t, getNodeScorer(configs.NodeScorerLeastAllocated).scoreNodes(ask, nodes, nodes), []int64{10, 70})
+    assert.DeepEqual(t, getNodeScorer(configs.NodeScorerMostAllocated).scoreNodes(ask, nodes, nodes), []int64{90, 30})
+
+    // the order is only changed if scorers are configured
+    assert.DeepEqual(t, getNodeOrder(sortNodesByScore(nil, ask, nodes, nodes)), []string{"node-1", "node-2"})
+    weights := map[string]int32{configs.NodeScorerLeastAllocated: 1}
+    assert.DeepEqual(t, getNodeOrder(sortNodesByScore(weights, ask, nodes, nodes)), []string{"node-2", "node-1"})
+    weights = map[string]int32{configs.NodeScorerMostAllocated: 1, configs.NodeScorerLeastAllocated: 0, "unknown": 5}
+    assert.DeepEqual(t, getNodeOrder(sortNodesByScore(weights, ask, nodes, nodes)), []string{"node-1", "node-2"})
+}
+
+func TestSpreadScorers(t *testing.T) {
+    allNodes := []*SchedulingNode{
+        newScoringTestNode(t, "node-1", []int{10, 10}, map[string]string{api.FAILURE_DOMAIN_ZONE: "zone-1", api.RACKNAME: "rack-1"}),
+        newScoringTestNode(t, "node-2", nil, map[string]string{api.FAILURE_DOMAIN_ZONE: "zone-1", api.RACKNAME: "rack-2"}),
+        newScoringTestNode(t, "node-3", []int{10}, map[string]string{api.FAILURE_DOMAIN_ZONE: "zone-2", api.RACKNAME: "rack-3"}),
+        newScoringTestNode(t, "node-4", nil, nil),
+    }
+    // the allocations on infeasible nodes count for the spread
+    feasibleNodes := allNodes[1:]
+    ask := newScoringTestAsk(nil)
+    assert.DeepEqual(t, getNodeScorer(configs.NodeScorerZoneSpread).scoreNodes(ask, feasibleNodes, allNodes), []int64{0, 50, 0})
+    assert.DeepEqual(t, getNodeScorer(configs.NodeScorerRackSpread).scoreNodes(ask, feasibleNodes, allNodes), []int64{100, 50, 0})
+
+    // without allocations of the application all nodes in a domain score the same
+    ask.ApplicationId = "app-2"
+    assert.DeepEqual(t, getNodeScorer(configs.NodeScorerZoneSpread).scoreNodes(ask, feasibleNodes, allNodes), []int64{100, 100, 0})
+
+    // the allocations are counted once per scheduling cycle: later cache changes are not seen by the nodes
+    allNodes[1].NodeInfo.AddAllocation(&cache.AllocationInfo{
+        AllocationProto:   &si.Allocation{Uuid: "node-2-a"},
+        ApplicationId:     "app-1",
+        AllocatedResource: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 10}),
+    })
+    ask.ApplicationId = "app-1"
+    assert.DeepEqual(t, getNodeScorer(configs.NodeScorerRackSpread).scoreNodes(ask, feasibleNodes, allNodes), []int64{100, 50, 0})
+    assert.Equal(t, allNodes[1].getApplicationAllocationCount("app-1"), int64(0))
+}
+
+func TestImageLocalityScorer(t *testing.T) {
+    nodes := []*SchedulingNode{
+        newScoringTestNode(t, "node-1", nil, map[string]string{api.LOCAL_IMAGES: "image-a,image-b,image-c"}),
+        newScoringTestNode(t, "node-2", nil, map[string]string{api.LOCAL_IMAGES: "image-b"}),
+        newScoringTestNode(t, "node-3", nil, nil),
+    }
+    scorer := getNodeScorer(configs.NodeScorerImageLocality)
+    ask := newScoringTestAsk(map[string]string{api.CONTAINER_IMAGE: "image-a, image-b"})
+    assert.DeepEqual(t, scorer.scoreNodes(ask, nodes, nodes), []int64{100, 50, 0})
+    // asks without images do not prefer any node
+    assert.DeepEqual(t, scorer.scoreNodes(newScoringTestAsk(nil), nodes, nodes), []int64{0, 0, 0})
+}
+
+type testScorerPlugin struct {
+    name   string
+    scores map[string]int64
+}
+
+func (p *testScorerPlugin) ScorerName() string {
+    return p.name
+}
+
+func (p *testScorerPlugin) ScoreNodes(allocationKey string, nodeIds []string) map[string]int64 {
+    return p.scores
+}
+
+func TestPluginScorer(t *testing.T) {
+    nodes := []*SchedulingNode{
+        newScoringTestNode(t, "node-1", nil, nil),
+        newScoringTestNode(t, "node-2", nil, nil),
+        newScoringTestNode(t, "node-3", nil, nil),
+    }
+    scorer := &pluginScorer{plugin: &testScorerPlugin{name: "test", scores: map[string]int64{"node-1": 150, "node-2": -5}}}
+    assert.DeepEqual(t, scorer.scoreNodes(newScoringTestAsk(nil), nodes, nodes), []int64{100, 0, 0})
+
+    // all registered scorers are used by their name
+    plugins.RegisterSchedulerPlugin(&testScorerPlugin{name: "test-1", scores: map[string]int64{"node-1": 100}})
+    plugins.RegisterSchedulerPlugin(&testScorerPlugin{name: "test-2", scores: map[string]int64{"node-3": 100}})
+    ask := newScoringTestAsk(nil)
+    assert.DeepEqual(t, getNodeScorer("test-1").scoreNodes(ask, nodes, nodes), []int64{100, 0, 0})
+    assert.DeepEqual(t, getNodeScorer("test-2").scoreNodes(ask, nodes, nodes), []int64{0, 0, 100})
+    assert.Assert(t, getNodeScorer("test-3") == nil)
+    weights := map[string]int32{"test-1": 1, "test-2": 2}
+    assert.DeepEqual(t, getNodeOrder(sortNodesByScore(weights, ask, nodes, nodes)), []string{"node-3", "node-1", "node-2"})
+}
@@ -81,7 +81,7 @@ func (manager PartitionManager) cleanQueues(schedulingQueue *SchedulingQueue) {
         }
     }
     // when we have done the children (or have none) this schedulingQueue might be removable
-    if schedulingQueue.isDraining() || !schedulingQueue.isManaged() {
+    if schedulingQueue.CachedQueueInfo.IsMarkedForRemoval() || !schedulingQueue.isManaged() {
         log.Logger().Debug("removing scheduling queue",
             zap.String("queueName", schedulingQueue.Name),
             zap.String("partitionName", manager.psc.Name))
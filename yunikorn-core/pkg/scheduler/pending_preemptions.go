@@ -0,0 +1,189 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "sync"
+    "time"
+)
+
+// A preemption that waits for the RM to release the preempted allocations (victims).
+// The resources of the victims are only free after the RM has terminated them: the allocation for the
+// preemptor is proposed after all victims are released.
+type pendingPreemption struct {
+    allocation *SchedulingAllocation          // allocation for the preemptor, the releases are the victims
+    victims    map[string]*resources.Resource // victims that have not been released yet by uuid
+    deadline   time.Time                      // the preemption is cancelled if the victims are not released in time
+}
+
+// Get the resources of the victims that have not been released yet.
+func (p *pendingPreemption) getPreemptingResource() *resources.Resource {
+    preempting := resources.NewResource()
+    for _, victim := range p.victims {
+        resources.AddTo(preempting, victim)
+    }
+    return preempting
+}
+
+// All preemptions that wait for victims to be released.
+// The preemptions are accessed from the scheduling, preemption and event handling routines.
+type pendingPreemptions struct {
+    preemptions map[*pendingPreemption]bool
+    victims     map[string]*pendingPreemption // victim uuid to the preemption it is released for
+    lock        sync.RWMutex
+}
+
+func newPendingPreemptions() *pendingPreemptions {
+    return &pendingPreemptions{
+        preemptions: make(map[*pendingPreemption]bool),
+        victims:     make(map[string]*pendingPreemption),
+    }
+}
+
+// Add a preemption for the allocation, the victims are the released allocations of the allocation.
+func (m *pendingPreemptions) add(alloc *SchedulingAllocation, deadline time.Time) {
+    m.lock.Lock()
+    defer m.lock.Unlock()
+
+    preemption := &pendingPreemption{
+        allocation: alloc,
+        victims:    make(map[string]*resources.Resource),
+        deadline:   deadline,
+    }
+    for uuid, victim := range alloc.ReleasedResources {
+        preemption.victims[uuid] = victim
+        m.victims[uuid] = preemption
+    }
+    m.preemptions[preemption] = true
+}
+
+// Mark the victim as released. If this was the last victim of the preemption the allocation of the
+// preemptor is returned, nil is returned in all other cases.
+func (m *pendingPreemptions) release(uuid string) *SchedulingAllocation {
+    m.lock.Lock()
+    defer m.lock.Unlock()
+
+    preemption, ok := m.victims[uuid]
+    if !ok {
+        return nil
+    }
+    delete(m.victims, uuid)
+    delete(preemption.victims, uuid)
+    if len(preemption.victims) != 0 {
+        return nil
+    }
+    delete(m.preemptions, preemption)
+    return preemption.allocation
+}
+
+// Remove all preemptions that have not finished before the given time.
+// Returns the allocations of the preemptors of the removed preemptions.
+func (m *pendingPreemptions) expire(now time.Time) []*SchedulingAllocation {
+    m.lock.Lock()
+    defer m.lock.Unlock()
+
+    expired := make([]*SchedulingAllocation, 0)
+    for preemption := range m.preemptions {
+        if now.Before(preemption.deadline) {
+            continue
+        }
+        m.removeInternal(preemption)
+        expired = append(expired, preemption.allocation)
+    }
+    return expired
+}
+
+// Remove all preemptions for the ask from the application.
+// This is used when the RM removes the ask: there is nothing to allocate anymore.
+func (m *pendingPreemptions) removeAsk(appId string, allocationKey string) {
+    m.lock.Lock()
+    defer m.lock.Unlock()
+
+    for preemption := range m.preemptions {
+        ask := preemption.allocation.SchedulingAsk
+        if ask.ApplicationId == appId && ask.AskProto.AllocationKey == allocationKey {
+            m.removeInternal(preemption)
+        }
+    }
+}
+
+// Remove the preemption and its victims, must be called while holding the lock.
+func (m *pendingPreemptions) removeInternal(preemption *pendingPreemption) {
+    for uuid := range preemption.victims {
+        delete(m.victims, uuid)
+    }
+    delete(m.preemptions, preemption)
+}
+
+// Is the allocation a victim of a pending preemption?
+func (m *pendingPreemptions) isVictim(uuid string) bool {
+    m.lock.RLock()
+    defer m.lock.RUnlock()
+
+    _, ok := m.victims[uuid]
+    return ok
+}
+
+// Get the number of victims of pending preemptions by application.
+func (m *pendingPreemptions) getVictimsByApplication() map[string]int {
+    m.lock.RLock()
+    defer m.lock.RUnlock()
+
+    victims := make(map[string]int)
+    for preemption := range m.preemptions {
+        for _, release := range preemption.allocation.Releases {
+            if _, ok := preemption.victims[release.Uuid]; ok {
+                victims[release.ApplicationId]++
+            }
+        }
+    }
+    return victims
+}
+
+// Get the resources of the pending preemptions in the partition by node.
+// The preemptors are allocating on the node. The victims that have not been released yet are preempting, but only
+// up to the resources of their preemptor: the rest of the victims is not available until the RM released them.
+func (m *pendingPreemptions) getNodeResources(partition string) (map[string]*resources.Resource, map[string]*resources.Resource) {
+    m.lock.RLock()
+    defer m.lock.RUnlock()
+
+    allocating := make(map[string]*resources.Resource)
+    preempting := make(map[string]*resources.Resource)
+    for preemption := range m.preemptions {
+        alloc := preemption.allocation
+        if alloc.PartitionName != partition {
+            continue
+        }
+        allocating[alloc.NodeId] = resources.Add(allocating[alloc.NodeId], alloc.SchedulingAsk.AllocatedResource)
+        preempting[alloc.NodeId] = resources.Add(preempting[alloc.NodeId],
+            resources.ComponentWiseMin(alloc.SchedulingAsk.AllocatedResource, preemption.getPreemptingResource()))
+    }
+    return allocating, preempting
+}
+
+// Get the victims of all pending preemptions, the partition of the preemption by victim uuid.
+func (m *pendingPreemptions) getVictims() map[string]string {
+    m.lock.RLock()
+    defer m.lock.RUnlock()
+
+    victims := make(map[string]string)
+    for uuid, preemption := range m.victims {
+        victims[uuid] = preemption.allocation.PartitionName
+    }
+    return victims
+}
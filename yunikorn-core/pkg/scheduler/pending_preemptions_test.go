@@ -0,0 +1,120 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "gotest.tools/assert"
+    "testing"
+    "time"
+)
+
+func newTestPreemptionAllocation(allocationKey string, nodeId string, memory int64, victims map[string]int64) *SchedulingAllocation {
+    ask := NewSchedulingAllocationAsk(&si.AllocationAsk{
+        AllocationKey: allocationKey,
+        ApplicationId: "app-1",
+        PartitionName: "[rm:123]default",
+        ResourceAsk: &si.Resource{
+            Resources: map[string]*si.Quantity{resources.MEMORY: {Value: memory}},
+        },
+        MaxAllocations: 1,
+    })
+    alloc := NewSchedulingAllocation(ask, nodeId)
+    alloc.ReleasedResources = make(map[string]*resources.Resource)
+    for uuid, victim := range victims {
+        alloc.ReleasedResources[uuid] = resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: resources.Quantity(victim)})
+    }
+    return alloc
+}
+
+func TestPendingPreemptionRelease(t *testing.T) {
+    pending := newPendingPreemptions()
+    alloc := newTestPreemptionAllocation("ask-1", "node-1", 30, map[string]int64{"victim-1": 10, "victim-2": 10})
+    pending.add(alloc, time.Now().Add(time.Minute))
+    assert.Assert(t, pending.isVictim("victim-1"), "victim-1 should be pending")
+
+    // the ask is allocating on the node, the victims are preempting
+    allocating, preempting := pending.getNodeResources("[rm:123]default")
+    assert.Equal(t, allocating["node-1"].Resources[resources.MEMORY], resources.Quantity(30))
+    assert.Equal(t, preempting["node-1"].Resources[resources.MEMORY], resources.Quantity(20))
+    allocating, _ = pending.getNodeResources("[rm:123]other")
+    assert.Equal(t, len(allocating), 0)
+    assert.DeepEqual(t, pending.getVictims(), map[string]string{"victim-1": "[rm:123]default", "victim-2": "[rm:123]default"})
+
+    // the preemption finishes with the last victim
+    assert.Assert(t, pending.release("unknown") == nil, "unknown release should not finish a preemption")
+    assert.Assert(t, pending.release("victim-1") == nil, "preemption should wait for victim-2")
+    allocating, preempting = pending.getNodeResources("[rm:123]default")
+    assert.Equal(t, allocating["node-1"].Resources[resources.MEMORY], resources.Quantity(30))
+    assert.Equal(t, preempting["node-1"].Resources[resources.MEMORY], resources.Quantity(10))
+    assert.Equal(t, pending.release("victim-2"), alloc)
+    assert.Assert(t, !pending.isVictim("victim-2"), "victim-2 should not be pending after the release")
+    allocating, _ = pending.getNodeResources("[rm:123]default")
+    assert.Equal(t, len(allocating), 0)
+    assert.Equal(t, len(pending.getVictims()), 0)
+}
+
+func TestPendingPreemptionLargerVictims(t *testing.T) {
+    pending := newPendingPreemptions()
+    alloc := newTestPreemptionAllocation("ask-1", "node-0", 10, map[string]int64{"victim-1": 20, "victim-2": 20})
+    pending.add(alloc, time.Now().Add(time.Minute))
+
+    // only the part of the victims used by the preemptor is preempting
+    allocating, preempting := pending.getNodeResources("[rm:123]default")
+    assert.Equal(t, allocating["node-0"].Resources[resources.MEMORY], resources.Quantity(10))
+    assert.Equal(t, preempting["node-0"].Resources[resources.MEMORY], resources.Quantity(10))
+
+    // the node is full until the victims are released: a second ask must not fit in the rest of the victims
+    node := newTestSchedulingNodes([]int{0})[0]
+    resources.AddTo(node.AllocatingResource, allocating["node-0"])
+    resources.AddTo(node.PreemptingResource, preempting["node-0"])
+    ask := resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 5})
+    assert.Assert(t, !node.CheckResource(ask), "ask should not fit in the victims of another preemption")
+    assert.Assert(t, !node.CheckAndAllocateResource(ask), "ask should not be allocated in the victims of another preemption")
+
+    // the released victim is available, the rest of the victim that is not released yet is not
+    assert.Assert(t, pending.release("victim-1") == nil, "preemption should wait for victim-2")
+    node.CachedAvailableResource = resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 20})
+    allocating, preempting = pending.getNodeResources("[rm:123]default")
+    node.AllocatingResource = allocating["node-0"]
+    node.PreemptingResource = preempting["node-0"]
+    ask = resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 25})
+    assert.Assert(t, !node.CheckResource(ask), "ask should not fit in the victim that is not released")
+    ask = resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 20})
+    assert.Assert(t, node.CheckResource(ask), "ask should fit in the released victim")
+}
+
+func TestPendingPreemptionExpire(t *testing.T) {
+    pending := newPendingPreemptions()
+    now := time.Now()
+    alloc1 := newTestPreemptionAllocation("ask-1", "node-1", 10, map[string]int64{"victim-1": 10})
+    alloc2 := newTestPreemptionAllocation("ask-2", "node-1", 10, map[string]int64{"victim-2": 10})
+    pending.add(alloc1, now.Add(-time.Second))
+    pending.add(alloc2, now.Add(time.Minute))
+
+    expired := pending.expire(now)
+    assert.Equal(t, len(expired), 1)
+    assert.Equal(t, expired[0], alloc1)
+    assert.Assert(t, !pending.isVictim("victim-1"), "victim-1 should be removed with the preemption")
+    assert.Assert(t, pending.release("victim-1") == nil, "expired preemption should not finish")
+
+    // removing the ask removes the preemption
+    pending.removeAsk("app-1", "ask-2")
+    assert.Assert(t, !pending.isVictim("victim-2"), "victim-2 should be removed with the ask")
+    assert.Equal(t, len(pending.expire(now.Add(time.Hour))), 0)
+}
@@ -121,6 +121,8 @@ func (m *AppPlacementManager) buildRules(rules []configs.PlacementRule) ([]rule,
     return newRules, nil
 }
 
+// Place the application in a queue using the rules, creating the queue if needed.
+// The placement decision, including the trace of all rules executed, is stored on the application.
 func (m *AppPlacementManager) PlaceApplication(app *cache.ApplicationInfo) error {
     // Placement manager not initialised cannot place application, just return
     m.lock.RLock()
@@ -128,73 +130,116 @@ func (m *AppPlacementManager) PlaceApplication(app *cache.ApplicationInfo) error
     if !m.initialised {
         return nil
     }
-    var queueName string
-    var err error
+    decision, err := m.placeApplication(app, true)
+    app.SetPlacementDecision(decision)
+    if err != nil {
+        app.QueueName = ""
+        return err
+    }
+    // Add the queue into the application, overriding what was submitted
+    app.SetQueue(m.info.GetQueue(decision.Queue))
+    return nil
+}
+
+// Run the rules for the application without placing it or creating queues.
+// Returns the placement decision the rules would make for the application as it is now, the application is not
+// changed. The error is set if the rules would reject the application.
+func (m *AppPlacementManager) SimulatePlacement(app *cache.ApplicationInfo) (*cache.PlacementDecision, error) {
+    m.lock.RLock()
+    defer m.lock.RUnlock()
+    if !m.initialised {
+        return nil, fmt.Errorf("placement rules are not configured for partition %s", m.name)
+    }
+    return m.placeApplication(app, false)
+}
+
+// Execute the rules in order until a rule returns a queue the user is allowed to submit to.
+// If the queue does not exist it is only created when the create flag is set.
+// The decision returned is never nil and contains the trace of all executed rules, the queue in the decision is only
+// set if the application was placed. Must be called while holding the lock.
+func (m *AppPlacementManager) placeApplication(app *cache.ApplicationInfo, create bool) (*cache.PlacementDecision, error) {
+    decision := &cache.PlacementDecision{}
     for _, checkRule := range m.rules {
         log.Logger().Debug("Executing rule for placing application",
             zap.String("ruleName", checkRule.getName()),
             zap.String("application", app.ApplicationId))
-        queueName, err = checkRule.placeApplication(app, m.info)
+        queueName, err := checkRule.placeApplication(app, m.info)
+        trace := cache.PlacementRuleTrace{
+            Rule:  checkRule.getName(),
+            Queue: queueName,
+        }
         if err != nil {
             log.Logger().Error("rule execution failed",
                 zap.String("ruleName", checkRule.getName()),
                 zap.Error(err))
-            app.QueueName = ""
-            return err
+            trace.Result = cache.PlacementFailed
+            trace.Message = err.Error()
+            decision.Trace = append(decision.Trace, trace)
+            return decision, err
+        }
+        if queueName == "" {
+            trace.Result = cache.PlacementNoMatch
+            trace.Message = checkRule.noMatchReason(app, m.info)
+            decision.Trace = append(decision.Trace, trace)
+            continue
         }
         // queueName returned make sure ACL allows access and create the queueName if not exist
-        if queueName != "" {
-            // get the queue object
-            queue := m.info.GetQueue(queueName)
-            // we create the queueName if it does not exists
-            if queue == nil {
-                current := queueName
-                for queue == nil {
-                    current = current[0:strings.LastIndex(current, cache.DOT)]
-                    // check if the queue exist
-                    queue = m.info.GetQueue(current)
-                }
-                // Check if the user is allowed to submit to this queueName, if not next rule
-                if !queue.CheckSubmitAccess(app.GetUser()) {
-                    log.Logger().Debug("Submit access denied on queue",
-                        zap.String("queueName", queue.GetQueuePath()),
-                        zap.String("ruleName", checkRule.getName()),
-                        zap.String("application", app.ApplicationId))
-                    // reset the queue name for the last rule in the chain
-                    queueName = ""
-                    continue
-                }
-                err = m.info.CreateQueues(queueName)
-                // errors can occur when the parent queueName is already a leaf queueName
-                if err != nil {
-                    app.QueueName = ""
-                    return err
-                }
-            } else {
-                // Check if the user is allowed to submit to this queueName, if not next rule
-                if !queue.CheckSubmitAccess(app.GetUser()) {
-                    log.Logger().Debug("Submit access denied on queue",
-                        zap.String("queueName", queueName),
-                        zap.String("ruleName", checkRule.getName()),
-                        zap.String("application", app.ApplicationId))
-                    // reset the queue name for the last rule in the chain
-                    queueName = ""
-                    continue
-                }
+        // get the queue object, for a queue that does not exist the ACL of the closest existing parent is used
+        queue := m.info.GetQueue(queueName)
+        trace.NewQueue = queue == nil
+        if queue == nil {
+            current := queueName
+            for queue == nil {
+                current = current[0:strings.LastIndex(current, cache.DOT)]
+                // check if the queue exist
+                queue = m.info.GetQueue(current)
+            }
+        }
+        // Check if the user is allowed to submit to this queueName, if not next rule
+        if !queue.CheckSubmitAccess(app.GetUser()) {
+            log.Logger().Debug("Submit access denied on queue",
+                zap.String("queueName", queue.GetQueuePath()),
+                zap.String("ruleName", checkRule.getName()),
+                zap.String("application", app.ApplicationId))
+            trace.Result = cache.PlacementDenied
+            trace.Message = fmt.Sprintf("submit access denied on queue %s", queue.GetQueuePath())
+            decision.Trace = append(decision.Trace, trace)
+            continue
+        }
+        // Check if the queue, or the parent the queue is created in, accepts new applications, if not next rule
+        if !queue.IsAcceptingApplications() {
+            log.Logger().Debug("Queue not accepting applications",
+                zap.String("queueName", queue.GetQueuePath()),
+                zap.String("ruleName", checkRule.getName()),
+                zap.String("application", app.ApplicationId))
+            trace.Result = cache.PlacementNotAccepting
+            trace.Message = fmt.Sprintf("queue %s is not accepting new applications", queue.GetQueuePath())
+            decision.Trace = append(decision.Trace, trace)
+            continue
+        }
+        if trace.NewQueue && create {
+            // errors can occur when the parent queueName is already a leaf queueName
+            if err = m.info.CreateQueues(queueName); err != nil {
+                trace.Result = cache.PlacementFailed
+                trace.Message = err.Error()
+                decision.Trace = append(decision.Trace, trace)
+                return decision, err
             }
-            // we have a queue that allows submitting and can be created: app placed
-            break
         }
+        // we have a queue that allows submitting and can be created: app placed
+        trace.Result = cache.PlacementPlaced
+        decision.Trace = append(decision.Trace, trace)
+        decision.Queue = queueName
+        decision.Rule = checkRule.getName()
+        break
     }
     log.Logger().Debug("Rule result for placing application",
         zap.String("application", app.ApplicationId),
-        zap.String("queueName", queueName))
+        zap.String("queueName", decision.Queue),
+        zap.Any("trace", decision.Trace))
     // no more rules to check no queueName found reject placement
-    if queueName == "" {
-        app.QueueName = ""
-        return fmt.Errorf("application rejected: no placment rule matched")
+    if decision.Queue == "" {
+        return decision, fmt.Errorf("application rejected: no placment rule matched")
     }
-    // Add the queue into the application, overriding what was submitted
-    app.SetQueue(m.info.GetQueue(queueName))
-    return nil
+    return decision, nil
 }
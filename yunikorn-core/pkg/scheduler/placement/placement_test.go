@@ -288,3 +288,215 @@ partitions:
         t.Errorf("parent queue: app should not have been placed, queue: '%s', error: %v", queueName, err)
     }
 }
+
+func TestManagerPlacementTrace(t *testing.T) {
+    // Create the structure for the test
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: testparent
+            submitacl: "*"
+            queues:
+              - name: testchild
+          - name: restricted
+            submitacl: "other-user "
+`
+    partInfo, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("Partition create failed with error: %v", err)
+    }
+    man := NewPlacementManager(partInfo)
+    rules := []configs.PlacementRule{
+        {Name: "tag",
+            Value: "namespace"},
+        {Name: "user",
+            Filter: configs.Filter{
+                Type:  "deny",
+                Users: []string{"testchild"},
+            }},
+        {Name: "fixed",
+            Value: "root.restricted"},
+        {Name: "user",
+            Parent: &configs.PlacementRule{
+                Name:  "fixed",
+                Value: "testparent"},
+        },
+    }
+    err = man.UpdateRules(rules)
+    if err != nil || !man.initialised {
+        t.Fatalf("failed to update existing manager, init state: %t, error: %v", man.initialised, err)
+    }
+    user := security.UserGroup{
+        User:   "testchild",
+        Groups: []string{},
+    }
+    appInfo := cache.NewApplicationInfo("app1", "default", "", user, map[string]string{})
+    err = man.PlaceApplication(appInfo)
+    if err != nil || appInfo.QueueName != "root.testparent.testchild" {
+        t.Fatalf("app should have been placed in user queue, queue: '%s', error: %v", appInfo.QueueName, err)
+    }
+    decision := appInfo.GetPlacementDecision()
+    if decision == nil || decision.Queue != "root.testparent.testchild" || decision.Rule != "user" {
+        t.Fatalf("placement decision not stored on the application: %v", decision)
+    }
+    expected := []string{cache.PlacementNoMatch, cache.PlacementNoMatch, cache.PlacementDenied, cache.PlacementPlaced}
+    if len(decision.Trace) != len(expected) {
+        t.Fatalf("expected a trace entry per executed rule, got: %v", decision.Trace)
+    }
+    for i, result := range expected {
+        if decision.Trace[i].Result != result {
+            t.Errorf("rule %d: expected result '%s' got '%s' (%s)", i, result, decision.Trace[i].Result, decision.Trace[i].Message)
+        }
+    }
+    if decision.Trace[0].Message != "application does not have the tag namespace set" {
+        t.Errorf("unexpected message for missing tag: %s", decision.Trace[0].Message)
+    }
+    if decision.Trace[1].Message != "user or group filtered out by the rule filter" {
+        t.Errorf("unexpected message for filtered user: %s", decision.Trace[1].Message)
+    }
+
+    // rejected application has the trace of all rules
+    user = security.UserGroup{
+        User:   "unknown-user",
+        Groups: []string{},
+    }
+    rules = []configs.PlacementRule{
+        {Name: "user"},
+    }
+    err = man.UpdateRules(rules)
+    if err != nil {
+        t.Fatalf("failed to update existing manager, error: %v", err)
+    }
+    appInfo = cache.NewApplicationInfo("app2", "default", "", user, map[string]string{})
+    err = man.PlaceApplication(appInfo)
+    if err == nil || appInfo.QueueName != "" {
+        t.Fatalf("app should not have been placed, queue: '%s', error: %v", appInfo.QueueName, err)
+    }
+    decision = appInfo.GetPlacementDecision()
+    if decision == nil || decision.Queue != "" || len(decision.Trace) != 1 {
+        t.Fatalf("placement decision not stored on the rejected application: %v", decision)
+    }
+    if decision.Trace[0].Message != "queue does not exist and the rule is not allowed to create it" {
+        t.Errorf("unexpected message for create flag: %s", decision.Trace[0].Message)
+    }
+}
+
+func TestManagerSimulatePlacement(t *testing.T) {
+    // Create the structure for the test
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: testparent
+            submitacl: "*"
+            parent: true
+`
+    partInfo, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("Partition create failed with error: %v", err)
+    }
+    man := NewPlacementManager(partInfo)
+    user := security.UserGroup{
+        User:   "testchild",
+        Groups: []string{},
+    }
+    appInfo := cache.NewApplicationInfo("app1", "default", "", user, map[string]string{})
+    // manager without rules cannot simulate
+    decision, err := man.SimulatePlacement(appInfo)
+    if err == nil || decision != nil {
+        t.Errorf("simulate without rules should have failed: %v", decision)
+    }
+    rules := []configs.PlacementRule{
+        {Name: "user",
+            Create: true,
+            Parent: &configs.PlacementRule{
+                Name:  "fixed",
+                Value: "testparent"},
+        },
+    }
+    err = man.UpdateRules(rules)
+    if err != nil {
+        t.Fatalf("failed to update existing manager, error: %v", err)
+    }
+    decision, err = man.SimulatePlacement(appInfo)
+    if err != nil || decision.Queue != "root.testparent.testchild" || !decision.Trace[0].NewQueue {
+        t.Errorf("simulate should have placed the app in a new queue: %v, error: %v", decision, err)
+    }
+    // nothing is changed by the simulation
+    if partInfo.GetQueue("root.testparent.testchild") != nil {
+        t.Error("simulate placement created the queue")
+    }
+    if appInfo.QueueName != "" || appInfo.GetPlacementDecision() != nil {
+        t.Errorf("simulate placement changed the application: queue '%s'", appInfo.QueueName)
+    }
+}
+
+func TestManagerPlacementQueueState(t *testing.T) {
+    // Create the structure for the test
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        submitacl: "*"
+        queues:
+          - name: stopped
+          - name: drained
+            parent: true
+          - name: fallback
+`
+    partInfo, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("Partition create failed with error: %v", err)
+    }
+    man := NewPlacementManager(partInfo)
+    rules := []configs.PlacementRule{
+        {Name: "fixed",
+            Value: "root.stopped"},
+        {Name: "user",
+            Create: true,
+            Parent: &configs.PlacementRule{
+                Name:  "fixed",
+                Value: "root.drained"},
+        },
+        {Name: "fixed",
+            Value: "root.fallback"},
+    }
+    err = man.UpdateRules(rules)
+    if err != nil || !man.initialised {
+        t.Fatalf("failed to update existing manager, init state: %t, error: %v", man.initialised, err)
+    }
+    if err = partInfo.GetQueue("root.stopped").ChangeState(cache.Stop); err != nil {
+        t.Fatalf("stopping queue failed: %v", err)
+    }
+    if err = partInfo.GetQueue("root.drained").ChangeState(cache.Drain); err != nil {
+        t.Fatalf("draining queue failed: %v", err)
+    }
+    user := security.UserGroup{
+        User:   "testuser",
+        Groups: []string{},
+    }
+    appInfo := cache.NewApplicationInfo("app1", "default", "", user, map[string]string{})
+    err = man.PlaceApplication(appInfo)
+    if err != nil || appInfo.QueueName != "root.fallback" {
+        t.Fatalf("app should have been placed in the fallback queue, queue: '%s', error: %v", appInfo.QueueName, err)
+    }
+    decision := appInfo.GetPlacementDecision()
+    expected := []string{cache.PlacementNotAccepting, cache.PlacementNotAccepting, cache.PlacementPlaced}
+    if len(decision.Trace) != len(expected) {
+        t.Fatalf("expected a trace entry per executed rule, got: %v", decision.Trace)
+    }
+    for i, result := range expected {
+        if decision.Trace[i].Result != result {
+            t.Errorf("rule %d: expected result '%s' got '%s' (%s)", i, result, decision.Trace[i].Result, decision.Trace[i].Message)
+        }
+    }
+    if partInfo.GetQueue("root.drained.testuser") != nil {
+        t.Errorf("queue should not have been created below the drained parent")
+    }
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/log"
+    "go.uber.org/zap"
+)
+
+// A rule to place an application based on the primary group of the submitting user.
+// The primary group is the first group in the list of groups of the user.
+type primaryGroupRule struct {
+    basicRule
+}
+
+func (pgr *primaryGroupRule) getName() string {
+    return "primarygroup"
+}
+
+func (pgr *primaryGroupRule) noMatchReason(app *cache.ApplicationInfo, info *cache.PartitionInfo) string {
+    if groups := app.GetUser().Groups; len(groups) == 0 || groups[0] == "" {
+        return "user does not have a primary group"
+    }
+    return pgr.basicRule.noMatchReason(app, info)
+}
+
+func (pgr *primaryGroupRule) initialise(conf configs.PlacementRule) error {
+    return pgr.initialiseBasic(conf)
+}
+
+func (pgr *primaryGroupRule) placeApplication(app *cache.ApplicationInfo, info *cache.PartitionInfo) (string, error) {
+    // a user without groups has no primary group: skip all other processing
+    user := app.GetUser()
+    if len(user.Groups) == 0 || user.Groups[0] == "" {
+        return "", nil
+    }
+    // before anything run the filter
+    if !pgr.filter.allowUser(user) {
+        log.Logger().Debug("Primary group rule filtered",
+            zap.String("application", app.ApplicationId),
+            zap.Any("user", user))
+        return "", nil
+    }
+    parentName, err := pgr.placeParent(app, info)
+    if err != nil || parentName == "" {
+        return "", err
+    }
+    queueName := parentName + cache.DOT + replaceDot(user.Groups[0])
+    log.Logger().Debug("Primary group rule intermediate result",
+        zap.String("application", app.ApplicationId),
+        zap.String("queue", queueName))
+    // if we cannot create the queue it must exist, rule does not match otherwise
+    if !pgr.create && info.GetQueue(queueName) == nil {
+        return "", nil
+    }
+    log.Logger().Info("Primary group rule application placed",
+        zap.String("application", app.ApplicationId),
+        zap.String("queue", queueName))
+    return queueName, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/security"
+    "testing"
+)
+
+func TestPrimaryGroupRulePlace(t *testing.T) {
+    // Create the structure for the test
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: testgroup
+      - name: testparent
+        queues:
+          - name: testgroup
+      - name: testleaf
+`
+    partInfo, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("Partition create failed with error: %v", err)
+    }
+    tags := make(map[string]string, 0)
+    user := security.UserGroup{
+        User: "testuser",
+        Groups: []string{"testgroup", "secondary"},
+    }
+    appInfo := cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+
+    // group queue that exists directly under the root
+    conf := configs.PlacementRule{
+        Name: "primaryGroup",
+    }
+    rule, err := newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("primary group rule create failed, err %v", err)
+    }
+    queue, err := rule.placeApplication(appInfo, partInfo)
+    if queue != "root.testgroup" || err != nil {
+        t.Errorf("primary group rule failed to place queue in correct queue '%s', err %v", queue, err)
+    }
+
+    // user without groups does not match
+    user = security.UserGroup{
+        User: "testuser",
+        Groups: []string{},
+    }
+    appInfo = cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("primary group rule placed app for user without groups '%s', err %v", queue, err)
+    }
+
+    // group queue that exists in the hierarchy below the parent
+    conf = configs.PlacementRule{
+        Name: "primarygroup",
+        Parent: &configs.PlacementRule{
+            Name: "fixed",
+            Value: "testparent",
+        },
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("primary group rule create failed with parent rule, err %v", err)
+    }
+    user = security.UserGroup{
+        User: "testuser",
+        Groups: []string{"testgroup"},
+    }
+    appInfo = cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "root.testparent.testgroup" || err != nil {
+        t.Errorf("primary group rule failed to place queue in correct queue '%s', err %v", queue, err)
+    }
+
+    // parent rule returning a leaf queue fails the rule
+    conf = configs.PlacementRule{
+        Name: "primarygroup",
+        Parent: &configs.PlacementRule{
+            Name: "fixed",
+            Value: "testleaf",
+        },
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("primary group rule create failed with parent rule, err %v", err)
+    }
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "" || err == nil {
+        t.Errorf("primary group rule with leaf parent should have failed '%s', err %v", queue, err)
+    }
+
+    // group queue that does not exist
+    user = security.UserGroup{
+        User: "testuser",
+        Groups: []string{"unknown.group"},
+    }
+    appInfo = cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+    conf = configs.PlacementRule{
+        Name: "primarygroup",
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("primary group rule create failed, err %v", err)
+    }
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("primary group rule placed in to be created queue with create false '%s', err %v", queue, err)
+    }
+    conf = configs.PlacementRule{
+        Name: "primarygroup",
+        Create: true,
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("primary group rule create failed, err %v", err)
+    }
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "root.unknown_dot_group" || err != nil {
+        t.Errorf("primary group rule failed to place in to be created queue '%s', err %v", queue, err)
+    }
+}
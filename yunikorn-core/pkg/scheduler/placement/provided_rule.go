@@ -37,6 +37,13 @@ func (pr *providedRule) getName() string {
     return "provided"
 }
 
+func (pr *providedRule) noMatchReason(app *cache.ApplicationInfo, info *cache.PartitionInfo) string {
+    if app.QueueName == "" {
+        return "no queue provided on submission"
+    }
+    return pr.basicRule.noMatchReason(app, info)
+}
+
 func (pr *providedRule) initialise(conf configs.PlacementRule) error {
     pr.create = conf.Create
     pr.filter = newFilter(conf.Filter)
@@ -0,0 +1,165 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+    "fmt"
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/log"
+    "go.uber.org/zap"
+    "regexp"
+    "strings"
+)
+
+// A rule to place an application by mapping a value to a queue name using a regular expression.
+// The value is taken from the tag set in the rule or is the user name if no tag is set. The regular expression must
+// match the whole value. The target is expanded using the capture groups of the match, for example the expression
+// "team-(.*)" with the target "root.teams.$1" places the value "team-a" in the queue "root.teams.a".
+// Dots in the captured values are replaced before expanding the target.
+// If the target expands to a fully qualified queue name the parent rule is not run.
+// NOTE: tags are normalised and only use lower case (not case sensitive)
+type regexRule struct {
+    basicRule
+    tagName string
+    regex   *regexp.Regexp
+    target  string
+}
+
+func (rr *regexRule) getName() string {
+    return "regex"
+}
+
+func (rr *regexRule) noMatchReason(app *cache.ApplicationInfo, info *cache.PartitionInfo) string {
+    value := app.GetUser().User
+    source := "user name"
+    if rr.tagName != "" {
+        value = app.GetTag(rr.tagName)
+        source = "tag " + rr.tagName
+    }
+    if value == "" {
+        return fmt.Sprintf("application does not have the %s set", source)
+    }
+    queueName := rr.expand(value)
+    if queueName == "" {
+        return fmt.Sprintf("%s '%s' does not match the expression %s", source, value, rr.regex.String())
+    }
+    if !validQueueName(queueName) {
+        return fmt.Sprintf("%s '%s' expands to the illegal queue name '%s'", source, value, queueName)
+    }
+    return rr.basicRule.noMatchReason(app, info)
+}
+
+func (rr *regexRule) initialise(conf configs.PlacementRule) error {
+    if conf.Value == "" {
+        return fmt.Errorf("a regex queue rule must have a regular expression set")
+    }
+    var err error
+    // the expression must match the whole value
+    rr.regex, err = regexp.Compile("^(?:" + conf.Value + ")$")
+    if err != nil {
+        return fmt.Errorf("a regex queue rule must have a valid regular expression: %v", err)
+    }
+    rr.target = conf.Target
+    if rr.target == "" {
+        return fmt.Errorf("a regex queue rule must have a target set")
+    }
+    rr.tagName = normalise(conf.Tag)
+    return rr.initialiseBasic(conf)
+}
+
+func (rr *regexRule) placeApplication(app *cache.ApplicationInfo, info *cache.PartitionInfo) (string, error) {
+    // get the value to map: skip all other processing if the value is not set or does not match
+    value := app.GetUser().User
+    if rr.tagName != "" {
+        value = app.GetTag(rr.tagName)
+    }
+    if value == "" {
+        return "", nil
+    }
+    queueName := rr.expand(value)
+    if queueName == "" {
+        return "", nil
+    }
+    // an empty capture group or illegal characters in the target can expand to an illegal queue name
+    if !validQueueName(queueName) {
+        log.Logger().Debug("Regex rule expanded to an illegal queue name",
+            zap.String("application", app.ApplicationId),
+            zap.String("value", value),
+            zap.String("queue", queueName))
+        return "", nil
+    }
+    // before anything run the filter
+    if !rr.filter.allowUser(app.GetUser()) {
+        log.Logger().Debug("Regex rule filtered",
+            zap.String("application", app.ApplicationId),
+            zap.Any("user", app.GetUser()),
+            zap.String("value", value))
+        return "", nil
+    }
+    // if we have a fully qualified queue after expanding do not run the parent rule
+    if !strings.HasPrefix(queueName, configs.RootQueue+cache.DOT) {
+        parentName, err := rr.placeParent(app, info)
+        if err != nil || parentName == "" {
+            return "", err
+        }
+        queueName = parentName + cache.DOT + queueName
+    }
+    log.Logger().Debug("Regex rule intermediate result",
+        zap.String("application", app.ApplicationId),
+        zap.String("queue", queueName))
+    // if we cannot create the queue it must exist, rule does not match otherwise
+    if !rr.create && info.GetQueue(queueName) == nil {
+        return "", nil
+    }
+    log.Logger().Info("Regex rule application placed",
+        zap.String("application", app.ApplicationId),
+        zap.String("queue", queueName))
+    return queueName, nil
+}
+
+// Expand the target with the capture groups of the expression matched against the value.
+// Returns an empty string if the value does not match.
+func (rr *regexRule) expand(value string) string {
+    match := rr.regex.FindStringSubmatchIndex(value)
+    if match == nil {
+        return ""
+    }
+    // rebuild the source from the captured values with the dots replaced and point the match at the new source
+    var source strings.Builder
+    replaced := make([]int, len(match))
+    for i := 0; i < len(match); i += 2 {
+        if match[i] < 0 {
+            replaced[i], replaced[i+1] = -1, -1
+            continue
+        }
+        replaced[i] = source.Len()
+        source.WriteString(replaceDot(value[match[i]:match[i+1]]))
+        replaced[i+1] = source.Len()
+    }
+    return string(rr.regex.ExpandString(nil, rr.target, source.String(), replaced))
+}
+
+// Check that each part of the (partial) queue name is a legal queue name: this also rejects empty parts.
+func validQueueName(name string) bool {
+    for _, part := range strings.Split(name, cache.DOT) {
+        if !configs.QueueNameRegExp.MatchString(part) {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/security"
+    "testing"
+)
+
+func TestRegexRule(t *testing.T) {
+    conf := configs.PlacementRule{
+        Name: "regex",
+        Target: "root.teams.$1",
+    }
+    rule, err := newRule(conf)
+    if err == nil || rule != nil {
+        t.Errorf("regex rule create did not fail without expression, rule: %v", rule)
+    }
+    conf = configs.PlacementRule{
+        Name: "regex",
+        Value: "team-(.*",
+        Target: "root.teams.$1",
+    }
+    rule, err = newRule(conf)
+    if err == nil || rule != nil {
+        t.Errorf("regex rule create did not fail with invalid expression, rule: %v", rule)
+    }
+    conf = configs.PlacementRule{
+        Name: "regex",
+        Value: "team-(.*)",
+    }
+    rule, err = newRule(conf)
+    if err == nil || rule != nil {
+        t.Errorf("regex rule create did not fail without target, rule: %v", rule)
+    }
+    conf = configs.PlacementRule{
+        Name: "regex",
+        Value: "team-(.*)",
+        Target: "root.teams.$1",
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Errorf("regex rule create failed, err %v", err)
+    }
+}
+
+func TestRegexRulePlace(t *testing.T) {
+    // Create the structure for the test
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: teams
+        queues:
+          - name: alpha
+          - name: a_dot_b
+      - name: testparent
+        queues:
+          - name: alpha
+`
+    partInfo, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("Partition create failed with error: %v", err)
+    }
+    user := security.UserGroup{
+        User: "team-alpha",
+        Groups: []string{},
+    }
+    tags := map[string]string{"namespace": "team-a.b"}
+    appInfo := cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+
+    // map the user name to a fully qualified queue
+    conf := configs.PlacementRule{
+        Name: "regex",
+        Value: "team-(.*)",
+        Target: "root.teams.$1",
+    }
+    rule, err := newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("regex rule create failed, err %v", err)
+    }
+    queue, err := rule.placeApplication(appInfo, partInfo)
+    if queue != "root.teams.alpha" || err != nil {
+        t.Errorf("regex rule failed to place queue in correct queue '%s', err %v", queue, err)
+    }
+
+    // map the tag, dots in the captured value are replaced
+    conf = configs.PlacementRule{
+        Name: "regex",
+        Tag: "Namespace",
+        Value: "team-(?P<team>.*)",
+        Target: "root.teams.${team}",
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("regex rule create failed with tag, err %v", err)
+    }
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "root.teams.a_dot_b" || err != nil {
+        t.Errorf("regex rule failed to place queue in correct queue '%s', err %v", queue, err)
+    }
+
+    // the expression must match the whole value
+    conf = configs.PlacementRule{
+        Name: "regex",
+        Value: "team",
+        Target: "root.teams",
+        Create: true,
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("regex rule create failed, err %v", err)
+    }
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("regex rule placed app on a partial match '%s', err %v", queue, err)
+    }
+
+    // an empty capture expands to an illegal queue name, even with create set
+    emptyApp := cache.NewApplicationInfo("app2", "default", "ignored", security.UserGroup{User: "team-", Groups: []string{}}, nil)
+    conf = configs.PlacementRule{
+        Name: "regex",
+        Value: "team-(.*)",
+        Target: "root.teams.$1",
+        Create: true,
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("regex rule create failed, err %v", err)
+    }
+    queue, err = rule.placeApplication(emptyApp, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("regex rule placed app on an empty capture '%s', err %v", queue, err)
+    }
+    // illegal characters in the capture are not allowed in the queue name
+    emptyApp = cache.NewApplicationInfo("app3", "default", "ignored", security.UserGroup{User: "team-a@b", Groups: []string{}}, nil)
+    queue, err = rule.placeApplication(emptyApp, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("regex rule placed app with an illegal queue name '%s', err %v", queue, err)
+    }
+
+    // a target that is not fully qualified uses the parent rule
+    conf = configs.PlacementRule{
+        Name: "regex",
+        Value: "team-(.*)",
+        Target: "$1",
+        Parent: &configs.PlacementRule{
+            Name: "fixed",
+            Value: "testparent",
+        },
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("regex rule create failed with parent rule, err %v", err)
+    }
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "root.testparent.alpha" || err != nil {
+        t.Errorf("regex rule failed to place queue in correct queue '%s', err %v", queue, err)
+    }
+
+    // queue that does not exist
+    user = security.UserGroup{
+        User: "team-beta",
+        Groups: []string{},
+    }
+    appInfo = cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("regex rule placed in to be created queue with create false '%s', err %v", queue, err)
+    }
+}
@@ -43,6 +43,11 @@ type rule interface {
 	// Return the parent rule.
 	// This method is implemented in the basicRule which each rule must be based on.
 	getParent() rule
+
+	// Explain why the rule did not return a queue for the application, used in the placement trace.
+	// The basicRule checks the filter, parent rule and create flag. Rules that skip the application before that
+	// should check their own conditions first.
+	noMatchReason(app *cache.ApplicationInfo, info *cache.PartitionInfo) string
 }
 
 // Basic structure that every placement rule uses.
@@ -64,6 +69,56 @@ func (r *basicRule) getName() string {
 	return "unnamed rule"
 }
 
+// Explain why the rule did not match based on the filter, the parent rule and the create flag.
+func (r *basicRule) noMatchReason(app *cache.ApplicationInfo, info *cache.PartitionInfo) string {
+	if !r.filter.allowUser(app.GetUser()) {
+		return "user or group filtered out by the rule filter"
+	}
+	if r.parent != nil {
+		if parentName, err := r.parent.placeApplication(app, info); err == nil && parentName == "" {
+			return fmt.Sprintf("parent rule %s did not match: %s", r.parent.getName(), r.parent.noMatchReason(app, info))
+		}
+	}
+	if !r.create {
+		return "queue does not exist and the rule is not allowed to create it"
+	}
+	return "rule did not return a queue"
+}
+
+// Initialise the create flag, filter and parent rule shared by all rules from the configuration.
+func (r *basicRule) initialiseBasic(conf configs.PlacementRule) error {
+	r.create = conf.Create
+	r.filter = newFilter(conf.Filter)
+	var err error
+	if conf.Parent != nil {
+		r.parent, err = newRule(*conf.Parent)
+	}
+	return err
+}
+
+// Run the parent rule if set and return the fully qualified parent queue name.
+// The root queue is returned if no parent rule is set. An empty string is returned if the parent rule did not match.
+// The parent rule fails if it returns a leaf queue.
+func (r *basicRule) placeParent(app *cache.ApplicationInfo, info *cache.PartitionInfo) (string, error) {
+	if r.parent == nil {
+		return configs.RootQueue, nil
+	}
+	parentName, err := r.parent.placeApplication(app, info)
+	// failed parent rule, fail this rule
+	// rule did not match: this could be filter or create flag related
+	if err != nil || parentName == "" {
+		return "", err
+	}
+	// check if this is a parent queue and qualify it
+	if !strings.HasPrefix(parentName, configs.RootQueue+cache.DOT) {
+		parentName = configs.RootQueue + cache.DOT + parentName
+	}
+	if queue := info.GetQueue(parentName); queue != nil && queue.IsLeafQueue() {
+		return "", fmt.Errorf("parent rule returned a leaf queue: %s", parentName)
+	}
+	return parentName, nil
+}
+
 // Create a new rule based on the getName of the rule requested. The rule is initialised with the configuration and can
 // be used directly.
 func newRule(conf configs.PlacementRule) (rule, error) {
@@ -84,6 +139,15 @@ func newRule(conf configs.PlacementRule) (rule, error) {
 	// rule that uses a tag from the application (like namespace)
 	case "tag":
 		newRule = &tagRule{}
+	// rule that uses the primary group of the user as the queue
+	case "primarygroup":
+		newRule = &primaryGroupRule{}
+	// rule that uses a secondary group of the user as the queue
+	case "secondarygroup":
+		newRule = &secondaryGroupRule{}
+	// rule that maps a tag or the user name to a queue using a regular expression
+	case "regex":
+		newRule = &regexRule{}
 	// test rule not to be used outside of testing code
 	case "test":
 		newRule = &testRule{}
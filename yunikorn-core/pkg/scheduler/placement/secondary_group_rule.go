@@ -0,0 +1,88 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/log"
+    "go.uber.org/zap"
+)
+
+// A rule to place an application based on the secondary groups of the submitting user.
+// The secondary groups are all groups of the user except the first one, which is the primary group.
+// The groups are checked in order and the first group that has an existing queue is used. If none of the queues
+// exist and the rule is allowed to create the queue the first secondary group is used.
+type secondaryGroupRule struct {
+    basicRule
+}
+
+func (sgr *secondaryGroupRule) getName() string {
+    return "secondarygroup"
+}
+
+func (sgr *secondaryGroupRule) noMatchReason(app *cache.ApplicationInfo, info *cache.PartitionInfo) string {
+    if len(app.GetUser().Groups) < 2 {
+        return "user does not have secondary groups"
+    }
+    return sgr.basicRule.noMatchReason(app, info)
+}
+
+func (sgr *secondaryGroupRule) initialise(conf configs.PlacementRule) error {
+    return sgr.initialiseBasic(conf)
+}
+
+func (sgr *secondaryGroupRule) placeApplication(app *cache.ApplicationInfo, info *cache.PartitionInfo) (string, error) {
+    // a user without secondary groups: skip all other processing
+    user := app.GetUser()
+    if len(user.Groups) < 2 {
+        return "", nil
+    }
+    // before anything run the filter
+    if !sgr.filter.allowUser(user) {
+        log.Logger().Debug("Secondary group rule filtered",
+            zap.String("application", app.ApplicationId),
+            zap.Any("user", user))
+        return "", nil
+    }
+    parentName, err := sgr.placeParent(app, info)
+    if err != nil || parentName == "" {
+        return "", err
+    }
+    var queueName string
+    for _, group := range user.Groups[1:] {
+        if group == "" {
+            continue
+        }
+        candidate := parentName + cache.DOT + replaceDot(group)
+        if info.GetQueue(candidate) != nil {
+            queueName = candidate
+            break
+        }
+        // remember the first secondary group in case we can create the queue
+        if queueName == "" && sgr.create {
+            queueName = candidate
+        }
+    }
+    if queueName == "" {
+        return "", nil
+    }
+    log.Logger().Info("Secondary group rule application placed",
+        zap.String("application", app.ApplicationId),
+        zap.String("queue", queueName))
+    return queueName, nil
+}
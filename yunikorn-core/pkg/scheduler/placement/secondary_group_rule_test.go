@@ -0,0 +1,111 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/security"
+    "testing"
+)
+
+func TestSecondaryGroupRulePlace(t *testing.T) {
+    // Create the structure for the test
+    data := `
+partitions:
+  - name: default
+    queues:
+      - name: primary
+      - name: second
+      - name: third
+`
+    partInfo, err := CreatePartitionInfo([]byte(data))
+    if err != nil {
+        t.Fatalf("Partition create failed with error: %v", err)
+    }
+    tags := make(map[string]string, 0)
+    conf := configs.PlacementRule{
+        Name: "secondaryGroup",
+    }
+    rule, err := newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("secondary group rule create failed, err %v", err)
+    }
+
+    // user with only a primary group does not match
+    user := security.UserGroup{
+        User: "testuser",
+        Groups: []string{"primary"},
+    }
+    appInfo := cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+    queue, err := rule.placeApplication(appInfo, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("secondary group rule placed app for user without secondary groups '%s', err %v", queue, err)
+    }
+
+    // first secondary group with an existing queue is used
+    user = security.UserGroup{
+        User: "testuser",
+        Groups: []string{"primary", "unknown", "third", "second"},
+    }
+    appInfo = cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "root.third" || err != nil {
+        t.Errorf("secondary group rule failed to place queue in correct queue '%s', err %v", queue, err)
+    }
+
+    // no existing queue: no match without create, first secondary group with create
+    user = security.UserGroup{
+        User: "testuser",
+        Groups: []string{"primary", "unknown", "other"},
+    }
+    appInfo = cache.NewApplicationInfo("app1", "default", "ignored", user, tags)
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("secondary group rule placed in to be created queue with create false '%s', err %v", queue, err)
+    }
+    conf = configs.PlacementRule{
+        Name: "secondarygroup",
+        Create: true,
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("secondary group rule create failed, err %v", err)
+    }
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "root.unknown" || err != nil {
+        t.Errorf("secondary group rule failed to place in to be created queue '%s', err %v", queue, err)
+    }
+
+    // filtered group does not match
+    conf = configs.PlacementRule{
+        Name: "secondarygroup",
+        Create: true,
+        Filter: configs.Filter{
+            Type: "deny",
+            Groups: []string{"primary"},
+        },
+    }
+    rule, err = newRule(conf)
+    if err != nil || rule == nil {
+        t.Fatalf("secondary group rule create failed with filter, err %v", err)
+    }
+    queue, err = rule.placeApplication(appInfo, partInfo)
+    if queue != "" || err != nil {
+        t.Errorf("secondary group rule placed filtered user '%s', err %v", queue, err)
+    }
+}
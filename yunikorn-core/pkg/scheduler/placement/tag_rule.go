@@ -38,6 +38,13 @@ func (tr *tagRule) getName() string {
     return "tag"
 }
 
+func (tr *tagRule) noMatchReason(app *cache.ApplicationInfo, info *cache.PartitionInfo) string {
+    if app.GetTag(tr.tagName) == "" {
+        return fmt.Sprintf("application does not have the tag %s set", tr.tagName)
+    }
+    return tr.basicRule.noMatchReason(app, info)
+}
+
 func (tr *tagRule) initialise(conf configs.PlacementRule) error {
     tr.tagName = normalise(conf.Value)
     if tr.tagName == "" {
@@ -0,0 +1,107 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "sort"
+    "time"
+)
+
+// An allocation that can be preempted together with the queue it runs in
+type preemptionVictim struct {
+    alloc *cache.AllocationInfo
+    queue *preemptionQueueContext
+}
+
+// Get the allocations on the node that can be preempted, in the order they should be preempted.
+// Allocations are skipped when their queue does not allow preemption, when they have not been
+// running for the minimum runtime configured for the partition or when they are preempted already.
+func getPreemptionVictims(preemptionPartitionCtx *preemptionPartitionContext, node *SchedulingNode) []*preemptionVictim {
+    victims := make([]*preemptionVictim, 0)
+    now := time.Now()
+    // Fixme: this operation has too many copies, should avoid for better perf
+    for _, alloc := range node.NodeInfo.GetAllAllocations() {
+        preemptQueue := preemptionPartitionCtx.leafQueues[alloc.AllocationProto.QueueName]
+        if preemptQueue == nil || !preemptQueue.schedulingQueue.Preemptable {
+            continue
+        }
+        if !isPreemptionCandidate(preemptionPartitionCtx, alloc, now) {
+            continue
+        }
+        victims = append(victims, &preemptionVictim{
+            alloc: alloc,
+            queue: preemptQueue,
+        })
+    }
+    sortPreemptionVictims(preemptionPartitionCtx, victims)
+    return victims
+}
+
+// Can the allocation be preempted based on the partition settings: the allocation must have been running for
+// the minimum runtime and must not be preempted already.
+func isPreemptionCandidate(preemptionPartitionCtx *preemptionPartitionContext, alloc *cache.AllocationInfo, now time.Time) bool {
+    if now.Sub(alloc.CreateTime) < preemptionPartitionCtx.minRuntime {
+        return false
+    }
+    // already preempted in this cycle or waiting for the RM to release it
+    uuid := alloc.AllocationProto.Uuid
+    if preemptionPartitionCtx.selected[uuid] ||
+        (preemptionPartitionCtx.pending != nil && preemptionPartitionCtx.pending.isVictim(uuid)) {
+        return false
+    }
+    return true
+}
+
+// Sort the victims based on the victim order of the partition, the youngest allocation is preempted first
+// if no order is set.
+func sortPreemptionVictims(preemptionPartitionCtx *preemptionPartitionContext, victims []*preemptionVictim) {
+    youngestFirst := func(i, j int) bool {
+        return victims[i].alloc.CreateTime.After(victims[j].alloc.CreateTime)
+    }
+    switch preemptionPartitionCtx.victimOrder {
+    case configs.VictimOrderLowestPriority:
+        sortPreemptionVictimsByPriority(victims)
+    case configs.VictimOrderOvershoot:
+        // allocations that fit in the preemptable resources of their queue go first, the less of the
+        // allocation is left over after preemption the better
+        sort.SliceStable(victims, func(i, j int) bool {
+            left := resources.SubEliminateNegative(victims[i].alloc.AllocatedResource, victims[i].queue.resources.preemptable)
+            right := resources.SubEliminateNegative(victims[j].alloc.AllocatedResource, victims[j].queue.resources.preemptable)
+            if comp := resources.Comp(preemptionPartitionCtx.partitionTotalResource, left, right); comp != 0 {
+                return comp < 0
+            }
+            return youngestFirst(i, j)
+        })
+    default:
+        sort.SliceStable(victims, youngestFirst)
+    }
+}
+
+// Sort the victims with the lowest priority first, the youngest allocation first within the same priority.
+func sortPreemptionVictimsByPriority(victims []*preemptionVictim) {
+    sort.SliceStable(victims, func(i, j int) bool {
+        left := victims[i].alloc.AllocationProto.GetPriority().GetPriorityValue()
+        right := victims[j].alloc.AllocationProto.GetPriority().GetPriorityValue()
+        if left != right {
+            return left < right
+        }
+        return victims[i].alloc.CreateTime.After(victims[j].alloc.CreateTime)
+    })
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "gotest.tools/assert"
+    "testing"
+    "time"
+)
+
+func newTestVictim(uuid string, memory int, priority int32, age time.Duration, queue *preemptionQueueContext) *preemptionVictim {
+    return &preemptionVictim{
+        alloc: &cache.AllocationInfo{
+            AllocationProto: &si.Allocation{
+                Uuid:     uuid,
+                Priority: &si.Priority{Priority: &si.Priority_PriorityValue{PriorityValue: priority}},
+            },
+            AllocatedResource: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: resources.Quantity(memory)}),
+            CreateTime:        time.Now().Add(-age),
+        },
+        queue: queue,
+    }
+}
+
+func getVictimOrder(victims []*preemptionVictim) []string {
+    order := make([]string, len(victims))
+    for i, victim := range victims {
+        order[i] = victim.alloc.AllocationProto.Uuid
+    }
+    return order
+}
+
+func TestSortPreemptionVictims(t *testing.T) {
+    queue := &preemptionQueueContext{
+        resources: &queuePreemptCalcResource{
+            preemptable: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 20}),
+        },
+    }
+    victims := []*preemptionVictim{
+        newTestVictim("old-low", 10, 1, time.Hour, queue),
+        newTestVictim("young-high", 50, 10, time.Second, queue),
+        newTestVictim("mid-low", 20, 1, time.Minute, queue),
+    }
+    ctx := &preemptionPartitionContext{
+        partitionTotalResource: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 100}),
+    }
+
+    // default is youngest first
+    sortPreemptionVictims(ctx, victims)
+    assert.DeepEqual(t, getVictimOrder(victims), []string{"young-high", "mid-low", "old-low"})
+
+    // lowest priority first, youngest first within the same priority
+    ctx.victimOrder = configs.VictimOrderLowestPriority
+    sortPreemptionVictims(ctx, victims)
+    assert.DeepEqual(t, getVictimOrder(victims), []string{"mid-low", "old-low", "young-high"})
+
+    // allocations that fit in the preemptable resources first, youngest first when equal
+    ctx.victimOrder = configs.VictimOrderOvershoot
+    sortPreemptionVictims(ctx, victims)
+    assert.DeepEqual(t, getVictimOrder(victims), []string{"mid-low", "old-low", "young-high"})
+}
+
+func TestCanPreempt(t *testing.T) {
+    ctx := &preemptionPartitionContext{
+        preempted: resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 10}),
+    }
+    toPreempt := resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 10})
+    // no maximum set
+    assert.Assert(t, ctx.canPreempt(toPreempt), "preemption should be unlimited without a maximum")
+
+    ctx.maxPreempted = resources.NewResourceFromMap(map[string]resources.Quantity{resources.MEMORY: 20})
+    assert.Assert(t, ctx.canPreempt(toPreempt), "preemption up to the maximum should be allowed")
+    resources.AddTo(ctx.preempted, toPreempt)
+    assert.Assert(t, !ctx.canPreempt(toPreempt), "preemption over the maximum should not be allowed")
+}
+
+func TestPartitionPreemptionPolicies(t *testing.T) {
+    policies := getPartitionPreemptionPolicies(configs.PartitionPreemptionConfig{Enabled: true})
+    assert.Assert(t, policies[configs.PreemptionPolicyDRF], "DRF policy should be the default")
+
+    policies = getPartitionPreemptionPolicies(configs.PartitionPreemptionConfig{Enabled: true, Policies: []string{"DRF"}})
+    assert.Equal(t, len(policies), 1)
+    assert.Assert(t, policies[configs.PreemptionPolicyDRF], "policy names should be case insensitive")
+}
@@ -17,7 +17,18 @@ limitations under the License.
 package scheduler
 
 import (
+    "fmt"
+    "github.com/cloudera/yunikorn-core/pkg/common"
+    "github.com/cloudera/yunikorn-core/pkg/common/commonevents"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/events"
+    "github.com/cloudera/yunikorn-core/pkg/log"
+    "github.com/cloudera/yunikorn-core/pkg/rmproxy/rmevent"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "go.uber.org/zap"
+    "strings"
+    "time"
 )
 
 // Below structures are intended to be used under single go routine, thus no
@@ -31,6 +42,30 @@ type preemptionPartitionContext struct {
     partitionTotalResource *resources.Resource
     root                   *preemptionQueueContext
     leafQueues             map[string]*preemptionQueueContext
+
+    // preemption settings for the partition
+    policies     map[string]bool     // names of the policies that run for this partition
+    victimOrder  string              // order in which victims on a node are selected
+    minRuntime   time.Duration       // minimum runtime of an allocation before it can be preempted
+    maxPreempted *resources.Resource // maximum resources preempted in one cycle, nil means unlimited
+    preempted    *resources.Resource // resources preempted in the current cycle
+    gracePeriod  time.Duration       // time the RM gives the victims to terminate
+    timeout      time.Duration       // time after which a preemption is cancelled if the victims are not released
+    pending      *pendingPreemptions // preemptions that wait for their victims to be released
+    selected     map[string]bool     // victims selected in the current cycle by uuid
+}
+
+// Does the policy run for this partition?
+func (m *preemptionPartitionContext) hasPolicy(name string) bool {
+    return m.policies[name]
+}
+
+// Can the resource be preempted without going over the maximum for this cycle?
+func (m *preemptionPartitionContext) canPreempt(toPreempt *resources.Resource) bool {
+    if m.maxPreempted == nil {
+        return true
+    }
+    return resources.FitIn(m.maxPreempted, resources.Add(m.preempted, toPreempt))
 }
 
 // Parameters used to do allocation within preemption loop.
@@ -77,18 +112,45 @@ func newQueuePreemptCalcResource() *queuePreemptCalcResource {
     }
 }
 
+// A preemption policy decides which allocations are preempted for which asks.
+// A policy only acts on the partitions in the preemption context that have the policy configured.
 type PreemptionPolicy interface {
+    // Name of the policy as used in the partition preemption configuration
+    Name() string
+    // Run one preemption step for the partitions that have this policy configured
     DoPreemption(scheduler *Scheduler)
 }
 
-func getPreemptionPolicies() []PreemptionPolicy {
-    preemptionPolicies := make([]PreemptionPolicy, 0)
-    preemptionPolicies = append(preemptionPolicies, &DRFPreemptionPolicy{})
-    return preemptionPolicies
+const (
+    // default time the RM gives preempted allocations to terminate
+    DefaultPreemptionGracePeriod = 30 * time.Second
+    // default time after which a preemption is cancelled if the preempted allocations are not released
+    DefaultPreemptionTimeout = 2 * time.Minute
+)
+
+// All known preemption policies, in the order they are run when configured
+var preemptionPolicies = []PreemptionPolicy{
+    &DRFPreemptionPolicy{},
+    &PriorityPreemptionPolicy{},
+}
+
+// Get the names of the policies configured for a partition, the DRF policy is used if none are configured
+func getPartitionPreemptionPolicies(config configs.PartitionPreemptionConfig) map[string]bool {
+    policies := make(map[string]bool)
+    for _, name := range config.Policies {
+        policies[strings.ToLower(name)] = true
+    }
+    if len(policies) == 0 {
+        policies[configs.PreemptionPolicyDRF] = true
+    }
+    return policies
 }
 
 // Visible by tests
 func (m *Scheduler) SingleStepPreemption() {
+    // Finish the pending preemptions of which the victims are gone
+    m.processRemovedVictims()
+
     // Skip if no preemption needed.
     if !m.clusterSchedulingContext.NeedPreemption() {
         return
@@ -96,13 +158,19 @@ func (m *Scheduler) SingleStepPreemption() {
 
     m.resetPreemptionContext()
 
-    // Do preemption for each policies
-    for _, policy := range getPreemptionPolicies() {
-        policy.DoPreemption(m)
+    // Do preemption for each policy that is used by at least one partition
+    for _, policy := range preemptionPolicies {
+        for _, partitionCtx := range m.preemptionContext.partitions {
+            if partitionCtx.hasPolicy(policy.Name()) {
+                policy.DoPreemption(m)
+                break
+            }
+        }
     }
 }
 
 // Copy & Reset PreemptionContext
+// Only partitions that have preemption enabled are added to the context.
 func (m *Scheduler) resetPreemptionContext() {
     // Create a new preemption context
     m.preemptionContext = &preemptionContext{
@@ -111,8 +179,39 @@ func (m *Scheduler) resetPreemptionContext() {
 
     // Copy from scheduler
     for partition, partitionContext := range m.clusterSchedulingContext.getPartitionMapClone() {
+        if !partitionContext.partition.NeedPreemption() {
+            continue
+        }
+        config := partitionContext.partition.GetPreemptionConfig()
         preemptionPartitionCtx := &preemptionPartitionContext{
-            leafQueues: make(map[string]*preemptionQueueContext),
+            leafQueues:  make(map[string]*preemptionQueueContext),
+            policies:    getPartitionPreemptionPolicies(config),
+            victimOrder: strings.ToLower(config.VictimOrder),
+            minRuntime:  config.MinRuntime,
+            preempted:   resources.NewResource(),
+            gracePeriod: DefaultPreemptionGracePeriod,
+            timeout:     DefaultPreemptionTimeout,
+            pending:     m.pendingPreemptions,
+            selected:    make(map[string]bool),
+        }
+        if config.GracePeriod > 0 {
+            preemptionPartitionCtx.gracePeriod = config.GracePeriod
+        }
+        if config.Timeout > 0 {
+            preemptionPartitionCtx.timeout = config.Timeout
+        }
+        if preemptionPartitionCtx.timeout < preemptionPartitionCtx.gracePeriod {
+            preemptionPartitionCtx.timeout = preemptionPartitionCtx.gracePeriod
+        }
+        if len(config.MaxPerCycle) != 0 {
+            maxPreempted, err := resources.NewResourceFromConf(config.MaxPerCycle)
+            if err != nil {
+                log.Logger().Warn("ignoring maximum preempted resources per cycle",
+                    zap.String("partitionName", partition),
+                    zap.Error(err))
+            } else {
+                preemptionPartitionCtx.maxPreempted = maxPreempted
+            }
         }
         m.preemptionContext.partitions[partition] = preemptionPartitionCtx
         preemptionPartitionCtx.root = m.recursiveInitPreemptionQueueContext(preemptionPartitionCtx, nil, partitionContext.Root)
@@ -139,3 +238,114 @@ func (m *Scheduler) recursiveInitPreemptionQueueContext(preemptionPartitionCtx *
 
     return preemptionQueue
 }
+
+// Start the preemption for the allocation: ask the RM to release the victims and wait for the releases
+// before the allocation is proposed. The preemption is cancelled if the victims are not released in time.
+func (m *Scheduler) startPreemption(alloc *SchedulingAllocation) {
+    gracePeriod := DefaultPreemptionGracePeriod
+    timeout := DefaultPreemptionTimeout
+    if preemptionPartitionCtx := m.preemptionContext.partitions[alloc.PartitionName]; preemptionPartitionCtx != nil {
+        gracePeriod = preemptionPartitionCtx.gracePeriod
+        timeout = preemptionPartitionCtx.timeout
+    }
+    m.pendingPreemptions.add(alloc, time.Now().Add(timeout))
+
+    released := make([]*si.AllocationReleaseResponse, 0)
+    for _, release := range alloc.Releases {
+        // the event is for the victim: the RM publishes it on the object that is preempted
+        events.Record(&events.Event{
+            ObjectType:    events.ObjectAllocation,
+            ObjectId:      release.Uuid,
+            Partition:     release.PartitionName,
+            ApplicationId: release.ApplicationId,
+            QueueName:     m.getVictimQueueName(release),
+            NodeId:        alloc.NodeId,
+            Reason:        events.AllocationPreempted,
+            Message:       fmt.Sprintf("allocation %s preempted for ask %s of application %s", release.Uuid,
+                alloc.SchedulingAsk.AskProto.AllocationKey, alloc.SchedulingAsk.ApplicationId),
+        })
+        released = append(released, &si.AllocationReleaseResponse{
+            Uuid:               release.Uuid,
+            TerminationType:    release.ReleaseType,
+            Message:            release.Message,
+            GracePeriodSeconds: int64(gracePeriod / time.Second),
+        })
+    }
+    log.Logger().Info("preempting allocations",
+        zap.String("allocationKey", alloc.SchedulingAsk.AskProto.AllocationKey),
+        zap.String("nodeId", alloc.NodeId),
+        zap.Int("numOfVictims", len(released)),
+        zap.String("gracePeriod", gracePeriod.String()))
+    m.eventHandlers.RMProxyEventHandler.HandleEvent(&rmevent.RMReleaseAllocationEvent{
+        ReleasedAllocations: released,
+        RMId:                common.GetRMIdFromPartitionName(alloc.PartitionName),
+    })
+}
+
+// Process allocations released by the RM. When all victims of a preemption are released the allocation for
+// the preemptor is proposed. The releases must have been passed on to the cache before this is called: the
+// cache processes the releases before the proposal.
+func (m *Scheduler) processPreemptedAllocationReleases(toReleases []*si.AllocationReleaseRequest) {
+    for _, toRelease := range toReleases {
+        alloc := m.pendingPreemptions.release(toRelease.Uuid)
+        if alloc == nil {
+            continue
+        }
+        log.Logger().Info("preempted allocations released, proposing allocation",
+            zap.String("allocationKey", alloc.SchedulingAsk.AskProto.AllocationKey),
+            zap.String("nodeId", alloc.NodeId))
+        proposal := newSingleAllocationProposal(alloc)
+        // the victims are released already
+        proposal.ReleaseProposals = nil
+        m.eventHandlers.CacheEventHandler.HandleEvent(proposal)
+    }
+}
+
+// Get the queue of the victim from the cache, empty if the victim is no longer known.
+func (m *Scheduler) getVictimQueueName(release *commonevents.ReleaseAllocation) string {
+    if partition := m.clusterInfo.GetPartition(release.PartitionName); partition != nil {
+        if victim := partition.GetAllocation(release.Uuid); victim != nil {
+            return victim.AllocationProto.QueueName
+        }
+    }
+    return ""
+}
+
+// Process victims of pending preemptions that are removed from the cache without a release by the RM, for instance
+// when their application or node is removed. The victims are released: the preemption does not wait for the timeout.
+func (m *Scheduler) processRemovedVictims() {
+    removed := make([]*si.AllocationReleaseRequest, 0)
+    for uuid, partitionName := range m.pendingPreemptions.getVictims() {
+        partition := m.clusterInfo.GetPartition(partitionName)
+        if partition != nil && partition.GetAllocation(uuid) != nil {
+            continue
+        }
+        log.Logger().Debug("victim of pending preemption removed",
+            zap.String("uuid", uuid),
+            zap.String("partitionName", partitionName))
+        removed = append(removed, &si.AllocationReleaseRequest{
+            Uuid:          uuid,
+            PartitionName: partitionName,
+        })
+    }
+    if len(removed) != 0 {
+        m.processPreemptedAllocationReleases(removed)
+    }
+}
+
+// Cancel the preemptions that have not finished in time, the asks of the preemptors are pending again.
+func (m *Scheduler) expirePreemptions(now time.Time) {
+    expired := m.pendingPreemptions.expire(now)
+    for _, alloc := range expired {
+        log.Logger().Info("preempted allocations not released in time, cancelling preemption",
+            zap.String("allocationKey", alloc.SchedulingAsk.AskProto.AllocationKey),
+            zap.String("nodeId", alloc.NodeId))
+        if err := m.updateSchedulingRequestPendingAskByDelta(newSingleAllocationProposal(alloc).AllocationProposals[0], 1); err != nil {
+            log.Logger().Debug("failed to increase pending ask",
+                zap.Error(err))
+        }
+    }
+    if len(expired) != 0 {
+        m.triggerSchedule()
+    }
+}
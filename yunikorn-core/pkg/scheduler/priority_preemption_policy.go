@@ -0,0 +1,185 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "sort"
+    "time"
+)
+
+// Preemption policy based on the priority of asks and allocations within one leaf queue.
+// A pending ask can preempt allocations with a lower priority from the same queue if the queue has
+// intra-queue preemption turned on. The disruption budget of the queue limits the number of allocations
+// of one application that are preempted at the same time.
+type PriorityPreemptionPolicy struct {
+}
+
+func (m *PriorityPreemptionPolicy) Name() string {
+    return configs.PreemptionPolicyPriority
+}
+
+func (m *PriorityPreemptionPolicy) DoPreemption(scheduler *Scheduler) {
+    for partition, preemptionPartitionCtx := range scheduler.preemptionContext.partitions {
+        if !preemptionPartitionCtx.hasPolicy(configs.PreemptionPolicyPriority) {
+            continue
+        }
+        nodes := scheduler.getSchedulingNodes(partition)
+        if len(nodes) == 0 {
+            continue
+        }
+        // number of preempted allocations by application, pending preemptions count against the budget
+        disrupted := scheduler.pendingPreemptions.getVictimsByApplication()
+        // change of the queue usage in this cycle by queue path
+        usageDelta := make(map[string]*resources.Resource)
+        for _, preemptQueue := range preemptionPartitionCtx.leafQueues {
+            queue := preemptQueue.schedulingQueue
+            if !queue.IntraQueuePreemption || !queue.Preemptable || queue.isStopped() {
+                continue
+            }
+            for _, candidate := range getPendingAsksByPriority(queue) {
+                // proposing an allocation decreases the pending repeat of the ask
+                for pending := candidate.PendingRepeatAsk; pending > 0; pending-- {
+                    alloc := intraQueuePreemptionAllocate(preemptionPartitionCtx, preemptQueue, nodes, candidate, disrupted, usageDelta)
+                    if alloc == nil || !scheduler.proposeAllocation(alloc) {
+                        break
+                    }
+                }
+            }
+        }
+    }
+}
+
+// Get the asks with a pending allocation from all applications in the leaf queue, highest priority first.
+func getPendingAsksByPriority(queue *SchedulingQueue) []*SchedulingAllocationAsk {
+    asks := make([]*SchedulingAllocationAsk, 0)
+    for _, app := range sortApplicationsFromQueue(queue) {
+        app.Requests.lock.RLock()
+        for _, ask := range app.Requests.requests {
+            if ask.PendingRepeatAsk > 0 {
+                asks = append(asks, ask)
+            }
+        }
+        app.Requests.lock.RUnlock()
+    }
+    sort.SliceStable(asks, func(i, j int) bool {
+        return asks[i].AskProto.GetPriority().GetPriorityValue() > asks[j].AskProto.GetPriority().GetPriorityValue()
+    })
+    return asks
+}
+
+// Get the resources that must be freed in the queue hierarchy before the ask fits in the maximum of the queue
+// and all its parents. Allocations in the leaf queue count against the maximum of all parents.
+func getQueueHeadroomShortage(queue *SchedulingQueue, ask *resources.Resource, usageDelta map[string]*resources.Resource) *resources.Resource {
+    shortage := resources.NewResource()
+    for cur := queue; cur != nil; cur = cur.parent {
+        maxResource := cur.CachedQueueInfo.MaxResource
+        if maxResource == nil {
+            continue
+        }
+        used := resources.Add(cur.CachedQueueInfo.GetAllocatedResource(), usageDelta[cur.Name])
+        headroom := resources.Sub(maxResource, used)
+        shortage = resources.ComponentWiseMax(shortage, resources.SubEliminateNegative(ask, headroom))
+    }
+    return shortage
+}
+
+// Get the allocations on the node from the queue that have a lower priority than the ask, lowest priority first.
+func getPriorityPreemptionVictims(preemptionPartitionCtx *preemptionPartitionContext, preemptQueue *preemptionQueueContext,
+    node *SchedulingNode, priority int32) []*preemptionVictim {
+    victims := make([]*preemptionVictim, 0)
+    now := time.Now()
+    for _, alloc := range node.NodeInfo.GetAllAllocations() {
+        if alloc.AllocationProto.QueueName != preemptQueue.queuePath ||
+            alloc.AllocationProto.GetPriority().GetPriorityValue() >= priority {
+            continue
+        }
+        if !isPreemptionCandidate(preemptionPartitionCtx, alloc, now) {
+            continue
+        }
+        victims = append(victims, &preemptionVictim{
+            alloc: alloc,
+            queue: preemptQueue,
+        })
+    }
+    sortPreemptionVictimsByPriority(victims)
+    return victims
+}
+
+// Is preempting one more allocation of the application within the disruption budget of the queue?
+func withinDisruptionBudget(queue *SchedulingQueue, disrupted int) bool {
+    return queue.DisruptionBudget < 0 || disrupted < queue.DisruptionBudget
+}
+
+// Find a node on which the candidate can be allocated by preempting lower priority allocations from the same queue.
+// Returns nil if the ask does not need preemption or no node has enough allocations that can be preempted.
+func intraQueuePreemptionAllocate(preemptionPartitionCtx *preemptionPartitionContext, preemptQueue *preemptionQueueContext, nodes []*SchedulingNode,
+    candidate *SchedulingAllocationAsk, disrupted map[string]int, usageDelta map[string]*resources.Resource) *SchedulingAllocation {
+    priority := candidate.AskProto.GetPriority().GetPriorityValue()
+    queueShortage := getQueueHeadroomShortage(preemptQueue.schedulingQueue, candidate.AllocatedResource, usageDelta)
+
+    for _, node := range nodes {
+        // To preempt resource = (allocating + candidate.asked) - (preempting + available)
+        resourceToPreempt := resources.Add(node.AllocatingResource, candidate.AllocatedResource)
+        resources.SubFrom(resourceToPreempt, node.PreemptingResource)
+        resources.SubFrom(resourceToPreempt, node.CachedAvailableResource)
+        resourceToPreempt = resources.ComponentWiseMax(resourceToPreempt, queueShortage)
+        // the ask fits without preemption: leave it to the regular scheduling
+        if !resources.StrictlyGreaterThanZero(resourceToPreempt) {
+            return nil
+        }
+
+        toReleaseAllocations := make(map[string]*cache.AllocationInfo)
+        totalReleasedResource := resources.NewResource()
+        appDisrupted := make(map[string]int)
+        for _, victim := range getPriorityPreemptionVictims(preemptionPartitionCtx, preemptQueue, node, priority) {
+            alloc := victim.alloc
+            if !withinDisruptionBudget(preemptQueue.schedulingQueue, disrupted[alloc.ApplicationId]+appDisrupted[alloc.ApplicationId]) {
+                continue
+            }
+            // Stay within the maximum that can be preempted in one cycle
+            if !preemptionPartitionCtx.canPreempt(resources.Add(totalReleasedResource, alloc.AllocatedResource)) {
+                continue
+            }
+            toReleaseAllocations[alloc.AllocationProto.Uuid] = alloc
+            resources.AddTo(totalReleasedResource, alloc.AllocatedResource)
+            appDisrupted[alloc.ApplicationId]++
+
+            // Check if we preempted enough resources.
+            if resources.StrictlyGreaterThanOrEquals(totalReleasedResource, resourceToPreempt) {
+                for appId, count := range appDisrupted {
+                    disrupted[appId] += count
+                }
+                // the queue and its parents use the ask instead of the victims
+                delta := resources.Sub(candidate.AllocatedResource, totalReleasedResource)
+                for cur := preemptQueue.schedulingQueue; cur != nil; cur = cur.parent {
+                    usageDelta[cur.Name] = resources.Add(usageDelta[cur.Name], delta)
+                }
+                return createPreemptionAndAllocationProposal(preemptionPartitionCtx, node, candidate, []*singleNodePreemptResult{
+                    {
+                        node:                  node,
+                        toReleaseAllocations:  toReleaseAllocations,
+                        totalReleasedResource: totalReleasedResource,
+                    },
+                })
+            }
+        }
+    }
+    return nil
+}
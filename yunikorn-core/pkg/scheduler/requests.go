@@ -141,3 +141,13 @@ func (m *SchedulingRequests) GetSchedulingAllocationAsk(allocationKey string) *S
 
     return m.requests[allocationKey]
 }
+
+// Update the queue of all asks, used when the application is moved to a different queue.
+func (m *SchedulingRequests) updateQueueName(queueName string) {
+    m.lock.Lock()
+    defer m.lock.Unlock()
+
+    for _, ask := range m.requests {
+        ask.QueueName = queueName
+    }
+}
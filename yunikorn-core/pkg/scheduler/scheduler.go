@@ -23,6 +23,7 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/common"
     "github.com/cloudera/yunikorn-core/pkg/common/commonevents"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/events"
     "github.com/cloudera/yunikorn-core/pkg/handler"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "github.com/cloudera/yunikorn-core/pkg/metrics"
@@ -59,6 +60,44 @@ type Scheduler struct {
     waitTillNextTry map[string]uint64
 
     step uint64 // TODO document this, see ask_finder@findMayAllocationFromApplication
+
+    // Scheduling loop: the loop is triggered via the channel and limited by the loop config
+    scheduleTrigger chan struct{}
+    loopConfig      ScheduleLoopConfig
+
+    // Preemptions waiting for the RM to release the preempted allocations
+    pendingPreemptions *pendingPreemptions
+
+    // Resource types of the fair shares recorded in the metrics by queue path by partition.
+    // It is only accessed from the scheduling loop, no lock needed.
+    recordedFairShares map[string]map[string]map[string]bool
+}
+
+const (
+    // number of allocations proposed in one scheduling cycle
+    scheduleBatchSize = 16
+    // default minimum time between the start of two scheduling cycles
+    DefaultMinScheduleInterval = 10 * time.Millisecond
+    // default maximum time the scheduling loop waits for a trigger before running a cycle
+    DefaultMaxIdleTick = time.Second
+)
+
+// Settings for the event driven scheduling loop.
+type ScheduleLoopConfig struct {
+    // Minimum time between the start of two scheduling cycles. Triggers that come in faster are
+    // merged into one cycle, this limits the CPU used while the cluster is busy.
+    MinInterval time.Duration
+    // Maximum time the loop stays idle without a trigger. After this a cycle is run regardless to
+    // pick up asks that could not be allocated before, for example asks that are waiting for a retry.
+    MaxIdleTick time.Duration
+}
+
+// Get the default scheduling loop settings.
+func DefaultScheduleLoopConfig() ScheduleLoopConfig {
+    return ScheduleLoopConfig{
+        MinInterval: DefaultMinScheduleInterval,
+        MaxIdleTick: DefaultMaxIdleTick,
+    }
 }
 
 func NewScheduler(clusterInfo *cache.ClusterInfo, metrics metrics.CoreSchedulerMetrics) *Scheduler {
@@ -68,10 +107,34 @@ func NewScheduler(clusterInfo *cache.ClusterInfo, metrics metrics.CoreSchedulerM
     m.clusterSchedulingContext = NewClusterSchedulingContext()
     m.pendingSchedulerEvents = make(chan interface{}, 1024*1024)
     m.metrics = metrics
+    m.scheduleTrigger = make(chan struct{}, 1)
+    m.loopConfig = DefaultScheduleLoopConfig()
+    m.pendingPreemptions = newPendingPreemptions()
+    m.recordedFairShares = make(map[string]map[string]map[string]bool)
 
     return m
 }
 
+// Set the scheduling loop settings, must be called before the service is started.
+// Settings that are not set fall back to the defaults.
+func (m *Scheduler) SetScheduleLoopConfig(config ScheduleLoopConfig) {
+    if config.MinInterval <= 0 {
+        config.MinInterval = DefaultMinScheduleInterval
+    }
+    if config.MaxIdleTick <= 0 {
+        config.MaxIdleTick = DefaultMaxIdleTick
+    }
+    if config.MaxIdleTick < config.MinInterval {
+        config.MaxIdleTick = config.MinInterval
+    }
+    m.loopConfig = config
+}
+
+// Get the scheduling loop settings.
+func (m *Scheduler) GetScheduleLoopConfig() ScheduleLoopConfig {
+    return m.loopConfig
+}
+
 // Start service
 func (m *Scheduler) StartService(handlers handler.EventHandlers, manualSchedule bool) {
     m.eventHandlers = handlers
@@ -107,19 +170,53 @@ func newSingleAllocationProposal(alloc *SchedulingAllocation) *cacheevent.Alloca
     }
 }
 
+// Trigger a scheduling cycle. The call never blocks: if a trigger is already pending the
+// triggers are merged and only one cycle is run.
+func (m *Scheduler) triggerSchedule() {
+    select {
+    case m.scheduleTrigger <- struct{}{}:
+    default:
+    }
+}
+
 // Internal start scheduling service
+// The loop waits for a trigger, or the max idle tick, before running a scheduling cycle.
+// Cycles are at least the min interval apart. A cycle that proposed a full batch of allocations
+// triggers the next cycle directly as there is probably more to allocate.
 func (m *Scheduler) internalSchedule() {
+    idleTimer := time.NewTimer(m.loopConfig.MaxIdleTick)
+    defer idleTimer.Stop()
     for {
-        m.singleStepSchedule(16, &preemptionParameters{
+        select {
+        case <-m.scheduleTrigger:
+            if !idleTimer.Stop() {
+                <-idleTimer.C
+            }
+        case <-idleTimer.C:
+        }
+
+        cycleStart := time.Now()
+        batchSize, allocated := m.singleStepSchedule(scheduleBatchSize, &preemptionParameters{
             crossQueuePreemption: false,
             blacklistedRequest: make(map[string]bool),
         })
+        m.metrics.ObserveSchedulingCycle(cycleStart)
+        m.metrics.ObserveSchedulingBatchSize(batchSize)
+        if allocated >= scheduleBatchSize {
+            m.triggerSchedule()
+        }
+
+        if wait := m.loopConfig.MinInterval - time.Since(cycleStart); wait > 0 {
+            time.Sleep(wait)
+        }
+        idleTimer.Reset(m.loopConfig.MaxIdleTick)
     }
 }
 
 // Internal start preemption service
 func (m *Scheduler) internalPreemption() {
     for {
+        m.expirePreemptions(time.Now())
         m.SingleStepPreemption()
         time.Sleep(1000 * time.Millisecond)
     }
@@ -141,6 +238,8 @@ func (m *Scheduler) updateSchedulingRequest(schedulingAsk *SchedulingAllocationA
     pendingDelta, err := schedulingApp.Requests.AddAllocationAsk(schedulingAsk)
     if err == nil && !resources.IsZero(pendingDelta) {
         schedulingApp.queue.IncPendingResource(pendingDelta)
+        schedulingApp.ApplicationInfo.UpdatePendingAsks(schedulingApp.hasPendingAsks())
+        m.triggerSchedule()
     }
     return err
 }
@@ -159,6 +258,8 @@ func (m *Scheduler) updateSchedulingRequestPendingAskByDelta(allocProposal *comm
     pendingDelta, err := schedulingApp.Requests.UpdateAllocationAskRepeat(allocProposal.AllocationKey, deltaPendingAsk)
     if err == nil && !resources.IsZero(pendingDelta) {
         schedulingApp.queue.IncPendingResource(pendingDelta)
+        // the state is updated by the cache when the allocation is confirmed or rejected
+        schedulingApp.ApplicationInfo.SetPendingAsks(schedulingApp.hasPendingAsks())
     }
     return err
 }
@@ -188,6 +289,68 @@ func (m *Scheduler) removeApplication(request *si.RemoveApplicationRequest) erro
     return nil
 }
 
+// Move an application to a different queue, invoked by the RM or the web service.
+// The move is recorded as an application event and the RM is notified of the result.
+func (m *Scheduler) moveApplication(request *si.MoveApplicationRequest) error {
+    m.lock.Lock()
+    defer m.lock.Unlock()
+
+    rmID := common.GetRMIdFromPartitionName(request.PartitionName)
+    err := m.clusterSchedulingContext.MoveSchedulingApplication(request.ApplicationId, request.PartitionName, request.QueueName)
+    if err != nil {
+        log.Logger().Info("failed to move app",
+            zap.String("appId", request.ApplicationId),
+            zap.String("partitionName", request.PartitionName),
+            zap.String("queueName", request.QueueName),
+            zap.Error(err))
+        m.eventHandlers.RMProxyEventHandler.HandleEvent(&rmevent.RMApplicationUpdateEvent{
+            RMId: rmID,
+            RejectedMoves: []*si.RejectedApplicationMove{{
+                ApplicationId: request.ApplicationId,
+                Reason:        err.Error(),
+            }},
+        })
+        return err
+    }
+
+    log.Logger().Info("app moved",
+        zap.String("appId", request.ApplicationId),
+        zap.String("partitionName", request.PartitionName),
+        zap.String("queueName", request.QueueName))
+    events.Record(&events.Event{
+        ObjectType:    events.ObjectApplication,
+        ObjectId:      request.ApplicationId,
+        Partition:     request.PartitionName,
+        ApplicationId: request.ApplicationId,
+        QueueName:     request.QueueName,
+        Reason:        events.ApplicationMoved,
+        Message:       fmt.Sprintf("moved to queue %s", request.QueueName),
+    })
+    m.eventHandlers.RMProxyEventHandler.HandleEvent(&rmevent.RMApplicationUpdateEvent{
+        RMId: rmID,
+        MovedApplications: []*si.MovedApplication{{
+            ApplicationId: request.ApplicationId,
+            QueueName:     request.QueueName,
+        }},
+    })
+    // the queue limits for the application changed
+    m.triggerSchedule()
+    return nil
+}
+
+func (m *Scheduler) processMoveApplicationEvent(event *schedulerevent.SchedulerMoveApplicationEvent) {
+    if err := m.moveApplication(event.Request); err != nil {
+        event.ResultChannel <- &commonevents.Result{
+            Succeeded: false,
+            Reason:    err.Error(),
+        }
+    } else {
+        event.ResultChannel <- &commonevents.Result{
+            Succeeded: true,
+        }
+    }
+}
+
 func enqueueAndCheckFull(queue chan interface{}, ev interface{}) {
     select {
     case queue <- ev:
@@ -217,12 +380,14 @@ func (m *Scheduler) processAllocationReleaseByAllocationKey(allocationAsksToRele
 
     // For all Requests
     for _, toRelease := range allocationAsksToRelease {
+        m.pendingPreemptions.removeAsk(toRelease.ApplicationId, toRelease.Allocationkey)
         schedulingApp := m.clusterSchedulingContext.GetSchedulingApplication(toRelease.ApplicationId, toRelease.PartitionName)
         if schedulingApp != nil {
             delta, _ := schedulingApp.Requests.RemoveAllocationAsk(toRelease.Allocationkey)
             if !resources.IsZero(delta) {
                 schedulingApp.queue.IncPendingResource(delta)
             }
+            schedulingApp.ApplicationInfo.UpdatePendingAsks(schedulingApp.hasPendingAsks())
 
             log.Logger().Info("release allocation",
                 zap.String("allocation", toRelease.Allocationkey),
@@ -296,6 +461,8 @@ func (m *Scheduler) processAllocationUpdateEvent(ev *schedulerevent.SchedulerAll
                     zap.Error(err))
             }
         }
+        // the asks are pending again: schedule them
+        m.triggerSchedule()
     }
 
     // When RM asks to remove some allocations, the event will be send to scheduler first, to release pending asks, etc.
@@ -304,6 +471,7 @@ func (m *Scheduler) processAllocationUpdateEvent(ev *schedulerevent.SchedulerAll
     if ev.ToReleases != nil {
         m.processAllocationReleaseByAllocationKey(ev.ToReleases.AllocationAsksToRelease)
         m.eventHandlers.CacheEventHandler.HandleEvent(cacheevent.NewReleaseAllocationEventFromProto(ev.ToReleases.AllocationsToRelease))
+        m.processPreemptedAllocationReleases(ev.ToReleases.AllocationsToRelease)
     }
 
     //添加，提交任务时前面都不会执行
@@ -362,12 +530,30 @@ func (m *Scheduler) processApplicationUpdateEvent(ev *schedulerevent.SchedulerAp
                     ApplicationId: app.ApplicationId,
                     Reason:        err.Error(),
                 })
+                events.Record(&events.Event{
+                    ObjectType:    events.ObjectApplication,
+                    ObjectId:      app.ApplicationId,
+                    Partition:     app.Partition,
+                    ApplicationId: app.ApplicationId,
+                    QueueName:     app.QueueName,
+                    Reason:        events.ApplicationRejected,
+                    Message:       err.Error(),
+                })
                 // app is rejected by the scheduler
                 _ = app.HandleApplicationEvent(cache.RejectApplication)
             } else {
                 acceptedApps = append(acceptedApps, &si.AcceptedApplication{
                     ApplicationId: app.ApplicationId,
                 })
+                events.Record(&events.Event{
+                    ObjectType:    events.ObjectApplication,
+                    ObjectId:      app.ApplicationId,
+                    Partition:     app.Partition,
+                    ApplicationId: app.ApplicationId,
+                    QueueName:     app.QueueName,
+                    Reason:        events.ApplicationAccepted,
+                    Message:       fmt.Sprintf("accepted in queue %s", app.QueueName),
+                })
                 // app is accepted by scheduler
 
 
@@ -397,6 +583,11 @@ func (m *Scheduler) processApplicationUpdateEvent(ev *schedulerevent.SchedulerAp
             m.eventHandlers.CacheEventHandler.HandleEvent(&cacheevent.RemovedApplicationEvent{ApplicationId: app.ApplicationId, PartitionName: app.PartitionName})
         }
     }
+
+    // the result of the move is logged and sent to the RM
+    for _, app := range ev.MovedApplications {
+        _ = m.moveApplication(app)
+    }
 }
 
 func (m *Scheduler) removePartitionsBelongToRM(event *commonevents.RemoveRMPartitionsEvent) {
@@ -421,6 +612,8 @@ func (m *Scheduler) processUpdatePartitionConfigsEvent(event *schedulerevent.Sch
         event.ResultChannel <- &commonevents.Result{
             Succeeded: true,
         }
+        // queue limits could have changed
+        m.triggerSchedule()
     }
 }
 
@@ -458,6 +651,10 @@ func (m *Scheduler) handleSchedulerEvent() {
             m.processUpdatePartitionConfigsEvent(v)
         case *schedulerevent.SchedulerDeletePartitionsConfigEvent:
             m.processDeletePartitionConfigsEvent(v)
+        case *schedulerevent.SchedulerResourcesAvailableEvent:
+            m.triggerSchedule()
+        case *schedulerevent.SchedulerMoveApplicationEvent:
+            m.processMoveApplicationEvent(v)
         default:
             panic(fmt.Sprintf("%s is not an acceptable type for Scheduler event.", reflect.TypeOf(v).String()))
         }
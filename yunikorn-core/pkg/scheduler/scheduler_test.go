@@ -0,0 +1,102 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/metrics"
+    "gotest.tools/assert"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+// metrics that only count the scheduling cycles, all other calls are not expected in the loop tests
+type cycleCountingMetrics struct {
+    metrics.CoreSchedulerMetrics
+    cycles int32
+}
+
+func (m *cycleCountingMetrics) ObserveSchedulingCycle(start time.Time) {
+    atomic.AddInt32(&m.cycles, 1)
+}
+
+func (m *cycleCountingMetrics) ObserveSchedulingBatchSize(size int) {
+}
+
+func (m *cycleCountingMetrics) getCycles() int32 {
+    return atomic.LoadInt32(&m.cycles)
+}
+
+func waitForCycles(t *testing.T, m *cycleCountingMetrics, expected int32) {
+    deadline := time.Now().Add(time.Second)
+    for m.getCycles() < expected {
+        if time.Now().After(deadline) {
+            t.Fatalf("expected at least %d scheduling cycles, got %d", expected, m.getCycles())
+        }
+        time.Sleep(time.Millisecond)
+    }
+}
+
+func TestScheduleLoopConfig(t *testing.T) {
+    m := NewScheduler(nil, nil)
+    assert.Equal(t, m.loopConfig, DefaultScheduleLoopConfig())
+
+    // unset values fall back to the defaults
+    m.SetScheduleLoopConfig(ScheduleLoopConfig{MinInterval: 50 * time.Millisecond})
+    assert.Equal(t, m.loopConfig.MinInterval, 50*time.Millisecond)
+    assert.Equal(t, m.loopConfig.MaxIdleTick, DefaultMaxIdleTick)
+
+    // idle tick can never be smaller than the interval
+    m.SetScheduleLoopConfig(ScheduleLoopConfig{MinInterval: time.Second, MaxIdleTick: time.Millisecond})
+    assert.Equal(t, m.loopConfig.MaxIdleTick, time.Second)
+}
+
+func TestScheduleTrigger(t *testing.T) {
+    counter := &cycleCountingMetrics{}
+    m := NewScheduler(nil, counter)
+    m.SetScheduleLoopConfig(ScheduleLoopConfig{
+        MinInterval: time.Millisecond,
+        MaxIdleTick: time.Hour,
+    })
+
+    // triggers are merged when the loop is not picking them up
+    m.triggerSchedule()
+    m.triggerSchedule()
+    assert.Equal(t, len(m.scheduleTrigger), 1)
+
+    go m.internalSchedule()
+    waitForCycles(t, counter, 1)
+    // without a trigger the loop stays idle
+    time.Sleep(50 * time.Millisecond)
+    assert.Equal(t, counter.getCycles(), int32(1))
+
+    m.triggerSchedule()
+    waitForCycles(t, counter, 2)
+}
+
+func TestScheduleIdleTick(t *testing.T) {
+    counter := &cycleCountingMetrics{}
+    m := NewScheduler(nil, counter)
+    m.SetScheduleLoopConfig(ScheduleLoopConfig{
+        MinInterval: time.Millisecond,
+        MaxIdleTick: 10 * time.Millisecond,
+    })
+
+    // without any trigger cycles are still run on the idle tick
+    go m.internalSchedule()
+    waitForCycles(t, counter, 3)
+}
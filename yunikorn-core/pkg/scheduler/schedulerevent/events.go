@@ -30,11 +30,23 @@ type SchedulerAllocationUpdatesEvent struct {
     RMId                string           // optional, only required during recovery
 }
 
+// From Cache, resources became available for scheduling: nodes were added or allocations were released.
+type SchedulerResourcesAvailableEvent struct {
+}
+
 // From Cache, update about apps.
 type SchedulerApplicationsUpdateEvent struct {
     // Type is *cache.ApplicationInfo, avoid cycle imports
     AddedApplications   []interface{}
     RemovedApplications []*si.RemoveApplicationRequest
+    MovedApplications   []*si.MoveApplicationRequest
+}
+
+// From the web service, move an application to a different queue.
+// The result of the move is returned via the channel.
+type SchedulerMoveApplicationEvent struct {
+    Request       *si.MoveApplicationRequest
+    ResultChannel chan *commonevents.Result
 }
 
 type SchedulerUpdatePartitionsConfigEvent struct {
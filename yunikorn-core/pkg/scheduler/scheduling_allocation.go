@@ -19,6 +19,7 @@ package scheduler
 import (
     "fmt"
     "github.com/cloudera/yunikorn-core/pkg/common/commonevents"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
 )
 
 type SchedulingAllocation struct {
@@ -27,6 +28,8 @@ type SchedulingAllocation struct {
     NodeId        string
     Releases      []*commonevents.ReleaseAllocation
     PartitionName string
+    // Resources of the allocations in the releases by uuid, only set when the allocation preempts others
+    ReleasedResources map[string]*resources.Resource
 }
 
 func NewSchedulingAllocation(ask *SchedulingAllocationAsk, nodeId string) *SchedulingAllocation {
@@ -105,6 +105,16 @@ func (csc *ClusterSchedulingContext) RemoveSchedulingApplication(appId string, p
     }
 }
 
+func (csc *ClusterSchedulingContext) MoveSchedulingApplication(appId, partitionName, queueName string) error {
+    csc.lock.Lock()
+    defer csc.lock.Unlock()
+
+    if partition := csc.partitions[partitionName]; partition != nil {
+        return partition.moveSchedulingApplication(appId, queueName)
+    }
+    return fmt.Errorf("failed to find partition=%s while move app=%s", partitionName, appId)
+}
+
 // Update the scheduler's partition list based on the processed config
 // - updates existing partitions and the queues linked
 // - add new partitions including queues
@@ -37,3 +37,8 @@ func NewSchedulingApplication(appInfo *cache.ApplicationInfo) *SchedulingApplica
         Requests:        NewSchedulingRequests(),
     }
 }
+
+// Does the application have asks that are not allocated yet?
+func (sa *SchedulingApplication) hasPendingAsks() bool {
+    return resources.StrictlyGreaterThanZero(sa.Requests.GetPendingResource())
+}
@@ -32,9 +32,15 @@ type SchedulingNode struct {
 
 	// Resource which is allocating (in addition to confirmed, allocated)
 	AllocatingResource      *resources.Resource
+	// Resource of allocations that are being preempted, the allocations they are preempted for are allocating.
+	// Only the part of the victims that the preemptors use is preempting: the rest is not available until released.
 	PreemptingResource      *resources.Resource
 	CachedAvailableResource *resources.Resource
 
+	// number of allocations by application on the node, taken from the cache once for the scheduling cycle
+	appAllocations     map[string]int64
+	appAllocationsOnce sync.Once
+
 	lock sync.RWMutex
 }
 
@@ -48,28 +54,60 @@ func NewSchedulingNode(info *cache.NodeInfo) *SchedulingNode {
 	}
 }
 
-func (m *SchedulingNode) CheckAndAllocateResource(delta *resources.Resource, preemptionPhase bool) bool {
+// Get the number of allocations of the application on the node.
+// The counts for all applications are taken from the cache on the first call: the scheduling node only lives for
+// one scheduling cycle and is shared by all asks evaluated in that cycle.
+func (m *SchedulingNode) getApplicationAllocationCount(appId string) int64 {
+	m.appAllocationsOnce.Do(func() {
+		m.appAllocations = make(map[string]int64)
+		for _, alloc := range m.NodeInfo.GetAllAllocations() {
+			m.appAllocations[alloc.ApplicationId]++
+		}
+	})
+	return m.appAllocations[appId]
+}
+
+// Allocate the resources on the node if they fit. Checking and allocating is done under the node lock: when
+// allocations are evaluated in parallel only one of the conflicting allocations will succeed.
+func (m *SchedulingNode) CheckAndAllocateResource(delta *resources.Resource) bool {
 	m.lock.Lock()
-	m.lock.Unlock()
+	defer m.lock.Unlock()
 	newAllocating := resources.Add(delta, m.AllocatingResource)
 
-	avail := m.CachedAvailableResource
-	if preemptionPhase {
-		avail = resources.Add(avail, m.PreemptingResource)
-	}
-
-	if resources.FitIn(avail, newAllocating) {
+	if resources.FitIn(m.getAvailableResource(), newAllocating) {
 		m.AllocatingResource = newAllocating
 		return true
 	}
 	return false
 }
 
+// Check if the resources fit on the node without allocating them.
+func (m *SchedulingNode) CheckResource(delta *resources.Resource) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return resources.FitIn(m.getAvailableResource(), resources.Add(delta, m.AllocatingResource))
+}
+
+// Get the resources available for allocations, must be called while holding the lock.
+// The preempting resources are available: they never exceed the allocating preemptors they are preempted for.
+func (m *SchedulingNode) getAvailableResource() *resources.Resource {
+	return resources.Add(m.CachedAvailableResource, m.PreemptingResource)
+}
+
+// Give back resources that were allocated on the node via CheckAndAllocateResource but are not used.
+func (m *SchedulingNode) DeallocateResource(delta *resources.Resource) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.AllocatingResource = resources.Sub(m.AllocatingResource, delta)
+}
+
 // Checking pre allocation conditions. The pre-allocation conditions are implemented via plugins in the shim.
 // If no plugins are implemented then the check will return true. If multiple plugins are implemented the first failure
 // will stop the checks.
 // The caller must not rely on all plugins being executed.
-func (m *SchedulingNode) CheckAllocateConditions(allocId string) bool {
+// A failure is only logged: the caller records the event for the ask.
+func (m *SchedulingNode) CheckAllocateConditions(ask *SchedulingAllocationAsk) bool {
+	allocId := ask.AskProto.AllocationKey
 	// Check the predicates plugin (k8shim)
 	if plugin := plugins.GetPredicatesPlugin(); plugin != nil {
 		log.Logger().Debug("predicates",
@@ -89,3 +127,18 @@ func (m *SchedulingNode) CheckAllocateConditions(allocId string) bool {
 	// must be last return in the list
 	return true
 }
+
+// Assume the volumes for the allocation on the node via the volumes plugin in the shim.
+// If no plugin is implemented there is nothing to assume and the check will return true.
+func (m *SchedulingNode) AssumeVolumes(allocId string) bool {
+	if plugin := plugins.GetVolumesPlugin(); plugin != nil {
+		if err := plugin.VolumesAssume(allocId, m.NodeId); err != nil {
+			log.Logger().Debug("assuming volumes failed",
+				zap.String("allocationId", allocId),
+				zap.String("nodeId", m.NodeId),
+				zap.Error(err))
+			return false
+		}
+	}
+	return true
+}
@@ -19,6 +19,7 @@ package scheduler
 import (
     "fmt"
     "github.com/cloudera/yunikorn-core/pkg/cache"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
     "github.com/cloudera/yunikorn-core/pkg/common/security"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "github.com/cloudera/yunikorn-core/pkg/scheduler/placement"
@@ -114,6 +115,17 @@ func (psc *PartitionSchedulingContext) AddSchedulingApplication(schedulingApp *S
         }
     }
 
+    // check the queue and its parents are not stopped or draining
+    if !schedulingQueue.CachedQueueInfo.IsAcceptingApplications() {
+        return fmt.Errorf("queue %s is not accepting new applications, rejecting application %s", queueName, appId)
+    }
+
+    // check the application limits of the queue and its parents, set in the config or via the child template
+    if limited := schedulingQueue.getQueueAtMaxApplications(nil); limited != nil {
+        return fmt.Errorf("queue %s has reached the maximum of %d applications, rejecting application %s",
+            limited.Name, limited.CachedQueueInfo.GetMaxApplications(), appId)
+    }
+
     // all is OK update the app and partition
     schedulingApp.queue = schedulingQueue
     schedulingQueue.AddSchedulingApplication(schedulingApp)
@@ -145,6 +157,50 @@ func (psc *PartitionSchedulingContext) RemoveSchedulingApplication(appId string)
     return schedulingApp, nil
 }
 
+// Move the application to a different leaf queue in the scheduling partition.
+// The target queue must exist, accept new applications, allow the application owner to submit and have room for the
+// application. The cache moves the allocated resources, the pending resources are moved between the scheduling queues.
+func (psc *PartitionSchedulingContext) moveSchedulingApplication(appId, queueName string) error {
+    psc.lock.Lock()
+    defer psc.lock.Unlock()
+
+    schedulingApp := psc.applications[appId]
+    if schedulingApp == nil {
+        return fmt.Errorf("moving application %s in partition %s, but application does not exist", appId, psc.Name)
+    }
+    target := psc.getQueue(queueName)
+    if target == nil || !target.isLeafQueue() {
+        return fmt.Errorf("failed to find leaf queue %s for application %s", queueName, appId)
+    }
+    source := schedulingApp.queue
+    if source == target {
+        return fmt.Errorf("application %s is already in queue %s", appId, target.Name)
+    }
+    if !target.CachedQueueInfo.IsAcceptingApplications() {
+        return fmt.Errorf("queue %s is not accepting new applications, cannot move application %s", target.Name, appId)
+    }
+    if !target.CheckSubmitAccess(schedulingApp.ApplicationInfo.GetUser()) {
+        return fmt.Errorf("submit access denied on queue %s for application %s", target.Name, appId)
+    }
+    if limited := target.getQueueAtMaxApplications(source); limited != nil {
+        return fmt.Errorf("queue %s has reached the maximum of %d applications, cannot move application %s",
+            limited.Name, limited.CachedQueueInfo.GetMaxApplications(), appId)
+    }
+    // move the allocated resources in the cache first: this checks the max resources of the target queues
+    if err := psc.partition.MoveApplication(appId, target.CachedQueueInfo); err != nil {
+        return err
+    }
+    // removing the application from the source queue also removes the pending resources from the queues
+    source.RemoveSchedulingApplication(schedulingApp)
+    schedulingApp.queue = target
+    schedulingApp.Requests.updateQueueName(target.Name)
+    target.AddSchedulingApplication(schedulingApp)
+    if pending := schedulingApp.Requests.GetPendingResource(); !resources.IsZero(pending) {
+        target.IncPendingResource(pending)
+    }
+    return nil
+}
+
 // Get the queue from the structure based on the fully qualified name.
 // Wrapper around the unlocked version getQueue()
 // Visible by tests
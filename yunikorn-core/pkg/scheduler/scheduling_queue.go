@@ -22,18 +22,24 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/common/security"
     "github.com/cloudera/yunikorn-core/pkg/log"
     "go.uber.org/zap"
+    "math"
+    "strconv"
     "strings"
     "sync"
 )
 
 // Represents Queue inside Scheduler
 type SchedulingQueue struct {
-    Name                string              // Fully qualified path for the queue
-    CachedQueueInfo     *cache.QueueInfo    // link back to the queue in the cache
-    ProposingResource   *resources.Resource // How much resource added for proposing, this is used by queue sort when do candidate selection
-    PartitionResource   *resources.Resource // For fairness calculation
-    ApplicationSortType SortType            // How applications are sorted (leaf queue only)
-    QueueSortType       SortType            // How sub queues are sorted (parent queue only)
+    Name                 string              // Fully qualified path for the queue
+    CachedQueueInfo      *cache.QueueInfo    // link back to the queue in the cache
+    ProposingResource    *resources.Resource // How much resource added for proposing, this is used by queue sort when do candidate selection
+    PartitionResource    *resources.Resource // For fairness calculation
+    ApplicationSortType  SortType            // How applications are sorted (leaf queue only)
+    QueueSortType        SortType            // How sub queues are sorted (parent queue only)
+    Preemptable          bool                // Can allocations in the queue be preempted
+    IntraQueuePreemption bool                // Can asks preempt lower priority allocations in the queue
+    DisruptionBudget     int                 // Maximum number of preempted allocations per application, negative is unlimited
+    Weight               float64             // Weight of the queue compared to its siblings for the fair share
 
     // Private fields need protection
     childrenQueues     map[string]*SchedulingQueue       // Only for direct children, parent queue only
@@ -84,12 +90,40 @@ func (sq *SchedulingQueue) updateSchedulingQueueProperties(prop map[string]strin
     // set the defaults, override with what is in the configured properties
     sq.ApplicationSortType = FifoSortPolicy
     sq.QueueSortType = FairSortPolicy
+    sq.Preemptable = true
+    sq.IntraQueuePreemption = false
+    sq.DisruptionBudget = -1
+    sq.Weight = 1
     // walk over all properties and process
     if prop != nil {
         for key, value := range prop {
             if key == cache.ApplicationSortPolicy  && value == "fair" {
                 sq.ApplicationSortType = FairSortPolicy
             }
+            if key == cache.QueuePreemptable && strings.ToLower(value) == "false" {
+                sq.Preemptable = false
+            }
+            if key == cache.QueueIntraQueuePreemption && strings.ToLower(value) == "true" {
+                sq.IntraQueuePreemption = true
+            }
+            if key == cache.QueueDisruptionBudget {
+                if budget, err := strconv.Atoi(value); err != nil || budget < 0 {
+                    log.Logger().Warn("ignoring illegal disruption budget",
+                        zap.String("queueName", sq.Name),
+                        zap.String("value", value))
+                } else {
+                    sq.DisruptionBudget = budget
+                }
+            }
+            if key == cache.QueueWeight {
+                if weight, err := strconv.ParseFloat(value, 64); err != nil || weight <= 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+                    log.Logger().Warn("ignoring illegal queue weight",
+                        zap.String("queueName", sq.Name),
+                        zap.String("value", value))
+                } else {
+                    sq.Weight = weight
+                }
+            }
             // for now skip the rest just log them
             log.Logger().Debug("queue property skipped",
                 zap.String("key", key),
@@ -150,6 +184,51 @@ func (sq *SchedulingQueue) AddSchedulingApplication(app *SchedulingApplication)
     sq.applications[app.ApplicationInfo.ApplicationId] = app
 }
 
+// Get the number of applications in the queue (leaf queue only)
+func (sq *SchedulingQueue) getApplicationCount() uint64 {
+    sq.lock.RLock()
+    defer sq.lock.RUnlock()
+
+    return uint64(len(sq.applications))
+}
+
+// Get the number of applications in the queue and all queues below it.
+func (sq *SchedulingQueue) getTotalApplicationCount() uint64 {
+    count := sq.getApplicationCount()
+    for _, child := range sq.GetCopyOfChildren() {
+        count += child.getTotalApplicationCount()
+    }
+    return count
+}
+
+// Get the first queue, starting at this queue and walking up the parents, that has reached its maximum number of
+// applications. A parent queue counts the applications of all queues below it. Returns nil if an application can be
+// added to this queue.
+// When an application is moved from the source queue the parents that also contain the source queue are not checked:
+// the number of applications in those queues does not change.
+func (sq *SchedulingQueue) getQueueAtMaxApplications(source *SchedulingQueue) *SchedulingQueue {
+    for queue := sq; queue != nil; queue = queue.parent {
+        if source != nil && source.isInQueue(queue) {
+            return nil
+        }
+        maxApps := queue.CachedQueueInfo.GetMaxApplications()
+        if maxApps > 0 && queue.getTotalApplicationCount() >= maxApps {
+            return queue
+        }
+    }
+    return nil
+}
+
+// Is this queue the queue or a queue below it.
+func (sq *SchedulingQueue) isInQueue(queue *SchedulingQueue) bool {
+    for current := sq; current != nil; current = current.parent {
+        if current == queue {
+            return true
+        }
+    }
+    return false
+}
+
 func (sq *SchedulingQueue) RemoveSchedulingApplication(app *SchedulingApplication) {
     sq.lock.Lock()
     defer sq.lock.Unlock()
@@ -194,8 +273,8 @@ func (sq *SchedulingQueue) RemoveQueue() bool {
     sq.lock.RLock()
     defer sq.lock.RUnlock()
 
-    // cannot remove a managed queue that is running
-    if sq.isManaged() && sq.isRunning() {
+    // cannot remove a managed queue that is still part of the config
+    if sq.isManaged() && !sq.CachedQueueInfo.IsMarkedForRemoval() {
         return false
     }
     // cannot remove a queue that has children or applications assigned
@@ -226,8 +305,9 @@ func (sq *SchedulingQueue) isDraining() bool {
     return sq.CachedQueueInfo.IsDraining()
 }
 
+// A queue is also considered stopped when one of its parents is stopped.
 func (sq *SchedulingQueue) isStopped() bool {
-    return sq.CachedQueueInfo.IsStopped()
+    return sq.CachedQueueInfo.IsStoppedInHierarchy()
 }
 
 // Is this queue managed or not.
@@ -20,6 +20,7 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/cache"
     "github.com/cloudera/yunikorn-core/pkg/common/configs"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/common/security"
     "strconv"
     "testing"
 )
@@ -255,4 +256,120 @@ func TestGetChildQueueInfos(t *testing.T) {
     if len(root.childrenQueues) != 2 {
         t.Errorf("parent queues are not added to the root queue, expected 2 children got %d", len(root.childrenQueues))
     }
-}
\ No newline at end of file
+}
+func TestQueuePreemptableProperty(t *testing.T) {
+    root, err := createRootQueue()
+    if err != nil {
+        t.Fatalf("failed to create basic root queue: %v", err)
+    }
+    if !root.Preemptable {
+        t.Errorf("queue should be preemptable by default")
+    }
+    root.updateSchedulingQueueProperties(map[string]string{cache.QueuePreemptable: "False"})
+    if root.Preemptable {
+        t.Errorf("queue should not be preemptable when the property is set to false")
+    }
+    root.updateSchedulingQueueProperties(nil)
+    if !root.Preemptable {
+        t.Errorf("queue should be preemptable after the property is removed")
+    }
+}
+
+func TestQueueIntraQueuePreemptionProperties(t *testing.T) {
+    root, err := createRootQueue()
+    if err != nil {
+        t.Fatalf("failed to create basic root queue: %v", err)
+    }
+    if root.IntraQueuePreemption || root.DisruptionBudget != -1 {
+        t.Errorf("intra queue preemption should be off and the budget unlimited by default")
+    }
+    root.updateSchedulingQueueProperties(map[string]string{
+        cache.QueueIntraQueuePreemption: "True",
+        cache.QueueDisruptionBudget:     "2",
+    })
+    if !root.IntraQueuePreemption || root.DisruptionBudget != 2 {
+        t.Errorf("intra queue preemption properties not set: %t, %d", root.IntraQueuePreemption, root.DisruptionBudget)
+    }
+    root.updateSchedulingQueueProperties(map[string]string{cache.QueueDisruptionBudget: "-1"})
+    if root.IntraQueuePreemption || root.DisruptionBudget != -1 {
+        t.Errorf("illegal disruption budget should be ignored: %d", root.DisruptionBudget)
+    }
+}
+
+func TestQueueWeightProperty(t *testing.T) {
+    root, err := createRootQueue()
+    if err != nil {
+        t.Fatalf("failed to create basic root queue: %v", err)
+    }
+    if root.Weight != 1 {
+        t.Errorf("queue weight should be 1 by default, got %f", root.Weight)
+    }
+    root.updateSchedulingQueueProperties(map[string]string{cache.QueueWeight: "2.5"})
+    if root.Weight != 2.5 {
+        t.Errorf("queue weight not set, expected 2.5 got %f", root.Weight)
+    }
+    for _, illegal := range []string{"0", "-1", "NaN", "Inf", "x"} {
+        root.updateSchedulingQueueProperties(map[string]string{cache.QueueWeight: illegal})
+        if root.Weight != 1 {
+            t.Errorf("illegal queue weight %s should be ignored, got %f", illegal, root.Weight)
+        }
+    }
+}
+
+func TestQueueMaxApplications(t *testing.T) {
+    root, err := createRootQueue()
+    if err != nil {
+        t.Fatalf("failed to create basic root queue: %v", err)
+    }
+    parentInfo, err := cache.NewManagedQueue(configs.QueueConfig{Name: "parent", Parent: true, MaxApplications: 2}, root.CachedQueueInfo)
+    if err != nil {
+        t.Fatalf("failed to create managed parent queue: %v", err)
+    }
+    parent := NewSchedulingQueueInfo(parentInfo, root)
+    leafA, err := createManagedQueue(parent, "a", false)
+    if err != nil {
+        t.Fatalf("failed to create managed leaf queue: %v", err)
+    }
+    leafB, err := createManagedQueue(parent, "b", false)
+    if err != nil {
+        t.Fatalf("failed to create managed leaf queue: %v", err)
+    }
+    leafInfo, err := cache.NewManagedQueue(configs.QueueConfig{Name: "c", MaxApplications: 1}, root.CachedQueueInfo)
+    if err != nil {
+        t.Fatalf("failed to create managed leaf queue: %v", err)
+    }
+    leafC := NewSchedulingQueueInfo(leafInfo, root)
+    addApp := func(queue *SchedulingQueue, appId string) {
+        appInfo := cache.NewApplicationInfo(appId, "default", queue.Name, security.UserGroup{}, nil)
+        queue.AddSchedulingApplication(NewSchedulingApplication(appInfo))
+    }
+
+    // the leaf limit is checked
+    if limited := leafC.getQueueAtMaxApplications(nil); limited != nil {
+        t.Errorf("empty leaf queue should not be at its limit: %s", limited.Name)
+    }
+    addApp(leafC, "app-c")
+    if limited := leafC.getQueueAtMaxApplications(nil); limited != leafC {
+        t.Errorf("leaf queue c should be at its limit, got %v", limited)
+    }
+
+    // the parent counts the applications of all queues below it
+    addApp(leafA, "app-a")
+    if limited := leafB.getQueueAtMaxApplications(nil); limited != nil {
+        t.Errorf("parent queue should not be at its limit: %s", limited.Name)
+    }
+    addApp(leafB, "app-b")
+    if count := parent.getTotalApplicationCount(); count != 2 {
+        t.Errorf("parent should count the applications of its children, expected 2 got %d", count)
+    }
+    if limited := leafA.getQueueAtMaxApplications(nil); limited != parent {
+        t.Errorf("parent queue should be at its limit, got %v", limited)
+    }
+    // moving between children of the parent does not change the parent count, moving into the parent does
+    if limited := leafA.getQueueAtMaxApplications(leafB); limited != nil {
+        t.Errorf("move within the parent should be allowed, limited by %s", limited.Name)
+    }
+    if limited := leafA.getQueueAtMaxApplications(leafC); limited != parent {
+        t.Errorf("move into the parent should be limited by the parent, got %v", limited)
+    }
+}
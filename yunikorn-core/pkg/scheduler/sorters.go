@@ -31,13 +31,14 @@ const (
     MaxAvailableResources = 2
 )
 
+// Sort the queues based on the resources proposed for each queue relative to the fair share of the queue.
 func SortQueue(queues []*SchedulingQueue, sortType SortType) {
     if sortType == FairSortPolicy {
         sort.SliceStable(queues, func(i, j int) bool {
             l := queues[i]
             r := queues[j]
 
-            comp := resources.CompFairnessRatio(l.ProposingResource, l.CachedQueueInfo.GuaranteedResource, r.ProposingResource, l.CachedQueueInfo.GuaranteedResource)
+            comp := resources.CompFairnessRatio(l.ProposingResource, l.CachedQueueInfo.GetFairShare(), r.ProposingResource, r.CachedQueueInfo.GetFairShare())
             return comp < 0
         })
     }
@@ -0,0 +1,140 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/events"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "gotest.tools/assert"
+    "testing"
+)
+
+var MoveQueueConfig = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        submitacl: "*"
+        queues:
+          - name: a
+            resources:
+              max:
+                memory: 200
+                vcore: 100
+          - name: b
+            resources:
+              max:
+                memory: 200
+                vcore: 100
+          - name: small
+            resources:
+              max:
+                memory: 50
+                vcore: 100
+`
+
+// Move a running application with allocations and pending asks between queues.
+func TestMoveApplication(t *testing.T) {
+    ms := &MockScheduler{}
+    defer ms.Stop()
+
+    ms.Init(t, MoveQueueConfig)
+
+    ms.AddNode("node-1:1234", &si.Resource{
+        Resources: map[string]*si.Quantity{
+            "memory": {Value: 100},
+            "vcore":  {Value: 100},
+        },
+    })
+    ms.AddNode("node-2:1234", &si.Resource{
+        Resources: map[string]*si.Quantity{
+            "memory": {Value: 100},
+            "vcore":  {Value: 100},
+        },
+    })
+    ms.AddApp("app-1", "root.a", "")
+
+    schedulerQueueRoot := ms.GetSchedulingQueue("root")
+    schedulerQueueA := ms.GetSchedulingQueue("root.a")
+    schedulerQueueB := ms.GetSchedulingQueue("root.b")
+    schedulerQueueSmall := ms.GetSchedulingQueue("root.small")
+
+    err := ms.proxy.Update(&si.UpdateRequest{
+        Asks: []*si.AllocationAsk{
+            {
+                AllocationKey: "alloc-1",
+                ResourceAsk: &si.Resource{
+                    Resources: map[string]*si.Quantity{
+                        "memory": {Value: 10},
+                        "vcore":  {Value: 1},
+                    },
+                },
+                MaxAllocations: 15,
+                ApplicationId:  "app-1",
+            },
+        },
+        RmId: "rm:123",
+    })
+    assert.NilError(t, err)
+    waitForPendingResource(t, schedulerQueueA, 150, 1000)
+
+    // allocate part of the asks and leave the rest pending
+    ms.scheduler.SingleStepScheduleAllocTest(10)
+    waitForAllocations(ms.mockRM, 10, 1000)
+    waitForPendingResource(t, schedulerQueueA, 50, 1000)
+    assert.Equal(t, schedulerQueueA.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY], resources.Quantity(100))
+
+    // the allocated resources do not fit in the target queue
+    err = ms.proxy.Update(&si.UpdateRequest{
+        MoveApplications: []*si.MoveApplicationRequest{
+            {ApplicationId: "app-1", QueueName: "root.small"},
+        },
+        RmId: "rm:123",
+    })
+    assert.NilError(t, err)
+    waitForRejectedMove(ms.mockRM, "app-1", 1000)
+    assert.Equal(t, schedulerQueueA.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY], resources.Quantity(100))
+    assert.Equal(t, schedulerQueueSmall.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY], resources.Quantity(0))
+    assert.Equal(t, schedulerQueueA.GetPendingResource().Resources[resources.MEMORY], resources.Quantity(50))
+
+    // move the application: allocated and pending resources move with it
+    err = ms.proxy.Update(&si.UpdateRequest{
+        MoveApplications: []*si.MoveApplicationRequest{
+            {ApplicationId: "app-1", QueueName: "root.b"},
+        },
+        RmId: "rm:123",
+    })
+    assert.NilError(t, err)
+    waitForMovedApplication(ms.mockRM, "app-1", "root.b", 1000)
+    assert.Equal(t, schedulerQueueA.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY], resources.Quantity(0))
+    assert.Equal(t, schedulerQueueB.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY], resources.Quantity(100))
+    assert.Equal(t, schedulerQueueRoot.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY], resources.Quantity(100))
+    assert.Equal(t, schedulerQueueA.GetPendingResource().Resources[resources.MEMORY], resources.Quantity(0))
+    assert.Equal(t, schedulerQueueB.GetPendingResource().Resources[resources.MEMORY], resources.Quantity(50))
+    assert.Equal(t, schedulerQueueRoot.GetPendingResource().Resources[resources.MEMORY], resources.Quantity(50))
+    appEvents := events.GetEventStore().GetEvents("", events.ObjectApplication, "app-1")
+    assert.Assert(t, len(appEvents) > 0, "no events recorded for the moved application")
+    assert.Equal(t, appEvents[len(appEvents)-1].Reason, events.ApplicationMoved)
+
+    // the remaining asks are allocated in the new queue
+    ms.scheduler.SingleStepScheduleAllocTest(10)
+    waitForAllocations(ms.mockRM, 15, 1000)
+    waitForPendingResource(t, schedulerQueueB, 0, 1000)
+    assert.Equal(t, schedulerQueueB.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY], resources.Quantity(150))
+    assert.Equal(t, schedulerQueueA.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY], resources.Quantity(0))
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tests
+
+import (
+    "fmt"
+    "github.com/cloudera/yunikorn-core/pkg/common/configs"
+    "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/entrypoint"
+    "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+    "testing"
+    "time"
+)
+
+// RM callback with predicates that take time to evaluate, like the predicates in the shim.
+// Only the nodes with an even number pass the predicates.
+type predicatesRMCallbackHandler struct {
+    *MockRMCallbackHandler
+    latency time.Duration
+}
+
+func (m *predicatesRMCallbackHandler) Predicates(args *si.PredicatesArgs) error {
+    time.Sleep(m.latency)
+    var nodeNumber int
+    if _, err := fmt.Sscanf(args.NodeId, "node-%d", &nodeNumber); err != nil || nodeNumber%2 != 0 {
+        return fmt.Errorf("node %s does not match the node selector", args.NodeId)
+    }
+    return nil
+}
+
+// Benchmark one scheduling step for one ask on a partition with the given number of nodes.
+func benchmarkNodeEvaluation(b *testing.B, numNodes int, parallelism int, percentage int32) {
+    serviceContext := entrypoint.StartAllServicesWithManualScheduler()
+    defer serviceContext.StopAll()
+    proxy := serviceContext.RMProxy
+    scheduler := serviceContext.Scheduler
+
+    configData := fmt.Sprintf(`
+partitions:
+  - name: default
+    queues:
+      - name: root
+        submitacl: "*"
+        queues:
+          - name: a
+    nodes:
+      parallelism: %d
+      percentageofnodestoscore: %d
+`, parallelism, percentage)
+    configs.MockSchedulerConfigByData([]byte(configData))
+    mockRM := &predicatesRMCallbackHandler{
+        MockRMCallbackHandler: NewMockRMCallbackHandler(b),
+        latency:               50 * time.Microsecond,
+    }
+    _, err := proxy.RegisterResourceManager(
+        &si.RegisterResourceManagerRequest{
+            RmId:        "rm:123",
+            PolicyGroup: "policygroup",
+            Version:     "0.0.2",
+        }, mockRM)
+    if err != nil {
+        b.Fatal(err.Error())
+    }
+
+    // nodes are big enough to hold all allocations of the benchmark
+    nodes := make([]*si.NewNodeInfo, numNodes)
+    for i := range nodes {
+        nodes[i] = &si.NewNodeInfo{
+            NodeId: fmt.Sprintf("node-%d", i),
+            Attributes: map[string]string{
+                "si.io/hostname": fmt.Sprintf("node-%d", i),
+                "si.io/rackname": "rack-1",
+            },
+            SchedulableResource: &si.Resource{
+                Resources: map[string]*si.Quantity{
+                    "memory": {Value: int64(b.N)},
+                },
+            },
+        }
+    }
+    err = proxy.Update(&si.UpdateRequest{
+        NewSchedulableNodes: nodes,
+        NewApplications:     newAddAppRequest(map[string]string{"app-1": "root.a"}),
+        RmId:                "rm:123",
+    })
+    if err != nil {
+        b.Fatal(err.Error())
+    }
+    waitForAcceptedApplications(mockRM.MockRMCallbackHandler, "app-1", 1000)
+    waitForAcceptedNodes(mockRM.MockRMCallbackHandler, fmt.Sprintf("node-%d", numNodes-1), 5000)
+
+    err = proxy.Update(&si.UpdateRequest{
+        Asks: []*si.AllocationAsk{
+            {
+                AllocationKey: "alloc-1",
+                ResourceAsk: &si.Resource{
+                    Resources: map[string]*si.Quantity{
+                        "memory": {Value: 1},
+                    },
+                },
+                MaxAllocations: int32(b.N),
+                ApplicationId:  "app-1",
+            },
+        },
+        RmId: "rm:123",
+    })
+    if err != nil {
+        b.Fatal(err.Error())
+    }
+    schedulingApp := scheduler.GetClusterSchedulingContext().GetSchedulingApplication("app-1", "[rm:123]default")
+    waitForPendingResourceForApplication(b, schedulingApp, resources.Quantity(b.N), 1000)
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        scheduler.SingleStepScheduleAllocTest(1)
+    }
+    b.StopTimer()
+    waitForAllocations(mockRM.MockRMCallbackHandler, b.N, 10000)
+}
+
+func BenchmarkNodeEvaluation(b *testing.B) {
+    tests := []struct {
+        numNodes    int
+        parallelism int
+        percentage  int32
+    }{
+        {500, 1, 100},
+        {500, 16, 100},
+        {3000, 1, 100},
+        {3000, 16, 100},
+        {3000, 16, 0},
+        {3000, 16, 10},
+    }
+    for _, test := range tests {
+        name := fmt.Sprintf("nodes=%d/parallelism=%d/percentage=%d", test.numNodes, test.parallelism, test.percentage)
+        b.Run(name, func(b *testing.B) {
+            benchmarkNodeEvaluation(b, test.numNodes, test.parallelism, test.percentage)
+        })
+    }
+}
@@ -18,10 +18,13 @@ limitations under the License.
 package tests
 
 import (
+    "github.com/cloudera/yunikorn-core/pkg/common"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
+    "github.com/cloudera/yunikorn-core/pkg/events"
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
     "gotest.tools/assert"
     "testing"
+    "time"
 )
 
 // Test basic interactions from rm proxy to cache and to scheduler.
@@ -141,4 +144,280 @@ func TestBasicPreemption(t *testing.T) {
     // Check pending resource, should be 900 now
     waitForPendingResource(t, schedulerQueueB, 900, 1000)
     waitForPendingResourceForApplication(t, schedulingApp2, 900, 1000)
+
+    // The RM is asked to terminate the victims with the default grace period, the victims
+    // are still allocated and nothing is allocated to app-2 until the RM releases them.
+    waitForPreemptedAllocations(ms.mockRM, 10, 1000)
+    preempted := ms.mockRM.getPreemptedAllocations()
+    for _, victim := range preempted {
+        assert.Equal(t, victim.GracePeriodSeconds, int64(30))
+    }
+    waitForAllocations(ms.mockRM, 20, 1000)
+    assert.Assert(t, schedulerQueueB.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY] == 0)
+
+    // the preemptions are recorded as events for the queue of the victims
+    preemptedEvents := 0
+    for _, event := range events.GetEventStore().GetEvents("", events.ObjectQueue, "root.a") {
+        if event.Reason == events.AllocationPreempted {
+            preemptedEvents += event.Count
+        }
+    }
+    assert.Equal(t, preemptedEvents, 10)
+    // each event is recorded for the victim allocation
+    for uuid := range preempted {
+        victimEvents := events.GetEventStore().GetEvents("", events.ObjectAllocation, uuid)
+        assert.Equal(t, len(victimEvents), 1)
+        assert.Equal(t, victimEvents[0].Reason, events.AllocationPreempted)
+        assert.Equal(t, victimEvents[0].ApplicationId, "app-1")
+    }
+
+    // Release the victims from the RM: the preemptors get allocated
+    toRelease := make([]*si.AllocationReleaseRequest, 0)
+    for uuid := range preempted {
+        toRelease = append(toRelease, &si.AllocationReleaseRequest{
+            Uuid:          uuid,
+            ApplicationId: "app-1",
+            PartitionName: "default",
+            Message:       "preempted",
+        })
+    }
+    err = ms.proxy.Update(&si.UpdateRequest{
+        Releases: &si.AllocationReleasesRequest{
+            AllocationsToRelease: toRelease,
+        },
+        RmId: "rm:123",
+    })
+
+    if nil != err {
+        t.Error(err.Error())
+    }
+
+    waitForApplicationAllocations(ms.mockRM, "app-2", 10, 1000)
+    waitForAllocations(ms.mockRM, 20, 1000)
+    assert.Assert(t, schedulerQueueA.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY] == 100)
+    assert.Assert(t, schedulerQueueB.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY] == 100)
+}
+
+// Test that a preemption finishes when the victims are removed with their application, not only when the RM
+// releases them.
+func TestPreemptionVictimsRemoved(t *testing.T) {
+    ms := &MockScheduler{}
+    defer ms.Stop()
+
+    ms.Init(t, TwoEqualQueueConfigEnabledPreemption)
+    scheduler := ms.scheduler
+
+    for _, nodeId := range []string{"node-1:1234", "node-2:1234"} {
+        ms.AddNode(nodeId, &si.Resource{
+            Resources: map[string]*si.Quantity{
+                "memory": {Value: 100},
+                "vcore":  {Value: 100},
+            },
+        })
+        waitForAcceptedNodes(ms.mockRM, nodeId, 1000)
+    }
+    ms.AddApp("app-1", "root.a", "")
+    ms.AddApp("app-2", "root.b", "")
+    waitForAcceptedApplications(ms.mockRM, "app-1", 1000)
+    waitForAcceptedApplications(ms.mockRM, "app-2", 1000)
+    schedulerQueueA := scheduler.GetClusterSchedulingContext().GetSchedulingQueue("root.a", "[rm:123]default")
+    schedulerQueueB := scheduler.GetClusterSchedulingContext().GetSchedulingQueue("root.b", "[rm:123]default")
+
+    // app-1 fills the cluster
+    err := ms.proxy.Update(&si.UpdateRequest{
+        Asks: []*si.AllocationAsk{
+            {
+                AllocationKey: "alloc-1",
+                ResourceAsk: &si.Resource{
+                    Resources: map[string]*si.Quantity{
+                        "memory": {Value: 10},
+                        "vcore":  {Value: 10},
+                    },
+                },
+                MaxAllocations: 20,
+                ApplicationId:  "app-1",
+            },
+        },
+        RmId: "rm:123",
+    })
+    assert.NilError(t, err)
+    waitForPendingResource(t, schedulerQueueA, 200, 1000)
+    scheduler.SingleStepScheduleAllocTest(20)
+    waitForAllocations(ms.mockRM, 20, 1000)
+
+    // app-2 can only get resources by preemption
+    err = ms.proxy.Update(&si.UpdateRequest{
+        Asks: []*si.AllocationAsk{
+            {
+                AllocationKey: "alloc-2",
+                ResourceAsk: &si.Resource{
+                    Resources: map[string]*si.Quantity{
+                        "memory": {Value: 10},
+                        "vcore":  {Value: 10},
+                    },
+                },
+                MaxAllocations: 10,
+                ApplicationId:  "app-2",
+            },
+        },
+        RmId: "rm:123",
+    })
+    assert.NilError(t, err)
+    waitForPendingResource(t, schedulerQueueB, 100, 1000)
+    scheduler.SingleStepPreemption()
+    waitForPreemptedAllocations(ms.mockRM, 10, 1000)
+    assert.Assert(t, schedulerQueueB.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY] == 0)
+
+    // remove app-1 with all its allocations: the victims are gone without a release by the RM
+    err = ms.proxy.Update(&si.UpdateRequest{
+        RemoveApplications: []*si.RemoveApplicationRequest{
+            {
+                ApplicationId: "app-1",
+                PartitionName: "default",
+            },
+        },
+        RmId: "rm:123",
+    })
+    assert.NilError(t, err)
+    err = common.WaitFor(10*time.Millisecond, time.Second, func() bool {
+        return schedulerQueueA.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY] == 0
+    })
+    assert.NilError(t, err, "allocations of app-1 were not removed")
+
+    // the next preemption cycle finishes the pending preemptions
+    scheduler.SingleStepPreemption()
+    waitForApplicationAllocations(ms.mockRM, "app-2", 10, 1000)
+    assert.Assert(t, schedulerQueueB.CachedQueueInfo.GetAllocatedResource().Resources[resources.MEMORY] == 100)
+}
+
+var OneQueueConfigIntraQueuePreemption = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        submitacl: "*"
+        queues:
+          - name: a
+            properties:
+              preemption.intraqueue: "true"
+              preemption.disruptionbudget: "3"
+    preemption:
+      enabled: true
+      policies:
+        - priority
+`
+
+// Test that high priority asks preempt lower priority allocations in the same queue within the disruption budget.
+func TestIntraQueuePriorityPreemption(t *testing.T) {
+    ms := &MockScheduler{}
+    defer ms.Stop()
+
+    ms.Init(t, OneQueueConfigIntraQueuePreemption)
+
+    scheduler := ms.scheduler
+
+    ms.AddNode("node-1:1234", &si.Resource{
+        Resources: map[string]*si.Quantity{
+            "memory": {Value: 100},
+            "vcore":  {Value: 100},
+        },
+    })
+    ms.AddApp("app-1", "root.a", "")
+    ms.AddApp("app-2", "root.a", "")
+
+    waitForAcceptedNodes(ms.mockRM, "node-1:1234", 1000)
+    waitForAcceptedApplications(ms.mockRM, "app-1", 1000)
+    waitForAcceptedApplications(ms.mockRM, "app-2", 1000)
+
+    schedulerQueueA := scheduler.GetClusterSchedulingContext().GetSchedulingQueue("root.a", "[rm:123]default")
+
+    // Low priority asks fill up the node
+    err := ms.proxy.Update(&si.UpdateRequest{
+        Asks: []*si.AllocationAsk{
+            {
+                AllocationKey: "alloc-1",
+                ResourceAsk: &si.Resource{
+                    Resources: map[string]*si.Quantity{
+                        "memory": {Value: 10},
+                        "vcore":  {Value: 10},
+                    },
+                },
+                Priority:       &si.Priority{Priority: &si.Priority_PriorityValue{PriorityValue: 1}},
+                MaxAllocations: 10,
+                ApplicationId:  "app-1",
+            },
+        },
+        RmId: "rm:123",
+    })
+
+    if nil != err {
+        t.Error(err.Error())
+    }
+
+    waitForPendingResource(t, schedulerQueueA, 100, 1000)
+    scheduler.SingleStepScheduleAllocTest(10)
+    waitForAllocations(ms.mockRM, 10, 1000)
+
+    // High priority asks in the same queue
+    err = ms.proxy.Update(&si.UpdateRequest{
+        Asks: []*si.AllocationAsk{
+            {
+                AllocationKey: "alloc-2",
+                ResourceAsk: &si.Resource{
+                    Resources: map[string]*si.Quantity{
+                        "memory": {Value: 10},
+                        "vcore":  {Value: 10},
+                    },
+                },
+                Priority:       &si.Priority{Priority: &si.Priority_PriorityValue{PriorityValue: 10}},
+                MaxAllocations: 5,
+                ApplicationId:  "app-2",
+            },
+        },
+        RmId: "rm:123",
+    })
+
+    if nil != err {
+        t.Error(err.Error())
+    }
+
+    waitForPendingResource(t, schedulerQueueA, 50, 1000)
+
+    // Only 3 allocations of app-1 can be preempted at the same time
+    scheduler.SingleStepPreemption()
+    waitForPreemptedAllocations(ms.mockRM, 3, 1000)
+    waitForPendingResource(t, schedulerQueueA, 20, 1000)
+
+    // A second cycle does not preempt more while the victims are not released
+    scheduler.SingleStepPreemption()
+    assert.Equal(t, len(ms.mockRM.getPreemptedAllocations()), 3)
+
+    // Release the victims from the RM: the preemptors get allocated
+    toRelease := make([]*si.AllocationReleaseRequest, 0)
+    for uuid := range ms.mockRM.getPreemptedAllocations() {
+        toRelease = append(toRelease, &si.AllocationReleaseRequest{
+            Uuid:          uuid,
+            ApplicationId: "app-1",
+            PartitionName: "default",
+            Message:       "preempted",
+        })
+    }
+    err = ms.proxy.Update(&si.UpdateRequest{
+        Releases: &si.AllocationReleasesRequest{
+            AllocationsToRelease: toRelease,
+        },
+        RmId: "rm:123",
+    })
+
+    if nil != err {
+        t.Error(err.Error())
+    }
+
+    waitForApplicationAllocations(ms.mockRM, "app-2", 3, 1000)
+    waitForApplicationAllocations(ms.mockRM, "app-1", 7, 1000)
+
+    // The budget is available again for the remaining asks
+    scheduler.SingleStepPreemption()
+    waitForPreemptedAllocations(ms.mockRM, 5, 1000)
+    waitForPendingResource(t, schedulerQueueA, 0, 1000)
 }
@@ -23,6 +23,7 @@ import (
     "github.com/cloudera/yunikorn-core/pkg/common/configs"
     "github.com/cloudera/yunikorn-core/pkg/common/resources"
     "github.com/cloudera/yunikorn-core/pkg/entrypoint"
+    "github.com/cloudera/yunikorn-core/pkg/events"
     "github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
     "gotest.tools/assert"
     "testing"
@@ -937,6 +938,14 @@ partitions:
     })
 
     waitForAcceptedApplications(mockRM, "app-added-2", 1000)
+
+    // the decisions are recorded as events for the applications
+    rejectEvents := events.GetEventStore().GetEvents("", events.ObjectApplication, "app-reject-1")
+    assert.Assert(t, len(rejectEvents) > 0, "no events recorded for the rejected application")
+    assert.Equal(t, rejectEvents[0].Reason, events.ApplicationRejected)
+    acceptEvents := events.GetEventStore().GetEvents("", events.ObjectApplication, "app-added-2")
+    assert.Assert(t, len(acceptEvents) > 0, "no events recorded for the accepted application")
+    assert.Equal(t, acceptEvents[0].Reason, events.ApplicationAccepted)
 }
 
 func TestSchedulingOverMaxCapacity(t *testing.T) {
@@ -1099,4 +1108,124 @@ partitions:
             assert.Equal(t, len(mockRM.getAllocations()), 2)
         })
     }
-}
\ No newline at end of file
+}
+func TestRemoveCompletedApplication(t *testing.T) {
+    // Start all tests
+    serviceContext := entrypoint.StartAllServicesWithManualScheduler()
+    defer serviceContext.StopAll()
+    proxy := serviceContext.RMProxy
+    cache := serviceContext.Cache
+    scheduler := serviceContext.Scheduler
+
+    // Register RM
+    configData := `
+partitions:
+  -
+    name: default
+    applications:
+      completingtimeout: 1s
+    queues:
+      - name: root
+        submitacl: "*"
+        queues:
+          - name: a
+`
+    configs.MockSchedulerConfigByData([]byte(configData))
+    mockRM := NewMockRMCallbackHandler(t)
+
+    _, err := proxy.RegisterResourceManager(
+        &si.RegisterResourceManagerRequest{
+            RmId:        "rm:123",
+            PolicyGroup: "policygroup",
+            Version:     "0.0.2",
+        }, mockRM)
+
+    if err != nil {
+        t.Error(err.Error())
+    }
+
+    // Register a node, and add an application
+    err = proxy.Update(&si.UpdateRequest{
+        NewSchedulableNodes: []*si.NewNodeInfo{
+            {
+                NodeId: "node-1:1234",
+                Attributes: map[string]string{
+                    "si.io/hostname": "node-1",
+                    "si.io/rackname": "rack-1",
+                },
+                SchedulableResource: &si.Resource{
+                    Resources: map[string]*si.Quantity{
+                        "memory": {Value: 100},
+                        "vcore":  {Value: 20},
+                    },
+                },
+            },
+        },
+        NewApplications: newAddAppRequest(map[string]string{"app-1":"root.a"}),
+        RmId: "rm:123",
+    })
+
+    if nil != err {
+        t.Error(err.Error())
+    }
+
+    waitForAcceptedApplications(mockRM, "app-1", 1000)
+    waitForAcceptedNodes(mockRM, "node-1:1234", 1000)
+
+    err = proxy.Update(&si.UpdateRequest{
+        Asks: []*si.AllocationAsk{
+            {
+                AllocationKey: "alloc-1",
+                ResourceAsk: &si.Resource{
+                    Resources: map[string]*si.Quantity{
+                        "memory": {Value: 10},
+                        "vcore":  {Value: 1},
+                    },
+                },
+                MaxAllocations: 1,
+                ApplicationId:  "app-1",
+            },
+        },
+        RmId: "rm:123",
+    })
+
+    if nil != err {
+        t.Error(err.Error())
+    }
+
+    schedulingApp := scheduler.GetClusterSchedulingContext().GetSchedulingApplication("app-1", "[rm:123]default")
+    waitForPendingResourceForApplication(t, schedulingApp, 10, 1000)
+
+    scheduler.SingleStepScheduleAllocTest(1)
+
+    waitForAllocations(mockRM, 1, 1000)
+
+    // Release the allocation, the application moves to completing
+    releases := make([]*si.AllocationReleaseRequest, 0)
+    for _, v := range mockRM.getAllocations() {
+        releases = append(releases, &si.AllocationReleaseRequest{
+            Uuid:          v.Uuid,
+            ApplicationId: v.ApplicationId,
+            PartitionName: v.PartitionName,
+        })
+    }
+    err = proxy.Update(&si.UpdateRequest{
+        Releases: &si.AllocationReleasesRequest{
+            AllocationsToRelease: releases,
+        },
+        RmId: "rm:123",
+    })
+
+    if nil != err {
+        t.Error(err.Error())
+    }
+
+    // The completed application is removed after the timeout and the RM is told about it
+    waitForRemovedApplications(mockRM, "app-1", 5000)
+    partitionInfo := cache.GetPartition("[rm:123]default")
+    err = common.WaitFor(10*time.Millisecond, time.Second, func() bool {
+        _, err = getApplicationInfoFromPartition(partitionInfo, "app-1")
+        return err != nil
+    })
+    assert.NilError(t, err, "completed application should have been removed from the partition")
+}
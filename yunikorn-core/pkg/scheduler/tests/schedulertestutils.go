@@ -28,27 +28,35 @@ import (
 )
 
 type MockRMCallbackHandler struct {
-    t *testing.T
+    t testing.TB
 
     acceptedApplications map[string]bool
     rejectedApplications map[string]bool
+    removedApplications  map[string]bool
     acceptedNodes        map[string]bool
     rejectedNodes        map[string]bool
     nodeAllocations      map[string][]*si.Allocation
     Allocations          map[string]*si.Allocation
+    preemptedAllocations map[string]*si.AllocationReleaseResponse
+    movedApplications    map[string]string
+    rejectedMoves        map[string]string
 
     lock sync.RWMutex
 }
 
-func NewMockRMCallbackHandler(t *testing.T) *MockRMCallbackHandler {
+func NewMockRMCallbackHandler(t testing.TB) *MockRMCallbackHandler {
     return &MockRMCallbackHandler{
         t:                    t,
         acceptedApplications: make(map[string]bool),
         rejectedApplications: make(map[string]bool),
+        removedApplications:  make(map[string]bool),
         acceptedNodes:        make(map[string]bool),
         rejectedNodes:        make(map[string]bool),
         nodeAllocations:      make(map[string][]*si.Allocation),
         Allocations:          make(map[string]*si.Allocation),
+        preemptedAllocations: make(map[string]*si.AllocationReleaseResponse),
+        movedApplications:    make(map[string]string),
+        rejectedMoves:        make(map[string]string),
     }
 }
 
@@ -70,6 +78,19 @@ func (m *MockRMCallbackHandler) RecvUpdateResponse(response *si.UpdateResponse)
         m.rejectedApplications[app.ApplicationId] = true
     }
 
+    for _, app := range response.RemovedApplications {
+        m.removedApplications[app.ApplicationId] = true
+    }
+
+    for _, app := range response.MovedApplications {
+        m.movedApplications[app.ApplicationId] = app.QueueName
+        delete(m.rejectedMoves, app.ApplicationId)
+    }
+
+    for _, app := range response.RejectedApplicationMoves {
+        m.rejectedMoves[app.ApplicationId] = app.Reason
+    }
+
     for _, node := range response.AcceptedNodes {
         m.acceptedNodes[node.NodeId] = true
     }
@@ -91,6 +112,11 @@ func (m *MockRMCallbackHandler) RecvUpdateResponse(response *si.UpdateResponse)
     }
 
     for _, alloc := range response.ReleasedAllocations {
+        // preempted allocations are released when the RM has terminated them
+        if alloc.TerminationType == si.AllocationReleaseResponse_PREEMPTED_BY_SCHEDULER {
+            m.preemptedAllocations[alloc.Uuid] = alloc
+            continue
+        }
         delete(m.Allocations, alloc.Uuid)
     }
 
@@ -129,6 +155,27 @@ func waitForAcceptedApplications(m *MockRMCallbackHandler, appId string, timeout
     }
 }
 
+func waitForRemovedApplications(m *MockRMCallbackHandler, appId string, timeoutMs int) {
+    var i = 0
+    for {
+        i++
+
+        m.lock.RLock()
+        removed := m.removedApplications[appId]
+        m.lock.RUnlock()
+
+        if !removed {
+            time.Sleep(time.Duration(100 * time.Millisecond))
+        } else {
+            return
+        }
+        if i*100 >= timeoutMs {
+            m.t.Fatalf("Failed to wait RemovedApplications: %s", appId)
+            return
+        }
+    }
+}
+
 func waitForRejectedApplications(m *MockRMCallbackHandler, appId string, timeoutMs int) {
     var i = 0
     for {
@@ -150,6 +197,32 @@ func waitForRejectedApplications(m *MockRMCallbackHandler, appId string, timeout
     }
 }
 
+func waitForMovedApplication(m *MockRMCallbackHandler, appId, queueName string, timeoutMs int) {
+    for i := 0; i*100 < timeoutMs; i++ {
+        m.lock.RLock()
+        moved := m.movedApplications[appId] == queueName
+        m.lock.RUnlock()
+        if moved {
+            return
+        }
+        time.Sleep(100 * time.Millisecond)
+    }
+    m.t.Fatalf("Failed to wait for application %s to be moved to %s", appId, queueName)
+}
+
+func waitForRejectedMove(m *MockRMCallbackHandler, appId string, timeoutMs int) {
+    for i := 0; i*100 < timeoutMs; i++ {
+        m.lock.RLock()
+        _, rejected := m.rejectedMoves[appId]
+        m.lock.RUnlock()
+        if rejected {
+            return
+        }
+        time.Sleep(100 * time.Millisecond)
+    }
+    m.t.Fatalf("Failed to wait for the move of application %s to be rejected", appId)
+}
+
 func waitForAcceptedNodes(m *MockRMCallbackHandler, nodeId string, timeoutMs int) {
     var i = 0
     for {
@@ -208,7 +281,7 @@ func waitForPendingResource(t *testing.T, queue *scheduler.SchedulingQueue, memo
     }
 }
 
-func waitForPendingResourceForApplication(t *testing.T, app *scheduler.SchedulingApplication, memory resources.Quantity, timeoutMs int) {
+func waitForPendingResourceForApplication(t testing.TB, app *scheduler.SchedulingApplication, memory resources.Quantity, timeoutMs int) {
     var i = 0
     for {
         i++
@@ -245,6 +318,54 @@ func waitForAllocations(m *MockRMCallbackHandler, nAlloc int, timeoutMs int) {
     }
 }
 
+func waitForApplicationAllocations(m *MockRMCallbackHandler, appId string, nAlloc int, timeoutMs int) {
+    var i = 0
+    for {
+        i++
+        allocLen := 0
+        for _, alloc := range m.getAllocations() {
+            if alloc.ApplicationId == appId {
+                allocLen++
+            }
+        }
+        if allocLen == nAlloc {
+            return
+        }
+        time.Sleep(time.Duration(100 * time.Millisecond))
+        if i*100 >= timeoutMs {
+            m.t.Fatalf("Failed to wait Allocations for application %s expected %d, got %d", appId, nAlloc, allocLen)
+            return
+        }
+    }
+}
+
+func (m *MockRMCallbackHandler) getPreemptedAllocations() map[string]*si.AllocationReleaseResponse {
+    m.lock.RLock()
+    defer m.lock.RUnlock()
+
+    preempted := make(map[string]*si.AllocationReleaseResponse)
+    for key, value := range m.preemptedAllocations {
+        preempted[key] = value
+    }
+    return preempted
+}
+
+func waitForPreemptedAllocations(m *MockRMCallbackHandler, nAlloc int, timeoutMs int) {
+    var i = 0
+    for {
+        i++
+        preemptedLen := len(m.getPreemptedAllocations())
+        if preemptedLen == nAlloc {
+            return
+        }
+        time.Sleep(time.Duration(100 * time.Millisecond))
+        if i*100 >= timeoutMs {
+            m.t.Fatalf("Failed to wait preempted allocations expected %d, got %d", nAlloc, preemptedLen)
+            return
+        }
+    }
+}
+
 func waitForNodesAllocatedResource(t *testing.T, cache *cache.ClusterInfo, partitionName string, nodeIds []string, allocatdMemory resources.Quantity, timeoutMs int) {
     var i = 0
     for {
@@ -0,0 +1,116 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package webservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/cloudera/yunikorn-core/pkg/cache"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Settings to authenticate the users of the requests that change the scheduler.
+// Without settings the web service uses plain HTTP and these requests are rejected as not authenticated.
+type AuthConfig struct {
+	// The header an authenticating proxy in front of the web service sets with the name of the user, for example
+	// X-Remote-User. The web service must only be reachable via the proxy: anyone can set the header.
+	// Empty means the header is ignored.
+	ProxyHeader string
+	// The certificate and key of the web service, the web service uses TLS when both are set.
+	CertFile string
+	KeyFile  string
+	// The CA that signs the client certificates, the common name of a verified client certificate is the user.
+	// Only used with TLS.
+	ClientCAFile string
+}
+
+// Does the web service use TLS?
+func (auth AuthConfig) useTLS() bool {
+	return auth.CertFile != "" && auth.KeyFile != ""
+}
+
+// Get the TLS settings of the web service. Client certificates are verified against the client CA when they are
+// given: requests that only read from the scheduler do not need a client certificate.
+func (auth AuthConfig) getTLSConfig() (*tls.Config, error) {
+	config := &tls.Config{}
+	if auth.ClientCAFile == "" {
+		return config, nil
+	}
+	caPEM, err := ioutil.ReadFile(auth.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in the client CA file %s", auth.ClientCAFile)
+	}
+	config.ClientCAs = clientCAs
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+	return config, nil
+}
+
+// Key of the authenticated user name in the request context.
+type authenticatedUserKey struct{}
+
+// Authenticate the user of the request and pass the name on to the inner handler in the request context.
+// The user is never taken from the request body.
+func Authenticator(inner http.Handler, auth AuthConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userName := getAuthenticatedUserName(r, auth.ProxyHeader); userName != "" {
+			r = r.WithContext(context.WithValue(r.Context(), authenticatedUserKey{}, userName))
+		}
+		inner.ServeHTTP(w, r)
+	})
+}
+
+// Get the name of the authenticated user of the request, returns an empty string if the request is not authenticated.
+// The user is taken from the verified TLS client certificate, or from the trusted proxy header when there is no
+// client certificate.
+func getAuthenticatedUserName(r *http.Request, proxyHeader string) string {
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		return r.TLS.VerifiedChains[0][0].Subject.CommonName
+	}
+	if proxyHeader != "" {
+		return strings.TrimSpace(r.Header.Get(proxyHeader))
+	}
+	return ""
+}
+
+// Check that the authenticated user of the request has admin access on all the queues.
+// The groups of the user are always resolved by the partition. The error response is written if the check fails.
+func checkAdminAccess(w http.ResponseWriter, r *http.Request, partition *cache.PartitionInfo, queues ...*cache.QueueInfo) bool {
+	userName, _ := r.Context().Value(authenticatedUserKey{}).(string)
+	if userName == "" {
+		http.Error(w, "request is not authenticated", http.StatusUnauthorized)
+		return false
+	}
+	user, err := partition.GetUserGroup(userName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return false
+	}
+	for _, queue := range queues {
+		if queue == nil || !queue.CheckAdminAccess(user) {
+			http.Error(w, "admin access denied on queue", http.StatusForbidden)
+			return false
+		}
+	}
+	return true
+}
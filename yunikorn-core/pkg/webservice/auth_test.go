@@ -0,0 +1,155 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package webservice
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/cloudera/yunikorn-core/pkg/cache"
+	"github.com/cloudera/yunikorn-core/pkg/common/configs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+const authTestConfig = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        adminacl: admin
+        queues:
+          - name: leaf
+          - name: restricted
+            adminacl: nobody
+`
+
+// Run the access check behind the authenticator with the given settings.
+func runAdminAccessCheck(auth AuthConfig, request *http.Request, partition *cache.PartitionInfo, queues ...*cache.QueueInfo) (bool, int) {
+	allowed := false
+	recorder := httptest.NewRecorder()
+	Authenticator(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed = checkAdminAccess(w, r, partition, queues...)
+	}), auth).ServeHTTP(recorder, request)
+	return allowed, recorder.Code
+}
+
+func TestCheckAdminAccess(t *testing.T) {
+	partition, err := cache.CreatePartitionInfo([]byte(authTestConfig))
+	if err != nil {
+		t.Fatalf("partition create failed: %v", err)
+	}
+	leaf := partition.GetQueue("root.leaf")
+	trustProxy := AuthConfig{ProxyHeader: "X-Remote-User"}
+
+	// the user in the body is never used, the proxy header is ignored when it is not trusted
+	request := httptest.NewRequest("PUT", "/ws/v1/partition/default/queue/root.leaf/state",
+		strings.NewReader(`{"state": "stop", "user": "admin", "groups": ["admin"]}`))
+	request.Header.Set("X-Remote-User", "admin")
+	if allowed, code := runAdminAccessCheck(AuthConfig{}, request, partition, leaf); allowed || code != http.StatusUnauthorized {
+		t.Errorf("request without authentication should have been rejected, status %d", code)
+	}
+
+	// the proxy header is used when it is trusted
+	if allowed, code := runAdminAccessCheck(trustProxy, request, partition, leaf); !allowed {
+		t.Errorf("admin authenticated by the proxy should have been allowed, status %d", code)
+	}
+	request.Header.Set("X-Remote-User", "other")
+	if allowed, code := runAdminAccessCheck(trustProxy, request, partition, leaf); allowed || code != http.StatusForbidden {
+		t.Errorf("user without admin access should have been denied, status %d", code)
+	}
+
+	// a verified client certificate takes precedence over the proxy header
+	request.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "admin"}}}},
+	}
+	if allowed, code := runAdminAccessCheck(trustProxy, request, partition, leaf); !allowed {
+		t.Errorf("admin authenticated by a client certificate should have been allowed, status %d", code)
+	}
+	if allowed, _ := runAdminAccessCheck(trustProxy, request, partition, leaf, nil); allowed {
+		t.Errorf("access to a missing queue should have been denied")
+	}
+	// a move needs admin access on both queues
+	if allowed, code := runAdminAccessCheck(trustProxy, request, partition, leaf, partition.GetQueue("root.restricted")); allowed || code != http.StatusForbidden {
+		t.Errorf("user without admin access on the target queue should have been denied, status %d", code)
+	}
+}
+
+func TestChangeQueueStateAuthenticated(t *testing.T) {
+	configs.MockSchedulerConfigByData([]byte(authTestConfig))
+	clusterInfo, _ := cache.NewClusterInfo()
+	if _, err := cache.SetClusterInfoFromConfigFile(clusterInfo, "rm-123", "default-policy-group"); err != nil {
+		t.Fatalf("cluster create failed: %v", err)
+	}
+	webApp := NewWebApp(clusterInfo, AuthConfig{ProxyHeader: "X-Remote-User"})
+	router := NewRouter(clusterInfo, webApp.auth)
+	changeState := func(user, state string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("PUT", "/ws/v1/partition/default/queue/root.leaf/state",
+			strings.NewReader(`{"state": "`+state+`"}`))
+		if user != "" {
+			request.Header.Set("X-Remote-User", user)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if recorder := changeState("", "stop"); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("request without the proxy header should have been rejected, status %d", recorder.Code)
+	}
+	if recorder := changeState("other", "stop"); recorder.Code != http.StatusForbidden {
+		t.Errorf("user without admin access should have been denied, status %d", recorder.Code)
+	}
+	if recorder := changeState("admin", "stop"); recorder.Code != http.StatusOK {
+		t.Errorf("admin should have stopped the queue, status %d: %s", recorder.Code, recorder.Body.String())
+	}
+	leaf := clusterInfo.GetPartition("[rm-123]default").GetQueue("root.leaf")
+	if !leaf.IsStopped() {
+		t.Errorf("queue should have been stopped")
+	}
+
+	// the header is not trusted without the setting
+	router = NewRouter(clusterInfo, AuthConfig{})
+	if recorder := changeState("admin", "start"); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("proxy header should not have been trusted, status %d", recorder.Code)
+	}
+}
+
+func TestGetTLSConfig(t *testing.T) {
+	config, err := AuthConfig{CertFile: "cert.pem", KeyFile: "key.pem"}.getTLSConfig()
+	if err != nil || config.ClientAuth != tls.NoClientCert {
+		t.Errorf("TLS without a client CA should not verify client certificates: %v", err)
+	}
+	if _, err = (AuthConfig{ClientCAFile: "missing-ca.pem"}).getTLSConfig(); err == nil {
+		t.Errorf("missing client CA file should have failed")
+	}
+	caFile, err := ioutil.TempFile("", "client-ca")
+	if err != nil {
+		t.Fatalf("temp file create failed: %v", err)
+	}
+	defer os.Remove(caFile.Name())
+	if _, err = caFile.WriteString("not a certificate"); err != nil {
+		t.Fatalf("temp file write failed: %v", err)
+	}
+	caFile.Close()
+	if _, err = (AuthConfig{ClientCAFile: caFile.Name()}).getTLSConfig(); err == nil {
+		t.Errorf("client CA file without certificates should have failed")
+	}
+}
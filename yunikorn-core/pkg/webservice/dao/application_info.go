@@ -28,6 +28,18 @@ type ApplicationDAOInfo struct {
 	SubmissionTime int64               `json:"submissionTime"`
 	Allocations    []AllocationDAOInfo `json:"allocations"`
 	State          string              `json:"applicationState"`
+	StateLog       []StateDAOInfo      `json:"stateLog"`
+	Placement      *PlacementDAOInfo   `json:"placement,omitempty"`
+}
+
+// The requested move of an application to a different queue.
+type ApplicationMoveDAOInfo struct {
+	Queue string `json:"queue"`
+}
+
+type StateDAOInfo struct {
+	Time             int64  `json:"time"`
+	ApplicationState string `json:"applicationState"`
 }
 
 type AllocationDAOInfo struct {
@@ -0,0 +1,32 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package dao
+
+type EventDAOInfo struct {
+	ObjectType    string `json:"objectType"`
+	ObjectId      string `json:"objectID"`
+	Partition     string `json:"partition,omitempty"`
+	ApplicationId string `json:"applicationID,omitempty"`
+	QueueName     string `json:"queueName,omitempty"`
+	NodeId        string `json:"nodeID,omitempty"`
+	Reason        string `json:"reason"`
+	Message       string `json:"message"`
+	FirstTime     int64  `json:"firstTime"`
+	LastTime      int64  `json:"lastTime"`
+	Count         int    `json:"count"`
+}
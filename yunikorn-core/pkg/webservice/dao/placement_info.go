@@ -0,0 +1,40 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dao
+
+// The application to run the placement rules for in a placement simulation.
+type PlacementSimulationDAOInfo struct {
+	User   string            `json:"user"`
+	Groups []string          `json:"groups,omitempty"`
+	Queue  string            `json:"queue,omitempty"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+type PlacementDAOInfo struct {
+	QueueName string                 `json:"queueName"`
+	Rule      string                 `json:"rule"`
+	Error     string                 `json:"error,omitempty"`
+	Trace     []PlacementRuleDAOInfo `json:"trace"`
+}
+
+type PlacementRuleDAOInfo struct {
+	Rule      string `json:"rule"`
+	QueueName string `json:"queueName,omitempty"`
+	Result    string `json:"result"`
+	Message   string `json:"message,omitempty"`
+	NewQueue  bool   `json:"newQueue"`
+}
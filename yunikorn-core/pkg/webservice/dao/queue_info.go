@@ -16,10 +16,22 @@ limitations under the License.
 package dao
 
 type QueueDAOInfo struct {
-	QueueName   string         `json:"queuename"`
-	Status      string         `json:"status"`
-	Capacities  QueueCapacity  `json:"capacities"`
-	ChildQueues []QueueDAOInfo `json:"queues"`
+	QueueName   string           `json:"queuename"`
+	Status      string           `json:"status"`
+	Capacities  QueueCapacity    `json:"capacities"`
+	OverMax     bool             `json:"overmax"`
+	Permissions QueuePermissions `json:"permissions"`
+	ChildQueues []QueueDAOInfo   `json:"queues"`
+}
+
+// The requested state change for a queue, the state is one of start, stop or drain.
+type QueueStateChangeDAOInfo struct {
+	State string `json:"state"`
+}
+
+type QueueStateDAOInfo struct {
+	QueueName string `json:"queuename"`
+	Status    string `json:"status"`
 }
 
 type QueueCapacity struct {
@@ -27,4 +39,19 @@ type QueueCapacity struct {
 	MaxCapacity     string `json:"maxcapacity"`
 	UsedCapacity    string `json:"usedcapacity"`
 	AbsUsedCapacity string `json:"absusedcapacity"`
+	FairShare       string `json:"fairshare"`
+}
+
+// The effective permissions of a queue: the ACLs of the queue narrowed by the ACLs of all its parents.
+type QueuePermissions struct {
+	Submit EffectiveACL `json:"submit"`
+	Admin  EffectiveACL `json:"admin"`
+}
+
+type EffectiveACL struct {
+	AllowAll     bool     `json:"allowall"`
+	Users        []string `json:"users,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	DeniedUsers  []string `json:"deniedusers,omitempty"`
+	DeniedGroups []string `json:"deniedgroups,omitempty"`
 }
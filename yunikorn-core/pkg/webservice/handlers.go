@@ -18,7 +18,15 @@ package webservice
 import (
 	"encoding/json"
 	"github.com/cloudera/yunikorn-core/pkg/cache"
+	"github.com/cloudera/yunikorn-core/pkg/common"
+	"github.com/cloudera/yunikorn-core/pkg/common/commonevents"
+	"github.com/cloudera/yunikorn-core/pkg/common/security"
+	"github.com/cloudera/yunikorn-core/pkg/events"
+	"github.com/cloudera/yunikorn-core/pkg/scheduler/placement"
+	"github.com/cloudera/yunikorn-core/pkg/scheduler/schedulerevent"
 	"github.com/cloudera/yunikorn-core/pkg/webservice/dao"
+	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
+	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
 	"strings"
@@ -71,11 +79,209 @@ func GetApplicationsInfo(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Get the events recorded for the object in the query, all events are returned if no object is given.
+// The object must be given as type:id, for example app:application-1, ask, alloc, node and queue are the other types.
+// The optional partition limits the events to the partition, it can be given with or without the RM prefix.
+func GetEvents(w http.ResponseWriter, r *http.Request) {
+	var partitionName, objectType, objectId string
+	if name := r.URL.Query().Get("partition"); name != "" {
+		partition := findPartition(name)
+		if partition == nil {
+			http.Error(w, "partition not found", http.StatusNotFound)
+			return
+		}
+		partitionName = partition.Name
+	}
+	if object := r.URL.Query().Get("object"); object != "" {
+		var err error
+		if objectType, objectId, err = events.ParseObject(object); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	writeHeaders(w)
+
+	eventsDao := make([]dao.EventDAOInfo, 0)
+	for _, event := range events.GetEventStore().GetEvents(partitionName, objectType, objectId) {
+		eventsDao = append(eventsDao, dao.EventDAOInfo{
+			ObjectType:    event.ObjectType,
+			ObjectId:      event.ObjectId,
+			Partition:     event.Partition,
+			ApplicationId: event.ApplicationId,
+			QueueName:     event.QueueName,
+			NodeId:        event.NodeId,
+			Reason:        event.Reason,
+			Message:       event.Message,
+			FirstTime:     event.FirstTime.UnixNano(),
+			LastTime:      event.LastTime.UnixNano(),
+			Count:         event.Count,
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(eventsDao); err != nil {
+		panic(err)
+	}
+}
+
+// Run the placement rules of the partition for the application in the request body and return the trace.
+// The rules are run against the current queues of the partition: no queues are created and nothing is placed.
+// The partition can be given with or without the RM prefix.
+func SimulatePlacement(w http.ResponseWriter, r *http.Request) {
+	partition := findPartition(mux.Vars(r)["partition"])
+	if partition == nil {
+		http.Error(w, "partition not found", http.StatusNotFound)
+		return
+	}
+	var request dao.PlacementSimulationDAOInfo
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if request.User == "" {
+		http.Error(w, "user must be set", http.StatusBadRequest)
+		return
+	}
+	user := security.UserGroup{
+		User:   request.User,
+		Groups: request.Groups,
+	}
+	tags := request.Tags
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	app := cache.NewApplicationInfo("simulate", partition.Name, request.Queue, user, tags)
+	decision, err := placement.NewPlacementManager(partition).SimulatePlacement(app)
+	if decision == nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	placementDao := getPlacementJson(decision)
+	if err != nil {
+		placementDao.Error = err.Error()
+	}
+	writeHeaders(w)
+
+	if err := json.NewEncoder(w).Encode(placementDao); err != nil {
+		panic(err)
+	}
+}
+
+// Change the state of the queue: stop, drain or start the queue.
+// The authenticated user of the request must have admin access to the queue.
+func ChangeQueueState(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	partition := findPartition(vars["partition"])
+	if partition == nil {
+		http.Error(w, "partition not found", http.StatusNotFound)
+		return
+	}
+	queue := partition.GetQueue(vars["queue"])
+	if queue == nil {
+		http.Error(w, "queue not found", http.StatusNotFound)
+		return
+	}
+	var request dao.QueueStateChangeDAOInfo
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var event cache.SchedulingObjectEvent
+	switch strings.ToLower(request.State) {
+	case "start":
+		event = cache.Start
+	case "stop":
+		event = cache.Stop
+	case "drain":
+		event = cache.Drain
+	default:
+		http.Error(w, "state must be one of start, stop or drain", http.StatusBadRequest)
+		return
+	}
+	if !checkAdminAccess(w, r, partition, queue) {
+		return
+	}
+	if err := queue.ChangeState(event); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeHeaders(w)
+
+	stateDao := dao.QueueStateDAOInfo{
+		QueueName: queue.GetQueuePath(),
+		Status:    cache.GetQueueStatus(queue),
+	}
+	if err := json.NewEncoder(w).Encode(stateDao); err != nil {
+		panic(err)
+	}
+}
+
+// Move an application to a different leaf queue in the same partition.
+// The authenticated user of the request must have admin access on the current queue and the target queue of the
+// application.
+func MoveApplication(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	partition := findPartition(vars["partition"])
+	if partition == nil {
+		http.Error(w, "partition not found", http.StatusNotFound)
+		return
+	}
+	app := partition.GetApplication(vars["application"])
+	if app == nil {
+		http.Error(w, "application not found", http.StatusNotFound)
+		return
+	}
+	var request dao.ApplicationMoveDAOInfo
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	target := partition.GetQueue(request.Queue)
+	if target == nil {
+		http.Error(w, "queue not found", http.StatusNotFound)
+		return
+	}
+	if !checkAdminAccess(w, r, partition, partition.GetQueue(app.QueueName), target) {
+		return
+	}
+	// the scheduler moves the application in the cache and the scheduler
+	result := make(chan *commonevents.Result)
+	gClusterInfo.EventHandlers.SchedulerEventHandler.HandleEvent(&schedulerevent.SchedulerMoveApplicationEvent{
+		Request: &si.MoveApplicationRequest{
+			ApplicationId: app.ApplicationId,
+			PartitionName: partition.Name,
+			QueueName:     target.GetQueuePath(),
+		},
+		ResultChannel: result,
+	})
+	if moved := <-result; !moved.Succeeded {
+		http.Error(w, moved.Reason, http.StatusConflict)
+		return
+	}
+	writeHeaders(w)
+
+	if err := json.NewEncoder(w).Encode(getApplicationJson(app)); err != nil {
+		panic(err)
+	}
+}
+
+// Find the partition by its full name or by the name without the RM prefix.
+func findPartition(name string) *cache.PartitionInfo {
+	if partition := gClusterInfo.GetPartition(name); partition != nil {
+		return partition
+	}
+	for _, fullName := range gClusterInfo.ListPartitions() {
+		if common.GetPartitionNameWithoutClusterId(fullName) == name {
+			return gClusterInfo.GetPartition(fullName)
+		}
+	}
+	return nil
+}
+
 func writeHeaders(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
-	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,HEAD,OPTIONS")
+	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,HEAD,OPTIONS")
 	w.Header().Set("Access-Control-Allow-Headers", "X-Requested-With,Content-Type,Accept,Origin")
 	w.WriteHeader(http.StatusOK)
 }
@@ -129,6 +335,19 @@ func getApplicationJson(app *cache.ApplicationInfo) *dao.ApplicationDAOInfo {
 		allocationInfos = append(allocationInfos, allocInfo)
 	}
 
+	var stateInfos []dao.StateDAOInfo
+	for _, entry := range app.GetStateLog() {
+		stateInfos = append(stateInfos, dao.StateDAOInfo{
+			Time:             entry.Time.UnixNano(),
+			ApplicationState: entry.ApplicationState,
+		})
+	}
+
+	var placementDao *dao.PlacementDAOInfo
+	if decision := app.GetPlacementDecision(); decision != nil {
+		placementDao = getPlacementJson(decision)
+	}
+
 	return &dao.ApplicationDAOInfo{
 		ApplicationId:  app.ApplicationId,
 		UsedResource:   strings.Trim(app.GetAllocatedResource().String(), "map"),
@@ -137,5 +356,25 @@ func getApplicationJson(app *cache.ApplicationInfo) *dao.ApplicationDAOInfo {
 		SubmissionTime: app.SubmissionTime,
 		Allocations:    allocationInfos,
 		State:          app.GetApplicationState(),
+		StateLog:       stateInfos,
+		Placement:      placementDao,
+	}
+}
+
+func getPlacementJson(decision *cache.PlacementDecision) *dao.PlacementDAOInfo {
+	traceInfos := make([]dao.PlacementRuleDAOInfo, 0, len(decision.Trace))
+	for _, trace := range decision.Trace {
+		traceInfos = append(traceInfos, dao.PlacementRuleDAOInfo{
+			Rule:      trace.Rule,
+			QueueName: trace.Queue,
+			Result:    trace.Result,
+			Message:   trace.Message,
+			NewQueue:  trace.NewQueue,
+		})
+	}
+	return &dao.PlacementDAOInfo{
+		QueueName: decision.Queue,
+		Rule:      decision.Rule,
+		Trace:     traceInfos,
 	}
 }
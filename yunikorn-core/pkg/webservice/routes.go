@@ -45,4 +45,28 @@ var routes = Routes{
 		"/ws/v1/apps",
 		GetApplicationsInfo,
 	},
+	Route{
+		"Scheduler",
+		"GET",
+		"/ws/v1/events",
+		GetEvents,
+	},
+	Route{
+		"Scheduler",
+		"POST",
+		"/ws/v1/partition/{partition}/placement/simulate",
+		SimulatePlacement,
+	},
+	Route{
+		"Scheduler",
+		"PUT",
+		"/ws/v1/partition/{partition}/queue/{queue}/state",
+		ChangeQueueState,
+	},
+	Route{
+		"Scheduler",
+		"PUT",
+		"/ws/v1/partition/{partition}/application/{application}/queue",
+		MoveApplication,
+	},
 }
@@ -32,16 +32,18 @@ var gClusterInfo *cache.ClusterInfo
 type WebService struct {
 	httpServer  *http.Server
 	clusterInfo *cache.ClusterInfo
+	auth        AuthConfig
 	lock        sync.RWMutex
 }
 
-func NewRouter(info *cache.ClusterInfo) *mux.Router {
+func NewRouter(info *cache.ClusterInfo, auth AuthConfig) *mux.Router {
 
 	router := mux.NewRouter().StrictSlash(true)
 	for _, route := range routes {
 		var handler http.Handler
 
 		handler = route.HandlerFunc
+		handler = Authenticator(handler, auth)
 		handler = Logger(handler, route.Name, info)
 
 		router.
@@ -66,12 +68,27 @@ func Logger(inner http.Handler, name string, info *cache.ClusterInfo) http.Handl
 }
 
 func (m *WebService) StartWebApp() {
-	router := NewRouter(m.clusterInfo)
+	router := NewRouter(m.clusterInfo, m.auth)
 	m.httpServer = &http.Server{Addr: ":9080", Handler: router}
+	if m.auth.useTLS() {
+		tlsConfig, err := m.auth.getTLSConfig()
+		if err != nil {
+			log.Logger().Error("web-app not started, TLS configuration failed",
+				zap.Error(err))
+			m.httpServer = nil
+			return
+		}
+		m.httpServer.TLSConfig = tlsConfig
+	}
 
-	log.Logger().Info("web-app started", zap.Int("port", 9080))
+	log.Logger().Info("web-app started", zap.Int("port", 9080), zap.Bool("tls", m.auth.useTLS()))
 	go func() {
-		httpError := m.httpServer.ListenAndServe()
+		var httpError error
+		if m.auth.useTLS() {
+			httpError = m.httpServer.ListenAndServeTLS(m.auth.CertFile, m.auth.KeyFile)
+		} else {
+			httpError = m.httpServer.ListenAndServe()
+		}
 		if httpError != nil && httpError != http.ErrServerClosed{
 			log.Logger().Error("HTTP serving error",
 				zap.Error(httpError))
@@ -79,8 +96,13 @@ func (m *WebService) StartWebApp() {
 	}()
 }
 
-func NewWebApp(clusterInfo *cache.ClusterInfo) *WebService {
-	m := &WebService{}
+// Create the web service for the cluster, the users of the requests that change the scheduler are authenticated
+// with the given settings.
+func NewWebApp(clusterInfo *cache.ClusterInfo, auth AuthConfig) *WebService {
+	m := &WebService{
+		clusterInfo: clusterInfo,
+		auth:        auth,
+	}
 	gClusterInfo = clusterInfo
 	return m
 }
@@ -140,6 +140,30 @@ func (app *Application) GetTask(taskId string) (*Task, error) {
 		taskId, app.applicationId)
 }
 
+// get the task that holds the allocation, nil if no task holds the allocation
+func (app *Application) getTaskByAllocationUuid(allocationUuid string) *Task {
+	app.lock.RLock()
+	defer app.lock.RUnlock()
+	for _, task := range app.taskMap {
+		if task.getAllocationUuid() == allocationUuid {
+			return task
+		}
+	}
+	return nil
+}
+
+// check if the application has tasks that wait for the backoff of a retry
+func (app *Application) hasRetryingTasks() bool {
+	app.lock.RLock()
+	defer app.lock.RUnlock()
+	for _, task := range app.taskMap {
+		if task.isRetrying() {
+			return true
+		}
+	}
+	return false
+}
+
 func (app *Application) GetApplicationId() string {
 	app.lock.RLock()
 	defer app.lock.RUnlock()
@@ -152,6 +176,13 @@ func (app *Application) GetQueue() string {
 	return app.queue
 }
 
+// Update the queue after the scheduler moved the application to a different queue.
+func (app *Application) SetQueue(queue string) {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	app.queue = queue
+}
+
 func (app *Application) AddTask(task *Task) {
 	app.lock.Lock()
 	defer app.lock.Unlock()
@@ -218,6 +249,31 @@ func (app *Application) handleSubmitApplicationEvent(event *fsm.Event) {
 	}
 }
 
+// Submit the application to the scheduler again after the scheduler removed it while tasks wait for a retry.
+// The retried tasks are resubmitted to the application when their backoff has passed.
+func (app *Application) Resubmit() error {
+	app.lock.RLock()
+	defer app.lock.RUnlock()
+	log.Logger.Info("resubmit app with retrying tasks",
+		zap.String("app", app.String()),
+		zap.String("clusterId", conf.GetSchedulerConf().ClusterId))
+	return app.schedulerApi.Update(
+		&si.UpdateRequest{
+			NewApplications: []*si.AddApplicationRequest{
+				{
+					ApplicationId: app.applicationId,
+					QueueName:     app.queue,
+					PartitionName: app.partition,
+					Ugi: &si.UserGroupInformation{
+						User: app.user,
+					},
+					Tags: app.tags,
+				},
+			},
+			RmId: conf.GetSchedulerConf().ClusterId,
+		})
+}
+
 func (app *Application) handleRecoverApplicationEvent(event *fsm.Event) {
 	log.Logger.Info("handle app recovering",
 		zap.String("app", app.String()),
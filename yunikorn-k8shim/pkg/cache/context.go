@@ -28,6 +28,7 @@ import (
 	"github.com/cloudera/yunikorn-k8shim/pkg/dispatcher"
 	"github.com/cloudera/yunikorn-k8shim/pkg/log"
 	plugin "github.com/cloudera/yunikorn-k8shim/pkg/plugin/predicates"
+	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
 	"go.uber.org/zap"
 	"k8s.io/api/core/v1"
 	"k8s.io/client-go/informers"
@@ -557,6 +558,77 @@ func (ctx *Context) AssumePod(name string, node string) error {
 	return nil
 }
 
+// assume the volumes of a pod on a node, this is called once the core has selected the node for the pod.
+// the volume binder caches the PV/PVC bindings that must be made for the pod when running the volume
+// predicates, assuming them reserves the volumes for the pod in the volume binder's cache.
+func (ctx *Context) AssumePodVolumes(name string, node string) error {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+
+	if pod, ok := ctx.schedulerCache.GetPod(name); ok {
+		assumedPod := pod.DeepCopy()
+		assumedPod.Spec.NodeName = node
+		allBound, err := ctx.volumeBinder.Binder.AssumePodVolumes(assumedPod, node)
+		if err != nil {
+			return err
+		}
+		log.Logger.Debug("pod volumes assumed",
+			zap.String("podName", pod.Name),
+			zap.String("nodeName", node),
+			zap.Bool("allBound", allBound))
+	}
+	return nil
+}
+
+// bind the assumed volumes of a pod, this is called before the allocation is confirmed by the core.
+// the call blocks until the volumes are bound or the volume bind timeout is reached.
+// on failure the assumed pod and its volume bindings are rolled back, the pod will be scheduled again.
+func (ctx *Context) BindPodVolumes(name string, node string) error {
+	// do not hold the lock while binding the volumes, this can take a while
+	pod, ok := ctx.schedulerCache.GetPod(name)
+	if !ok {
+		return nil
+	}
+	assumedPod := pod.DeepCopy()
+	assumedPod.Spec.NodeName = node
+	if err := ctx.volumeBinder.Binder.BindPodVolumes(assumedPod); err != nil {
+		log.Logger.Error("failed to bind pod volumes",
+			zap.String("podName", pod.Name),
+			zap.String("nodeName", node),
+			zap.Error(err))
+		if forgetErr := ctx.schedulerCache.ForgetPod(assumedPod); forgetErr != nil {
+			log.Logger.Debug("failed to forget assumed pod",
+				zap.String("podName", pod.Name),
+				zap.Error(forgetErr))
+		}
+		ctx.volumeBinder.DeletePodBindings(assumedPod)
+		return err
+	}
+	return nil
+}
+
+// forget the assumed volumes of a pod, this is called when the core rejects the allocation of the pod after the
+// volumes were assumed, e.g. when the allocation no longer fits in the queue. the volume bindings cached for the pod
+// are removed and the assumed pod is forgotten, the pod will be scheduled again.
+func (ctx *Context) ForgetPodVolumes(name string) {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+
+	pod, ok := ctx.schedulerCache.GetPod(name)
+	if !ok {
+		return
+	}
+	ctx.volumeBinder.DeletePodBindings(pod)
+	// only an assumed pod can be forgotten, a pod that was not assumed yet stays in the cache
+	if pod.Spec.NodeName != "" {
+		if err := ctx.schedulerCache.ForgetPod(pod); err != nil {
+			log.Logger.Debug("failed to forget assumed pod",
+				zap.String("podName", pod.Name),
+				zap.Error(err))
+		}
+	}
+}
+
 // if app already exists in the context, directly return the app from context
 // if app doesn't exist in the context yet, create a new app instance and add to context
 func (ctx *Context) getOrCreateApplication(pod *v1.Pod) *Application {
@@ -597,6 +669,18 @@ func (ctx *Context) AddApplication(app *Application) {
 	ctx.applications[app.GetApplicationId()] = app
 }
 
+// Remove the application from the context, a new pod for the application adds it again.
+// The application is not removed while it has tasks that wait for a retry: the retry would be lost.
+func (ctx *Context) RemoveApplication(appId string) error {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	if app, ok := ctx.applications[appId]; ok && app.hasRetryingTasks() {
+		return fmt.Errorf("application %s has tasks that wait for a retry", appId)
+	}
+	delete(ctx.applications, appId)
+	return nil
+}
+
 func (ctx *Context) GetApplication(appId string) (*Application, error) {
 	ctx.lock.RLock()
 	defer ctx.lock.RUnlock()
@@ -617,6 +701,81 @@ func (ctx *Context) getTask(appId string, taskId string) (*Task, error) {
 	return nil, fmt.Errorf("application %s is not found in context", appId)
 }
 
+// Preempt the allocation: the pod of the task that holds the allocation is deleted with the grace period.
+// The allocation is released to the scheduler when the pod is removed, this confirms the preemption.
+func (ctx *Context) PreemptAllocation(allocationUuid string, gracePeriodSeconds int64) error {
+	ctx.lock.RLock()
+	defer ctx.lock.RUnlock()
+	for _, app := range ctx.applications {
+		if task := app.getTaskByAllocationUuid(allocationUuid); task != nil {
+			pod := task.GetTaskPod()
+			log.Logger.Info("preempting pod",
+				zap.String("namespace", pod.Namespace),
+				zap.String("podName", pod.Name),
+				zap.String("allocationUuid", allocationUuid),
+				zap.Int64("gracePeriodSeconds", gracePeriodSeconds))
+			return ctx.kubeClient.DeleteWithGracePeriod(pod, gracePeriodSeconds)
+		}
+	}
+	return fmt.Errorf("allocation %s is not found in context", allocationUuid)
+}
+
+// Reasons of the scheduler events that are published as warnings, all other events are normal events.
+var schedulerWarningReasons = map[string]bool{
+	"ApplicationRejected": true,
+	"AskSkippedHeadroom":  true,
+	"PredicateFailed":     true,
+	"AllocationRejected":  true,
+	"AllocationPreempted": true,
+}
+
+// Publish an event from the scheduler on the pods it relates to.
+// Request events are published on the pod of the task, allocation events on the pod that holds the allocation
+// and application events on all pending pods of the application.
+// Node and queue events have no related pod and are only logged.
+func (ctx *Context) PublishSchedulerEvent(record *si.EventRecord) {
+	eventType := v1.EventTypeNormal
+	if schedulerWarningReasons[record.Reason] {
+		eventType = v1.EventTypeWarning
+	}
+	message := record.Message
+	if record.Count > 1 {
+		message = fmt.Sprintf("%s (x%d)", message, record.Count)
+	}
+	switch record.Type {
+	case si.EventRecord_REQUEST:
+		app, err := ctx.GetApplication(record.ApplicationId)
+		if err != nil {
+			return
+		}
+		if task, err := app.GetTask(record.ObjectId); err == nil {
+			events.GetRecorder().Event(task.GetTaskPod(), eventType, record.Reason, message)
+		}
+	case si.EventRecord_ALLOCATION:
+		app, err := ctx.GetApplication(record.ApplicationId)
+		if err != nil {
+			return
+		}
+		if task := app.getTaskByAllocationUuid(record.ObjectId); task != nil {
+			events.GetRecorder().Event(task.GetTaskPod(), eventType, record.Reason, message)
+		}
+	case si.EventRecord_APP:
+		app, err := ctx.GetApplication(record.ObjectId)
+		if err != nil {
+			return
+		}
+		for _, task := range app.GetPendingTasks() {
+			events.GetRecorder().Event(task.GetTaskPod(), eventType, record.Reason, message)
+		}
+	default:
+		log.Logger.Debug("scheduler event",
+			zap.Stringer("type", record.Type),
+			zap.String("objectId", record.ObjectId),
+			zap.String("reason", record.Reason),
+			zap.String("message", message))
+	}
+}
+
 func (ctx *Context) SelectApplications(filter func(app *Application) bool) []*Application {
 	ctx.lock.RLock()
 	defer ctx.lock.RUnlock()
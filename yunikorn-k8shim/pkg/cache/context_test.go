@@ -17,13 +17,19 @@ limitations under the License.
 package cache
 
 import (
+	"fmt"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common/events"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common/test"
 	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
 	"github.com/cloudera/yunikorn-k8shim/pkg/dispatcher"
+	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
 	"gotest.tools/assert"
 	"k8s.io/api/core/v1"
 	apis "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller/volume/persistentvolume"
+	"k8s.io/kubernetes/pkg/scheduler/volumebinder"
+	"sync"
 	"testing"
 	"time"
 )
@@ -60,11 +66,49 @@ func TestAddApplications(t *testing.T) {
 
 	task01 := CreateTaskForTest("task00001", app01, nil, nil, nil)
 	task02 := CreateTaskForTest("task00002", app01, nil, nil, nil)
-	app01.AddTask(&task01)
-	app01.AddTask(&task02)
+	app01.AddTask(task01)
+	app01.AddTask(task02)
 	assert.Equal(t, len(context.applications["app00001"].GetPendingTasks()), 2)
 }
 
+func TestRemoveApplication(t *testing.T) {
+	context := initContextForTest()
+	app01 := NewApplication("app00001", "root.a", "testuser", map[string]string{}, nil)
+	context.AddApplication(app01)
+	app02 := NewApplication("app00002", "root.a", "testuser", map[string]string{}, nil)
+	context.AddApplication(app02)
+	assert.Equal(t, len(context.applications), 2)
+
+	err := context.RemoveApplication("app00001")
+	assert.NilError(t, err)
+	assert.Equal(t, len(context.applications), 1)
+	_, err = context.GetApplication("app00001")
+	assert.Assert(t, err != nil)
+	_, err = context.GetApplication("app00002")
+	assert.NilError(t, err)
+
+	// removing an unknown application is a no-op
+	err = context.RemoveApplication("app00003")
+	assert.NilError(t, err)
+	assert.Equal(t, len(context.applications), 1)
+
+	// an application with a task that waits for a retry is not removed
+	task := CreateTaskForTest("task00001", app02, nil, nil, nil)
+	app02.AddTask(task)
+	task.retrying = true
+	err = context.RemoveApplication("app00002")
+	assert.Assert(t, err != nil, "application with a retrying task must not be removed")
+	_, err = context.GetApplication("app00002")
+	assert.NilError(t, err)
+
+	// the retry moves the task back to pending: the application can be removed
+	task.handleRetryTaskEvent(nil)
+	assert.Assert(t, !task.isRetrying())
+	err = context.RemoveApplication("app00002")
+	assert.NilError(t, err)
+	assert.Equal(t, len(context.applications), 0)
+}
+
 func TestAddPod(t *testing.T) {
 	context := initContextForTest()
 
@@ -229,8 +273,9 @@ func TestPodRejected(t *testing.T) {
 	assert.Equal(t, string(app01.GetPendingTasks()[0].GetTaskPod().UID), "UID-POD-00001")
 	assert.Equal(t, app01.GetPendingTasks()[0].GetTaskPod().Namespace, "default")
 
-	// reject the task
+	// reject the task, without retries it fails directly
 	task, _ := app01.GetTask("UID-POD-00001")
+	task.retryPolicy = retryPolicy{maxRetries: 0}
 	err := task.handle(NewRejectTaskEvent("app00001", "UID-POD-00001", ""))
 	assert.Assert(t, err == nil)
 	assert.Equal(t, len(app01.GetPendingTasks()), 0)
@@ -240,6 +285,80 @@ func TestPodRejected(t *testing.T) {
 	assertTaskState(t, task01, events.States().Task.Failed, 3*time.Second)
 }
 
+func TestPodRejectedRetry(t *testing.T) {
+	context := initContextForTest()
+	dispatcher.RegisterEventHandler(dispatcher.EventTypeApp, context.ApplicationEventHandler())
+	dispatcher.RegisterEventHandler(dispatcher.EventTypeTask, context.TaskEventHandler())
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	// count the asks that are sent to the scheduler
+	var lock sync.Mutex
+	asks := 0
+	ms := newMockSchedulerApi()
+	ms.updateFn = func(request *si.UpdateRequest) error {
+		lock.Lock()
+		defer lock.Unlock()
+		asks += len(request.Asks)
+		return nil
+	}
+	getAsks := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return asks
+	}
+
+	app := NewApplication("app00001", "root.a", "testuser", map[string]string{}, ms)
+	context.AddApplication(app)
+	task := CreateTaskForTest("task00001", app, &si.Resource{}, context.kubeClient, ms)
+	task.retryPolicy = retryPolicy{
+		maxRetries:     2,
+		initialBackoff: 10 * time.Millisecond,
+		maxBackoff:     20 * time.Millisecond,
+	}
+	app.AddTask(task)
+
+	// submit and reject the task: it must move back to pending, the shim scheduler resubmits pending tasks
+	assert.NilError(t, task.handle(NewSubmitTaskEvent("app00001", "task00001")))
+	assert.Equal(t, getAsks(), 1)
+	for retry := 1; retry <= 2; retry++ {
+		assert.NilError(t, task.handle(NewRejectTaskEvent("app00001", "task00001", "")))
+		assertTaskState(t, task, events.States().Task.Pending, 3*time.Second)
+		assert.Equal(t, task.retries, retry)
+		// the retry itself must not submit the task
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, getAsks(), retry)
+		assert.NilError(t, task.handle(NewSubmitTaskEvent("app00001", "task00001")))
+		assert.Equal(t, getAsks(), retry+1)
+		assertTaskState(t, task, events.States().Task.Scheduling, 3*time.Second)
+	}
+
+	// retries are exhausted: the next reject fails the task
+	assert.NilError(t, task.handle(NewRejectTaskEvent("app00001", "task00001", "")))
+	assertTaskState(t, task, events.States().Task.Failed, 3*time.Second)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{
+		maxRetries:     5,
+		initialBackoff: time.Second,
+		maxBackoff:     5 * time.Second,
+	}
+	assert.Equal(t, policy.backoff(1), time.Second)
+	assert.Equal(t, policy.backoff(2), 2*time.Second)
+	assert.Equal(t, policy.backoff(3), 4*time.Second)
+	assert.Equal(t, policy.backoff(4), 5*time.Second)
+	assert.Assert(t, policy.canRetry(4))
+	assert.Assert(t, !policy.canRetry(5))
+
+	// defaults and turning off retries
+	policy = newRetryPolicy(&conf.SchedulerConf{})
+	assert.Equal(t, policy.maxRetries, conf.DefaultTaskRetryLimit)
+	assert.Equal(t, policy.initialBackoff, conf.DefaultTaskRetryBackoff)
+	policy = newRetryPolicy(&conf.SchedulerConf{TaskRetryLimit: -1})
+	assert.Assert(t, !policy.canRetry(0))
+}
+
 func assertTaskState(t *testing.T, task *Task, expectedState string, timeout time.Duration) {
 	deadline := time.Now().Add(timeout)
 	for {
@@ -253,3 +372,162 @@ func assertTaskState(t *testing.T, task *Task, expectedState string, timeout tim
 	}
 }
 
+
+func TestPodVolumes(t *testing.T) {
+	context := initContextForTest()
+	context.schedulerCache.AddNode(&v1.Node{
+		ObjectMeta: apis.ObjectMeta{Name: "host0001"},
+	})
+	pod := &v1.Pod{
+		ObjectMeta: apis.ObjectMeta{
+			Name:      "pod00001",
+			Namespace: "default",
+			UID:       "UID-POD-00001",
+		},
+	}
+	assert.NilError(t, context.schedulerCache.AddPod(pod))
+
+	// assume and bind the volumes
+	binderConfig := &persistentvolume.FakeVolumeBinderConfig{}
+	context.volumeBinder = volumebinder.NewFakeVolumeBinder(binderConfig)
+	fakeBinder := context.volumeBinder.Binder.(*persistentvolume.FakeVolumeBinder)
+	assert.NilError(t, context.AssumePodVolumes("UID-POD-00001", "host0001"))
+	assert.Assert(t, fakeBinder.AssumeCalled)
+	assert.NilError(t, context.AssumePod("UID-POD-00001", "host0001"))
+	assert.NilError(t, context.BindPodVolumes("UID-POD-00001", "host0001"))
+	assert.Assert(t, fakeBinder.BindCalled)
+	_, ok := context.schedulerCache.GetPod("UID-POD-00001")
+	assert.Assert(t, ok, "pod should still be in the cache")
+
+	// assume fails: the error is passed on
+	binderConfig.AssumeErr = fmt.Errorf("assume failed")
+	assert.ErrorContains(t, context.AssumePodVolumes("UID-POD-00001", "host0001"), "assume failed")
+
+	// bind fails: the assumed pod is forgotten
+	binderConfig.BindErr = fmt.Errorf("bind failed")
+	assert.ErrorContains(t, context.BindPodVolumes("UID-POD-00001", "host0001"), "bind failed")
+	_, ok = context.schedulerCache.GetPod("UID-POD-00001")
+	assert.Assert(t, !ok, "assumed pod should have been removed from the cache")
+}
+
+func TestForgetPodVolumes(t *testing.T) {
+	context := initContextForTest()
+	context.schedulerCache.AddNode(&v1.Node{
+		ObjectMeta: apis.ObjectMeta{Name: "host0001"},
+	})
+	pod := &v1.Pod{
+		ObjectMeta: apis.ObjectMeta{
+			Name:      "pod00001",
+			Namespace: "default",
+			UID:       "UID-POD-00001",
+		},
+	}
+	assert.NilError(t, context.schedulerCache.AddPod(pod))
+	context.volumeBinder = volumebinder.NewFakeVolumeBinder(&persistentvolume.FakeVolumeBinderConfig{})
+
+	// unknown pods are ignored
+	context.ForgetPodVolumes("UID-POD-UNKNOWN")
+
+	// the allocation is rejected after the volumes and pod were assumed: both are forgotten
+	assert.NilError(t, context.AssumePodVolumes("UID-POD-00001", "host0001"))
+	assert.NilError(t, context.AssumePod("UID-POD-00001", "host0001"))
+	context.ForgetPodVolumes("UID-POD-00001")
+	_, ok := context.schedulerCache.GetPod("UID-POD-00001")
+	assert.Assert(t, !ok, "assumed pod should have been removed from the cache")
+}
+
+func TestPreemptAllocation(t *testing.T) {
+	context := initContextForTest()
+	app := NewApplication("app00001", "root.a", "testuser", map[string]string{}, nil)
+	context.AddApplication(app)
+	task := CreateTaskForTest("task00001", app, nil, nil, nil)
+	task.allocationUuid = "uuid-0001"
+	app.AddTask(task)
+
+	var deleted *v1.Pod
+	context.kubeClient.(*test.KubeClientMock).MockDeleteFn(func(pod *v1.Pod) error {
+		deleted = pod
+		return nil
+	})
+
+	// unknown allocations cannot be preempted
+	err := context.PreemptAllocation("uuid-unknown", 30)
+	assert.Assert(t, err != nil)
+	assert.Assert(t, deleted == nil)
+
+	// the pod of the task that holds the allocation is deleted with the grace period
+	err = context.PreemptAllocation("uuid-0001", 30)
+	assert.NilError(t, err)
+	assert.Equal(t, deleted, task.GetTaskPod())
+	assert.Equal(t, context.kubeClient.(*test.KubeClientMock).GetGracePeriodSeconds(), int64(30))
+}
+
+func TestPublishSchedulerEvent(t *testing.T) {
+	context := initContextForTest()
+	app := NewApplication("app00001", "root.a", "testuser", map[string]string{}, nil)
+	context.AddApplication(app)
+	app.AddTask(CreateTaskForTest("task00001", app, nil, nil, nil))
+	app.AddTask(CreateTaskForTest("task00002", app, nil, nil, nil))
+	bound := CreateTaskForTest("task00003", app, nil, nil, nil)
+	bound.allocationUuid = "uuid-00003"
+	bound.sm.SetState(events.States().Task.Bound)
+	app.AddTask(bound)
+
+	recorder, ok := events.GetRecorder().(*record.FakeRecorder)
+	assert.Assert(t, ok, "test mode should use the fake recorder")
+	drainEvents := func() []string {
+		recorded := make([]string, 0)
+		for {
+			select {
+			case event := <-recorder.Events:
+				recorded = append(recorded, event)
+			default:
+				return recorded
+			}
+		}
+	}
+	drainEvents()
+
+	// request events are published on the pod of the task
+	context.PublishSchedulerEvent(&si.EventRecord{
+		Type:          si.EventRecord_REQUEST,
+		ObjectId:      "task00001",
+		ApplicationId: "app00001",
+		Reason:        "PredicateFailed",
+		Message:       "node selector does not match",
+		Count:         3,
+	})
+	recorded := drainEvents()
+	assert.Equal(t, len(recorded), 1)
+	assert.Equal(t, recorded[0], "Warning PredicateFailed node selector does not match (x3)")
+
+	// application events are published on all pending pods
+	context.PublishSchedulerEvent(&si.EventRecord{
+		Type:          si.EventRecord_APP,
+		ObjectId:      "app00001",
+		ApplicationId: "app00001",
+		Reason:        "ApplicationAccepted",
+		Count:         1,
+	})
+	assert.Equal(t, len(drainEvents()), 2)
+
+	// allocation events are published on the pod that holds the allocation, not on the pending pods
+	context.PublishSchedulerEvent(&si.EventRecord{
+		Type:          si.EventRecord_ALLOCATION,
+		ObjectId:      "uuid-00003",
+		ApplicationId: "app00001",
+		Reason:        "AllocationPreempted",
+		Message:       "allocation uuid-00003 preempted",
+		Count:         1,
+	})
+	recorded = drainEvents()
+	assert.Equal(t, len(recorded), 1)
+	assert.Equal(t, recorded[0], "Warning AllocationPreempted allocation uuid-00003 preempted")
+
+	// unknown objects and node events are not published
+	context.PublishSchedulerEvent(&si.EventRecord{Type: si.EventRecord_REQUEST, ObjectId: "task-unknown", ApplicationId: "app00001"})
+	context.PublishSchedulerEvent(&si.EventRecord{Type: si.EventRecord_APP, ObjectId: "app-unknown"})
+	context.PublishSchedulerEvent(&si.EventRecord{Type: si.EventRecord_ALLOCATION, ObjectId: "uuid-unknown", ApplicationId: "app00001"})
+	context.PublishSchedulerEvent(&si.EventRecord{Type: si.EventRecord_NODE, ObjectId: "node-1"})
+	assert.Equal(t, len(drainEvents()), 0)
+}
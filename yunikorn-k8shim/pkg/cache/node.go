@@ -18,7 +18,6 @@ package cache
 
 import (
 	"github.com/cloudera/yunikorn-core/pkg/api"
-	"github.com/cloudera/yunikorn-k8shim/pkg/common"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common/events"
 	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
 	"github.com/cloudera/yunikorn-k8shim/pkg/log"
@@ -33,18 +32,20 @@ type SchedulerNode struct {
 	name                string
 	uid                 string
 	capacity            *si.Resource
+	attributes          map[string]string
 	existingAllocations []*si.Allocation
 	schedulerApi        api.SchedulerApi
 	fsm                 *fsm.FSM
 	lock                *sync.RWMutex
 }
 
-func newSchedulerNode(nodeName string, nodeUid string,
-	nodeResource *si.Resource, schedulerApi api.SchedulerApi) *SchedulerNode {
+func newSchedulerNode(nodeName string, nodeUid string, nodeResource *si.Resource,
+	nodeAttributes map[string]string, schedulerApi api.SchedulerApi) *SchedulerNode {
 	schedulerNode := &SchedulerNode{
 		name: nodeName,
 		uid:  nodeUid,
 		capacity: nodeResource,
+		attributes: nodeAttributes,
 		schedulerApi: schedulerApi,
 		lock: &sync.RWMutex{},
 	}
@@ -102,10 +103,7 @@ func (n *SchedulerNode) handleNodeRecovery(event *fsm.Event) {
 			{
 				NodeId:              n.name,
 				SchedulableResource: n.capacity,
-				Attributes: map[string]string{
-					common.DefaultNodeAttributeHostNameKey: n.name,
-					common.DefaultNodeAttributeRackNameKey: common.DefaultRackName,
-				},
+				Attributes:          n.attributes,
 				ExistingAllocations: n.existingAllocations,
 			},
 		},
@@ -28,6 +28,7 @@ import (
 	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
 	"go.uber.org/zap"
 	"k8s.io/api/core/v1"
+	"strconv"
 	"sync"
 )
 
@@ -78,7 +79,7 @@ func (nc *schedulerNodes) addExistingAllocation(pod *v1.Pod) error {
 			if schedulerNode, ok := nc.nodesMap[pod.Spec.NodeName]; ok {
 				schedulerNode.addExistingAllocation(&si.Allocation{
 					AllocationKey:    pod.Name,
-					AllocationTags:   nil,
+					AllocationTags:   getExistingAllocationTags(pod),
 					Uuid:             string(pod.UID),
 					ResourcePerAlloc: common.GetPodResource(pod),
 					QueueName:        utils.GetQueueNameFromPod(pod),
@@ -94,6 +95,21 @@ func (nc *schedulerNodes) addExistingAllocation(pod *v1.Pod) error {
 	return nil
 }
 
+// tags for an allocation that already exists on a node: the core keeps the time the pod started
+// (or was created if it has not started) as the creation time of the recovered allocation
+func getExistingAllocationTags(pod *v1.Pod) map[string]string {
+	created := pod.CreationTimestamp
+	if pod.Status.StartTime != nil {
+		created = *pod.Status.StartTime
+	}
+	if created.IsZero() {
+		return nil
+	}
+	return map[string]string{
+		api.CREATION_TIME: strconv.FormatInt(created.Unix(), 10),
+	}
+}
+
 func (nc *schedulerNodes) addNode(node *v1.Node) {
 	nc.addAndReportNode(node, true)
 }
@@ -107,7 +123,8 @@ func (nc *schedulerNodes) addAndReportNode(node *v1.Node, reportNode bool) {
 		log.Logger.Info("adding node to context",
 			zap.String("nodeName", node.Name),
 			zap.String("UID", string(node.UID)))
-		newNode := newSchedulerNode(node.Name, string(node.UID), common.GetNodeResource(&node.Status), nc.proxy)
+		newNode := newSchedulerNode(node.Name, string(node.UID), common.GetNodeResource(&node.Status),
+			common.GetNodeAttributes(node), nc.proxy)
 		nc.nodesMap[node.Name] = newNode
 	}
 
@@ -17,6 +17,7 @@ limitations under the License.
 package cache
 
 import (
+	coreapi "github.com/cloudera/yunikorn-core/pkg/api"
 	"github.com/cloudera/yunikorn-k8shim/pkg/cache/external"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common/test"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apis "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -283,3 +285,26 @@ func TestDeleteNode(t *testing.T) {
 func NewTestSchedulerCache() *external.SchedulerCache {
 	return external.NewSchedulerCache(nil, nil, nil, nil)
 }
+
+func TestGetExistingAllocationTags(t *testing.T) {
+	created := time.Now().Add(-2 * time.Hour)
+	started := time.Now().Add(-time.Hour)
+	pod := &v1.Pod{
+		ObjectMeta: apis.ObjectMeta{
+			Name:              "pod-1",
+			CreationTimestamp: apis.NewTime(created),
+		},
+	}
+	// not started: the creation time of the pod is used
+	tags := getExistingAllocationTags(pod)
+	assert.Equal(t, tags[coreapi.CREATION_TIME], strconv.FormatInt(created.Unix(), 10))
+
+	// started: the start time of the pod is used
+	startTime := apis.NewTime(started)
+	pod.Status.StartTime = &startTime
+	tags = getExistingAllocationTags(pod)
+	assert.Equal(t, tags[coreapi.CREATION_TIME], strconv.FormatInt(started.Unix(), 10))
+
+	// no time at all: no tags
+	assert.Assert(t, getExistingAllocationTags(&v1.Pod{}) == nil)
+}
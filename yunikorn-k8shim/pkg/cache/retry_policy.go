@@ -0,0 +1,73 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cache
+
+import (
+	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
+	"time"
+)
+
+// The retry policy decides how often and when a task is retried after it was rejected
+// by the scheduler or failed to bind. The backoff doubles on every retry up to the max backoff.
+type retryPolicy struct {
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func newRetryPolicy(configs *conf.SchedulerConf) retryPolicy {
+	policy := retryPolicy{
+		maxRetries:     conf.DefaultTaskRetryLimit,
+		initialBackoff: conf.DefaultTaskRetryBackoff,
+		maxBackoff:     conf.DefaultTaskRetryMaxBackoff,
+	}
+	if configs == nil {
+		return policy
+	}
+	// a negative limit turns off retries, zero values are not set and use the default
+	if configs.TaskRetryLimit < 0 {
+		policy.maxRetries = 0
+	} else if configs.TaskRetryLimit > 0 {
+		policy.maxRetries = configs.TaskRetryLimit
+	}
+	if configs.TaskRetryBackoff > 0 {
+		policy.initialBackoff = configs.TaskRetryBackoff
+	}
+	if configs.TaskRetryMaxBackoff > 0 {
+		policy.maxBackoff = configs.TaskRetryMaxBackoff
+	}
+	return policy
+}
+
+// check if the task can be retried after the given number of retries
+func (p retryPolicy) canRetry(retries int) bool {
+	return retries < p.maxRetries
+}
+
+// get the backoff before the given retry, the first retry is retry 1
+func (p retryPolicy) backoff(retry int) time.Duration {
+	backoff := p.initialBackoff
+	for i := 1; i < retry; i++ {
+		backoff *= 2
+		if backoff >= p.maxBackoff {
+			return p.maxBackoff
+		}
+	}
+	if backoff > p.maxBackoff {
+		return p.maxBackoff
+	}
+	return backoff
+}
@@ -22,12 +22,14 @@ import (
 	"github.com/cloudera/yunikorn-k8shim/pkg/client"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common/events"
+	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
 	"github.com/cloudera/yunikorn-k8shim/pkg/dispatcher"
 	"github.com/cloudera/yunikorn-k8shim/pkg/log"
 	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
 	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sync"
+	"time"
 
 	"github.com/looplab/fsm"
 	"k8s.io/api/core/v1"
@@ -44,18 +46,21 @@ type Task struct {
 	kubeClient     client.KubeClient
 	schedulerApi   api.SchedulerApi  //core的api
 	nodeName       string         //pod中用户直接指定的nodename？？
+	retries        int
+	retrying       bool
+	retryPolicy    retryPolicy
 	sm             *fsm.FSM
 	lock           *sync.RWMutex
 }
 
-func newTask(tid string, app *Application, client client.KubeClient, schedulerApi api.SchedulerApi, pod *v1.Pod) Task {
+func newTask(tid string, app *Application, client client.KubeClient, schedulerApi api.SchedulerApi, pod *v1.Pod) *Task {
 	taskResource := common.GetPodResource(pod)
 	return createTaskInternal(tid, app, taskResource, pod, client, schedulerApi)
 }
 
 // test only
 func CreateTaskForTest(tid string, app *Application, resource *si.Resource,
-	client client.KubeClient, schedulerApi api.SchedulerApi) Task {
+	client client.KubeClient, schedulerApi api.SchedulerApi) *Task {
 	// for testing purpose, the pod name is same as the taskId
 	taskPod := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -66,8 +71,8 @@ func CreateTaskForTest(tid string, app *Application, resource *si.Resource,
 }
 
 func createTaskInternal(tid string, app *Application, resource *si.Resource,
-	pod *v1.Pod, client client.KubeClient, schedulerApi api.SchedulerApi) Task {
-	task := Task{
+	pod *v1.Pod, client client.KubeClient, schedulerApi api.SchedulerApi) *Task {
+	task := &Task{
 		taskId:        tid,
 		applicationId: app.GetApplicationId(),
 		application:   app,
@@ -75,6 +80,7 @@ func createTaskInternal(tid string, app *Application, resource *si.Resource,
 		resource:      resource,
 		kubeClient:    client,
 		schedulerApi:  schedulerApi,
+		retryPolicy:   newRetryPolicy(conf.GetSchedulerConf()),
 		lock:          &sync.RWMutex{},
 	}
 
@@ -103,6 +109,9 @@ func createTaskInternal(tid string, app *Application, resource *si.Resource,
 			{Name: string(events.TaskRejected),
 				Src: []string{states.Pending, states.Scheduling},
 				Dst: states.Rejected},
+			{Name: string(events.RetryTask),
+				Src: []string{states.Rejected, states.Allocated},
+				Dst: states.Pending},
 			{Name: string(events.TaskFail),
 				Src: []string{states.Rejected, states.Allocated},
 				Dst: states.Failed},
@@ -110,6 +119,7 @@ func createTaskInternal(tid string, app *Application, resource *si.Resource,
 		fsm.Callbacks{
 			string(events.SubmitTask): task.handleSubmitTaskEvent,
 			string(events.TaskFail):   task.handleFailEvent,
+			string(events.RetryTask):  task.handleRetryTaskEvent,
 			states.Allocated:          task.postTaskAllocated,
 			states.Rejected:           task.postTaskRejected,
 			states.Completed:          task.postTaskCompleted,
@@ -138,8 +148,7 @@ func (task *Task) handle(te events.TaskEvent) error {
 }
 
 func createTaskFromPod(app *Application, client client.KubeClient, scheduler api.SchedulerApi, pod *v1.Pod) *Task {
-	task := newTask(string(pod.UID), app, client, scheduler, pod)
-	return &task
+	return newTask(string(pod.UID), app, client, scheduler, pod)
 }
 
 func (task *Task) GetTaskPod() *v1.Pod {
@@ -148,6 +157,12 @@ func (task *Task) GetTaskPod() *v1.Pod {
 	return task.pod
 }
 
+func (task *Task) getAllocationUuid() string {
+	task.lock.RLock()
+	defer task.lock.RUnlock()
+	return task.allocationUuid
+}
+
 func (task *Task) GetTaskState() string {
 	// fsm has its own internal lock, we don't need to hold node's lock here
 	return task.sm.Current()
@@ -182,7 +197,7 @@ func (task *Task) handleSubmitTaskEvent(event *fsm.Event) {
 	log.Logger.Debug("scheduling pod",
 		zap.String("podName", task.GetTaskPod().Name))
 	// convert the request
-	rr := common.CreateUpdateRequestForTask(task.applicationId, task.taskId, task.resource)
+	rr := common.CreateUpdateRequestForTask(task.applicationId, task.taskId, task.resource, common.GetPodTags(task.pod))
 	log.Logger.Debug("send update request", zap.String("request", rr.String()))
 	if err := task.schedulerApi.Update(&rr); err != nil {
 		log.Logger.Debug("failed to send scheduling request to scheduler", zap.Error(err))
@@ -228,9 +243,13 @@ func (task *Task) postTaskAllocated(event *fsm.Event) {
 			errorMessage = fmt.Sprintf("bind pod failed, name: %s, uid: %s, %#v",
 				task.pod.Name, task.pod.UID, err)
 			log.Logger.Error(errorMessage)
-			dispatcher.Dispatch(NewFailTaskEvent(task.applicationId, task.taskId, errorMessage))
 			events.GetRecorder().Eventf(task.pod,
 				v1.EventTypeWarning, "PodBindFailure", errorMessage)
+			// the allocation is not used: give it back to the scheduler before retrying
+			if task.retryPolicy.canRetry(task.retries) {
+				task.releaseAllocation()
+			}
+			task.retryOrFail(errorMessage)
 			return
 		}
 
@@ -243,15 +262,72 @@ func (task *Task) postTaskAllocated(event *fsm.Event) {
 }
 
 func (task *Task) postTaskRejected(event *fsm.Event) {
-	// currently, once task is rejected by scheduler, we directly move task to failed state.
-	// so this function simply triggers the state transition when it is rejected.
-	// but further, we can introduce retry mechanism if necessary.
-	dispatcher.Dispatch(NewFailTaskEvent(task.applicationId, task.taskId,
-		fmt.Sprintf("task %s failed because it is rejected by scheduler", task.taskId)))
-
+	// once task is rejected by scheduler, it is retried based on the retry policy.
+	// the task moves to failed state when it cannot be retried anymore.
 	events.GetRecorder().Eventf(task.pod,
 		v1.EventTypeWarning, "TaskRejected",
 		"application \"%s\" task \"%s\" is rejected by the scheduler", task.applicationId, task.taskId)
+
+	task.lock.Lock()
+	defer task.lock.Unlock()
+	task.retryOrFail(fmt.Sprintf("task %s is rejected by scheduler", task.taskId))
+}
+
+// retry the task after a backoff if the retry policy allows it, otherwise fail the task.
+// the task stays in its current state during the backoff, this makes sure the task is not
+// picked up as a pending task and resubmitted before the backoff has passed.
+// the caller must hold the task lock.
+func (task *Task) retryOrFail(reason string) {
+	if !task.retryPolicy.canRetry(task.retries) {
+		errorMessage := fmt.Sprintf("task %s failed after %d retries: %s", task.taskId, task.retries, reason)
+		events.GetRecorder().Eventf(task.pod,
+			v1.EventTypeWarning, "TaskRetryLimitReached",
+			"application \"%s\" task \"%s\" failed after %d retries", task.applicationId, task.taskId, task.retries)
+		dispatcher.Dispatch(NewFailTaskEvent(task.applicationId, task.taskId, errorMessage))
+		return
+	}
+	task.retries++
+	backoff := task.retryPolicy.backoff(task.retries)
+	log.Logger.Info("task will be retried",
+		zap.String("appId", task.applicationId),
+		zap.String("taskId", task.taskId),
+		zap.Int("retry", task.retries),
+		zap.Int("maxRetries", task.retryPolicy.maxRetries),
+		zap.String("backoff", backoff.String()),
+		zap.String("reason", reason))
+	events.GetRecorder().Eventf(task.pod,
+		v1.EventTypeWarning, "TaskRetry",
+		"application \"%s\" task \"%s\" will be retried in %s (retry %d of %d): %s",
+		task.applicationId, task.taskId, backoff, task.retries, task.retryPolicy.maxRetries, reason)
+	// the scheduler has no ask for the task until the backoff has passed
+	task.retrying = true
+	appId := task.applicationId
+	taskId := task.taskId
+	time.AfterFunc(backoff, func() {
+		dispatcher.Dispatch(NewRetryTaskEvent(appId, taskId, reason))
+	})
+}
+
+// check if the task waits for the backoff of a retry
+func (task *Task) isRetrying() bool {
+	task.lock.RLock()
+	defer task.lock.RUnlock()
+	return task.retrying
+}
+
+// this is called when the task moves back to pending for a retry, the allocation is cleared.
+// the task is not submitted here: the shim scheduler submits all pending tasks to the scheduler,
+// including the tasks that are retried.
+func (task *Task) handleRetryTaskEvent(event *fsm.Event) {
+	task.lock.Lock()
+	task.allocationUuid = ""
+	task.nodeName = ""
+	task.retrying = false
+	task.lock.Unlock()
+
+	events.GetRecorder().Eventf(task.pod,
+		v1.EventTypeNormal, "TaskResubmitted",
+		"application \"%s\" task \"%s\" is pending and will be resubmitted to the scheduler", task.applicationId, task.taskId)
 }
 
 func (task *Task) postTaskFailed(event *fsm.Event) {
@@ -275,11 +351,13 @@ func (task *Task) postTaskCompleted(event *fsm.Event) {
 
 func (task *Task) releaseAllocation() {
 	// when task is completed, we notify the scheduler to release allocations
+	// the allocation UUID is read before the routine starts, a retry clears it
+	allocationUuid := task.allocationUuid
 	go func() {
 		// scheduler api might be nil in some tests
 		if task.schedulerApi != nil {
 			releaseRequest := common.CreateReleaseAllocationRequestForTask(
-				task.applicationId, task.allocationUuid, task.application.partition)
+				task.applicationId, allocationUuid, task.application.partition)
 
 			log.Logger.Debug("send release request",
 				zap.String("releaseRequest", releaseRequest.String()))
@@ -231,3 +231,40 @@ func (re RejectTaskEvent) GetTaskId() string {
 func (re RejectTaskEvent) GetApplicationId() string {
 	return re.applicationId
 }
+
+// ------------------------
+// Retry Event
+// ------------------------
+type RetryTaskEvent struct {
+	applicationId string
+	taskId        string
+	event         events.TaskEventType
+	message       string
+}
+
+func NewRetryTaskEvent(appId string, taskId string, retryMessage string) RetryTaskEvent {
+	return RetryTaskEvent{
+		applicationId: appId,
+		taskId:        taskId,
+		event:         events.RetryTask,
+		message:       retryMessage,
+	}
+}
+
+func (re RetryTaskEvent) GetEvent() events.TaskEventType {
+	return re.event
+}
+
+func (re RetryTaskEvent) GetArgs() []interface{} {
+	args := make([]interface{}, 1)
+	args[0] = re.message
+	return args
+}
+
+func (re RetryTaskEvent) GetTaskId() string {
+	return re.taskId
+}
+
+func (re RetryTaskEvent) GetApplicationId() string {
+	return re.applicationId
+}
@@ -70,7 +70,9 @@ func (callback *AsyncRMCallback) RecvUpdateResponse(response *si.UpdateResponse)
 
 		//core只返回id，不包含其他信息
 		//将app的状态变为accepted，并触发相应的行为
-		if app, err := callback.context.GetApplication(app.ApplicationId); err == nil {
+		// a resubmitted application is already running
+		if app, err := callback.context.GetApplication(app.ApplicationId); err == nil &&
+			app.GetApplicationState() != events.States().Application.Running {
 			ev := cache.NewSimpleApplicationEvent(app.GetApplicationId(), events.AcceptApplication)
 			dispatcher.Dispatch(ev)
 		}
@@ -87,6 +89,42 @@ func (callback *AsyncRMCallback) RecvUpdateResponse(response *si.UpdateResponse)
 		}
 	}
 
+	// the scheduler removed the application after it completed
+	for _, removed := range response.RemovedApplications {
+		log.Logger.Info("callback: response to removed application",
+			zap.String("appId", removed.ApplicationId),
+			zap.String("reason", removed.Reason))
+
+		if err := callback.context.RemoveApplication(removed.ApplicationId); err != nil {
+			// the retried tasks need the application in the scheduler
+			log.Logger.Info("application not removed, resubmitting it to the scheduler",
+				zap.String("appId", removed.ApplicationId),
+				zap.Error(err))
+			if app, err := callback.context.GetApplication(removed.ApplicationId); err == nil {
+				if err = app.Resubmit(); err != nil {
+					log.Logger.Warn("failed to resubmit app", zap.Error(err))
+				}
+			}
+		}
+	}
+
+	// the scheduler moved the application to a different queue
+	for _, moved := range response.MovedApplications {
+		log.Logger.Info("callback: response to moved application",
+			zap.String("appId", moved.ApplicationId),
+			zap.String("queue", moved.QueueName))
+
+		if app, err := callback.context.GetApplication(moved.ApplicationId); err == nil {
+			app.SetQueue(moved.QueueName)
+		}
+	}
+
+	for _, rejected := range response.RejectedApplicationMoves {
+		log.Logger.Warn("callback: response to rejected application move",
+			zap.String("appId", rejected.ApplicationId),
+			zap.String("reason", rejected.Reason))
+	}
+
 	// handle new allocations
 	//接受了task的调度请求，并返回了调度结果，更改task状态，并触发相应的行为
 	for _, alloc := range response.NewAllocations {
@@ -107,6 +145,8 @@ func (callback *AsyncRMCallback) RecvUpdateResponse(response *si.UpdateResponse)
 		// request rejected by the scheduler, put it back and try scheduling again
 		log.Logger.Info("callback: response to rejected allocation",
 			zap.String("allocationKey", reject.AllocationKey))
+		// the volumes and the pod were assumed on the node when the core proposed the allocation
+		callback.context.ForgetPodVolumes(reject.AllocationKey)
 
 		if app, err := callback.context.GetApplication(reject.ApplicationId); err == nil {
 			dispatcher.Dispatch(cache.NewRejectTaskEvent(app.GetApplicationId(), reject.AllocationKey,
@@ -117,7 +157,24 @@ func (callback *AsyncRMCallback) RecvUpdateResponse(response *si.UpdateResponse)
 
 	for _, release := range response.ReleasedAllocations {
 		log.Logger.Info("callback: response to released allocations",
-			zap.String("Uuid", release.Uuid))
+			zap.String("Uuid", release.Uuid),
+			zap.Stringer("terminationType", release.TerminationType))
+
+		// the scheduler asks to terminate the pod, the allocation is released when the pod is gone
+		if release.TerminationType == si.AllocationReleaseResponse_PREEMPTED_BY_SCHEDULER {
+			go func(uuid string, gracePeriodSeconds int64) {
+				if err := callback.context.PreemptAllocation(uuid, gracePeriodSeconds); err != nil {
+					log.Logger.Warn("failed to preempt allocation",
+						zap.String("Uuid", uuid),
+						zap.Error(err))
+				}
+			}(release.Uuid, release.GracePeriodSeconds)
+		}
+	}
+
+	// publish the scheduler events on the related pods
+	for _, record := range response.Events {
+		callback.context.PublishSchedulerEvent(record)
 	}
 
 	return nil
@@ -139,3 +196,13 @@ func (callback *AsyncRMCallback) ReSyncSchedulerCache(args *si.ReSyncSchedulerCa
 }
 
 
+
+// this callback implements scheduler plugin interface VolumesPlugin.
+func (callback *AsyncRMCallback) VolumesAssume(allocationKey string, nodeId string) error {
+	return callback.context.AssumePodVolumes(allocationKey, nodeId)
+}
+
+// this callback implements scheduler plugin interface VolumesPlugin.
+func (callback *AsyncRMCallback) VolumesBind(allocationKey string, nodeId string) error {
+	return callback.context.BindPodVolumes(allocationKey, nodeId)
+}
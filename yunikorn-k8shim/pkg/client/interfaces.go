@@ -29,6 +29,9 @@ type KubeClient interface {
 	// Delete a pod from a host
 	Delete(pod *v1.Pod) error
 
+	// Delete a pod from a host, the pod is given the grace period in seconds to terminate
+	DeleteWithGracePeriod(pod *v1.Pod, gracePeriodSeconds int64) error
+
 	// minimal expose this, only informers factory needs it
 	GetClientSet() *kubernetes.Clientset
 }
@@ -83,11 +83,16 @@ func (nc SchedulerKubeClient) Bind(pod *v1.Pod, hostId string) error {
 
 }
 
+// grace period used when deleting a pod without a specific grace period
+const defaultDeleteGracePeriodSeconds = int64(3)
+
 func (nc SchedulerKubeClient) Delete(pod *v1.Pod) error {
-	// TODO make this configurable for pods
-	gracefulSeconds := int64(3)
+	return nc.DeleteWithGracePeriod(pod, defaultDeleteGracePeriodSeconds)
+}
+
+func (nc SchedulerKubeClient) DeleteWithGracePeriod(pod *v1.Pod, gracePeriodSeconds int64) error {
 	if err := nc.clientSet.CoreV1().Pods(pod.Namespace).Delete(pod.Name, &apis.DeleteOptions{
-		GracePeriodSeconds: &gracefulSeconds,
+		GracePeriodSeconds: &gracePeriodSeconds,
 	}); err != nil {
 		log.Logger.Error("failed to delete pod",
 			zap.String("namespace", pod.Namespace),
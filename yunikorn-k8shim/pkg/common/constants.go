@@ -19,13 +19,20 @@ package common
 // Cluster
 const DefaultNodeAttributeHostNameKey = "si.io/hostname"
 const DefaultNodeAttributeRackNameKey = "si.io/rackname"
+const DefaultNodeAttributeLocalImagesKey = "si.io/local-images"
 const DefaultRackName = "/rack-default"
 
+// Allocation
+const AllocationTagContainerImageKey = "si.io/container-image"
+
 // Application
 const LabelApplicationId = "applicationId"
 const LabelQueueName = "queue"
 const ApplicationDefaultQueue = "root"
 const DefaultPartition = "default"
+const NamespaceAnnotationQueueName = "yunikorn.io/queue"
+const AutoGenAppPrefix = "yunikorn"
+const AutoGenAppSuffix = "autogen"
 
 // Resource
 const Memory = "memory"
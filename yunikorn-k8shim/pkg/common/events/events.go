@@ -63,6 +63,7 @@ const (
 	SubmitTask    TaskEventType = "SubmitTask"
 	TaskAllocated TaskEventType = "TaskAllocated"
 	TaskRejected  TaskEventType = "TaskRejected"
+	RetryTask     TaskEventType = "RetryTask"
 	TaskBound     TaskEventType = "TaskBound"
 	CompleteTask  TaskEventType = "CompleteTask"
 	TaskFail      TaskEventType = "TaskFail"
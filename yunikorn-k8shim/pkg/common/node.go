@@ -19,6 +19,7 @@ package common
 import (
 	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
 	"k8s.io/api/core/v1"
+	"strings"
 )
 
 // stores info about what scheduler cares about a node
@@ -43,3 +44,20 @@ func CreateFromNodeSpec(nodeName string, nodeUid string, nodeResource *si.Resour
 		resource: nodeResource,
 	}
 }
+
+// Get the attributes of the node that are reported to the scheduler: the host and rack of the node,
+// and the names of the images present on the node as a comma separated list.
+func GetNodeAttributes(node *v1.Node) map[string]string {
+	attributes := map[string]string{
+		DefaultNodeAttributeHostNameKey: node.Name,
+		DefaultNodeAttributeRackNameKey: DefaultRackName,
+	}
+	images := make([]string, 0)
+	for _, image := range node.Status.Images {
+		images = append(images, image.Names...)
+	}
+	if len(images) > 0 {
+		attributes[DefaultNodeAttributeLocalImagesKey] = strings.Join(images, ",")
+	}
+	return attributes
+}
@@ -80,3 +80,28 @@ func TestCreateNodeWithCustomResource(t *testing.T) {
 	assert.Equal(t, node.resource.Resources[CPU].Value, int64(9000))
 	assert.Equal(t, node.resource.Resources["nvidia.com/gpu"].Value, int64(3))
 }
+
+func TestGetNodeAttributes(t *testing.T) {
+	var k8sNode = v1.Node{
+		ObjectMeta: apis.ObjectMeta{
+			Name: "host0001",
+			UID:  "uid_0001",
+		},
+		Status: v1.NodeStatus{
+			Images: []v1.ContainerImage{
+				{Names: []string{"nginx@sha256:1234", "nginx:1.17"}},
+				{Names: []string{"busybox:latest"}},
+			},
+		},
+	}
+	attributes := GetNodeAttributes(&k8sNode)
+	assert.Equal(t, attributes[DefaultNodeAttributeHostNameKey], "host0001")
+	assert.Equal(t, attributes[DefaultNodeAttributeRackNameKey], DefaultRackName)
+	assert.Equal(t, attributes[DefaultNodeAttributeLocalImagesKey], "nginx@sha256:1234,nginx:1.17,busybox:latest")
+
+	// no images on the node
+	k8sNode.Status.Images = nil
+	attributes = GetNodeAttributes(&k8sNode)
+	_, ok := attributes[DefaultNodeAttributeLocalImagesKey]
+	assert.Assert(t, !ok)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"strings"
 )
 
 // resource builder is a helper struct to construct si resources
@@ -75,12 +76,29 @@ func getResource(resourceList v1.ResourceList) *si.Resource {
 	return resources.Build()
 }
 
-func CreateUpdateRequestForTask(appId, taskId string, resource *si.Resource) si.UpdateRequest {
+// Get the tags of the ask for the pod: the images of the containers of the pod as a comma separated list.
+func GetPodTags(pod *v1.Pod) map[string]string {
+	images := make([]string, 0)
+	for _, container := range pod.Spec.Containers {
+		if container.Image != "" {
+			images = append(images, container.Image)
+		}
+	}
+	if len(images) == 0 {
+		return nil
+	}
+	return map[string]string{
+		AllocationTagContainerImageKey: strings.Join(images, ","),
+	}
+}
+
+func CreateUpdateRequestForTask(appId, taskId string, resource *si.Resource, tags map[string]string) si.UpdateRequest {
 	ask := si.AllocationAsk{
 		AllocationKey: taskId,
 		ResourceAsk:   resource,
 		ApplicationId: appId,
 		MaxAllocations: 1,
+		Tags:          tags,
 	}
 
 	result := si.UpdateRequest{
@@ -184,3 +184,20 @@ func TestParsePodResource(t *testing.T) {
 	assert.Equal(t, resource.Resources[CPU].GetValue(), int64(3000))
 	assert.Equal(t, resource.Resources["nvidia.com/gpu"].GetValue(), int64(5))
 }
+
+func TestGetPodTags(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "container-01", Image: "nginx:1.17"},
+				{Name: "container-02", Image: "busybox:latest"},
+			},
+		},
+	}
+	tags := GetPodTags(pod)
+	assert.Equal(t, len(tags), 1)
+	assert.Equal(t, tags[AllocationTagContainerImageKey], "nginx:1.17,busybox:latest")
+
+	// no images no tags
+	assert.Assert(t, GetPodTags(&v1.Pod{}) == nil)
+}
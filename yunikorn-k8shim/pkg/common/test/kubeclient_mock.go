@@ -25,6 +25,8 @@ import (
 type KubeClientMock struct {
 	bindFn   func(pod *v1.Pod, hostId string) error
 	deleteFn func(pod *v1.Pod) error
+	// grace period of the last delete with a grace period
+	gracePeriodSeconds int64
 }
 
 func NewKubeClientMock() *KubeClientMock {
@@ -54,6 +56,15 @@ func (c *KubeClientMock) Delete(pod *v1.Pod) error {
 	return c.deleteFn(pod)
 }
 
+func (c *KubeClientMock) DeleteWithGracePeriod(pod *v1.Pod, gracePeriodSeconds int64) error {
+	c.gracePeriodSeconds = gracePeriodSeconds
+	return c.deleteFn(pod)
+}
+
+func (c *KubeClientMock) GetGracePeriodSeconds() int64 {
+	return c.gracePeriodSeconds
+}
+
 func (c *KubeClientMock) GetClientSet() *kubernetes.Clientset {
 	return nil
 }
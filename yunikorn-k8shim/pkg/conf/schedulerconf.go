@@ -31,22 +31,38 @@ const (
 	DefaultLogEncoding = "console"
 	DefaultVolumeBindTimeout = 10 * time.Second
 	DefaultSchedulingInterval = time.Second
+	DefaultDispatchTimeout = 300 * time.Millisecond
+	DefaultTaskRetryLimit = 3
+	DefaultTaskRetryBackoff = time.Second
+	DefaultTaskRetryMaxBackoff = time.Minute
+	DefaultScheduleMinInterval = 10 * time.Millisecond
+	DefaultScheduleMaxIdleTick = time.Second
 )
 
 var configuration *SchedulerConf
 
 type SchedulerConf struct {
-	ClusterId         string        `json:"clusterId"`
-	ClusterVersion    string        `json:"clusterVersion"`
-	SchedulerName     string        `json:"schedulerName"`
-	PolicyGroup       string        `json:"policyGroup"`
-	Interval          time.Duration `json:"schedulingIntervalSecond"`
-	KubeConfig        string        `json:"absoluteKubeConfigFilePath"`
-	LoggingLevel      int           `json:"loggingLevel"`
-	LogEncoding       string        `json:"logEncoding"`
-	LogFile           string        `json:"logFilePath"`
-	VolumeBindTimeout time.Duration `json:"volumeBindTimeout"`
-	TestMode          bool          `json:"testMode"`
+	ClusterId           string        `json:"clusterId"`
+	ClusterVersion      string        `json:"clusterVersion"`
+	SchedulerName       string        `json:"schedulerName"`
+	PolicyGroup         string        `json:"policyGroup"`
+	Interval            time.Duration `json:"schedulingIntervalSecond"`
+	KubeConfig          string        `json:"absoluteKubeConfigFilePath"`
+	LoggingLevel        int           `json:"loggingLevel"`
+	LogEncoding         string        `json:"logEncoding"`
+	LogFile             string        `json:"logFilePath"`
+	VolumeBindTimeout   time.Duration `json:"volumeBindTimeout"`
+	DispatchTimeout     time.Duration `json:"dispatchTimeout"`
+	TaskRetryLimit      int           `json:"taskRetryLimit"`
+	TaskRetryBackoff    time.Duration `json:"taskRetryBackoff"`
+	TaskRetryMaxBackoff time.Duration `json:"taskRetryMaxBackoff"`
+	ScheduleMinInterval time.Duration `json:"scheduleMinInterval"`
+	ScheduleMaxIdleTick time.Duration `json:"scheduleMaxIdleTick"`
+	WebAuthProxyHeader  string        `json:"webAuthProxyHeader"`
+	WebCertFile         string        `json:"webCertFile"`
+	WebKeyFile          string        `json:"webKeyFile"`
+	WebClientCAFile     string        `json:"webClientCAFile"`
+	TestMode            bool          `json:"testMode"`
 }
 
 func GetSchedulerConf() *SchedulerConf {
@@ -82,6 +98,28 @@ func init() {
 		"policy group")
 	volumeBindTimeout := flag.Duration("volumeBindTimeout", DefaultVolumeBindTimeout,
 		"timeout in seconds when binding a volume")
+	dispatchTimeout := flag.Duration("dispatchTimeout", DefaultDispatchTimeout,
+		"maximum time to wait for a full event channel before an event is queued for a retry")
+	taskRetryLimit := flag.Int("taskRetryLimit", DefaultTaskRetryLimit,
+		"number of times a rejected task or a task that failed to bind is retried before it fails, negative turns off retries")
+	taskRetryBackoff := flag.Duration("taskRetryBackoff", DefaultTaskRetryBackoff,
+		"initial backoff before a task is retried, doubled on every retry")
+	taskRetryMaxBackoff := flag.Duration("taskRetryMaxBackoff", DefaultTaskRetryMaxBackoff,
+		"maximum backoff before a task is retried")
+	scheduleMinInterval := flag.Duration("scheduleMinInterval", DefaultScheduleMinInterval,
+		"minimum time between two scheduling cycles of the core scheduler")
+	scheduleMaxIdleTick := flag.Duration("scheduleMaxIdleTick", DefaultScheduleMaxIdleTick,
+		"maximum time the core scheduler waits without a trigger before running a scheduling cycle")
+
+	// web service options
+	webAuthProxyHeader := flag.String("webAuthProxyHeader", "",
+		"header with the user set by an authenticating proxy in front of the web service, only set when the web service is only reachable via the proxy")
+	webCertFile := flag.String("webCertFile", "",
+		"certificate file of the web service, the web service uses TLS when the certificate and key are set")
+	webKeyFile := flag.String("webKeyFile", "",
+		"key file of the web service")
+	webClientCAFile := flag.String("webClientCAFile", "",
+		"CA file to verify the client certificates of the web service, the common name of the certificate is the user")
 
 	// logging options
 	logLevel := flag.Int("logLevel", DefaultLoggingLevel,
@@ -94,15 +132,25 @@ func init() {
 	flag.Parse()
 
 	configuration = &SchedulerConf{
-		ClusterId:         *clusterId,
-		ClusterVersion:    *clusterVersion,
-		PolicyGroup:       *policyGroup,
-		SchedulerName:     *schedulerName,
-		Interval:          *schedulingInterval,
-		KubeConfig:        *kubeConfig,
-		LoggingLevel:      *logLevel,
-		LogEncoding:       *encode,
-		LogFile:           *logFile,
-		VolumeBindTimeout: *volumeBindTimeout,
+		ClusterId:           *clusterId,
+		ClusterVersion:      *clusterVersion,
+		PolicyGroup:         *policyGroup,
+		SchedulerName:       *schedulerName,
+		Interval:            *schedulingInterval,
+		KubeConfig:          *kubeConfig,
+		LoggingLevel:        *logLevel,
+		LogEncoding:         *encode,
+		LogFile:             *logFile,
+		VolumeBindTimeout:   *volumeBindTimeout,
+		DispatchTimeout:     *dispatchTimeout,
+		TaskRetryLimit:      *taskRetryLimit,
+		TaskRetryBackoff:    *taskRetryBackoff,
+		TaskRetryMaxBackoff: *taskRetryMaxBackoff,
+		ScheduleMinInterval: *scheduleMinInterval,
+		ScheduleMaxIdleTick: *scheduleMaxIdleTick,
+		WebAuthProxyHeader:  *webAuthProxyHeader,
+		WebCertFile:         *webCertFile,
+		WebKeyFile:          *webKeyFile,
+		WebClientCAFile:     *webClientCAFile,
 	}
 }
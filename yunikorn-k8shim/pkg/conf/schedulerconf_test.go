@@ -28,4 +28,6 @@ func TestDefaultValues(t *testing.T) {
 	assert.Equal(t, conf.SchedulerName, DefaultSchedulerName)
 	assert.Equal(t, conf.LoggingLevel, DefaultLoggingLevel)
 	assert.Equal(t, conf.LogEncoding, DefaultLogEncoding)
+	assert.Equal(t, conf.ScheduleMinInterval, DefaultScheduleMinInterval)
+	assert.Equal(t, conf.ScheduleMaxIdleTick, DefaultScheduleMaxIdleTick)
 }
\ No newline at end of file
@@ -19,6 +19,7 @@ package dispatcher
 import (
 	"fmt"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common/events"
+	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
 	"github.com/cloudera/yunikorn-k8shim/pkg/log"
 	"go.uber.org/zap"
 	"sync"
@@ -38,24 +39,80 @@ const (
 	EventTypeScheduler
 )
 
+// the event types that have their own channel: task events share the channel of the application events
+var channelTypes = []EventType{EventTypeApp, EventTypeNode, EventTypeScheduler}
+
+// get the channel the events of the type are dispatched to. The events of an application and its tasks must be
+// handled in the order they are dispatched, they share one channel.
+func getChannelType(eventType EventType) EventType {
+	if eventType == EventTypeTask {
+		return EventTypeApp
+	}
+	return eventType
+}
+
+func (t EventType) String() string {
+	switch t {
+	case EventTypeApp:
+		return "application"
+	case EventTypeTask:
+		return "task"
+	case EventTypeNode:
+		return "node"
+	case EventTypeScheduler:
+		return "scheduler"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// capacity of each channel
+	eventChannelCapacity = 1024
+	// capacity of the retry queue of each channel, events are dropped when the retry queue is full
+	retryQueueCapacity = 10 * eventChannelCapacity
+	// interval to move events from the retry queues back into the channels
+	retryInterval = 100 * time.Millisecond
+)
+
+// an event waiting to be handled with the time it was first dispatched
+type dispatchedEvent struct {
+	event      events.SchedulingEvent
+	dispatched time.Time
+}
+
 // central dispatcher that dispatches scheduling events.
+// application (including task), node and scheduler events each have their own channel and are
+// handled by their own go routine, events on the same channel are handled one by one in order.
+// This makes sure that a burst of one type of events, e.g. node events, cannot starve the handling
+// of another type of events, while the events of an application and its tasks stay in order.
 type Dispatcher struct {
-	eventChan chan events.SchedulingEvent
-	stopChan  chan struct{}
-	handlers  map[EventType]func(interface{})       //通过调用RegisterEventHandler注册，用来处理eventChan中的事件
-	running   atomic.Value
-	lock      sync.RWMutex
+	eventChans      map[EventType]chan dispatchedEvent
+	retryQueues     map[EventType]*retryQueue
+	dispatchLocks   map[EventType]*sync.Mutex // serialises adding events to a channel and its retry queue
+	stopChan        chan struct{}
+	handlers        map[EventType]func(interface{}) //通过调用RegisterEventHandler注册，用来处理eventChan中的事件
+	dispatchTimeout time.Duration
+	running         atomic.Value
+	wg              sync.WaitGroup
+	lock            sync.RWMutex
 }
 
 func init() {
 	once.Do(func() {
 		if dispatcher == nil {
 			dispatcher = &Dispatcher{
-				eventChan: make(chan events.SchedulingEvent, 1024),
-				handlers:  make(map[EventType]func(interface{})),
-				stopChan:  make(chan struct{}),
-				running:   atomic.Value{},
-				lock:      sync.RWMutex{},
+				eventChans:  make(map[EventType]chan dispatchedEvent),
+				retryQueues:   make(map[EventType]*retryQueue),
+				dispatchLocks: make(map[EventType]*sync.Mutex),
+				handlers:    make(map[EventType]func(interface{})),
+				running:     atomic.Value{},
+				lock:        sync.RWMutex{},
+			}
+			for _, channelType := range channelTypes {
+				dispatcher.eventChans[channelType] = make(chan dispatchedEvent, eventChannelCapacity)
+				dispatcher.retryQueues[channelType] = newRetryQueue(retryQueueCapacity)
+				dispatcher.dispatchLocks[channelType] = &sync.Mutex{}
 			}
 			dispatcher.setRunning(false)
 		}
@@ -75,13 +132,28 @@ func getEventHandler(eventType EventType) func(interface{}) {
 	return dispatcher.handlers[eventType]
 }
 
+// get the type of a scheduling event, which decides the channel the event is dispatched to
+func getEventType(event events.SchedulingEvent) (EventType, error) {
+	switch event.(type) {
+	case events.ApplicationEvent:
+		return EventTypeApp, nil
+	case events.TaskEvent:
+		return EventTypeTask, nil
+	case events.SchedulerEvent:
+		return EventTypeScheduler, nil
+	case events.SchedulerNodeEvent:
+		return EventTypeNode, nil
+	default:
+		return 0, fmt.Errorf("unsupported event %v", event)
+	}
+}
+
 // dispatches scheduler events to actual app/task handler,
 // each app/task has its own state machine and maintain their own states.
-// currently all events share same channel, so they are dispatched
-// one by one in order.
+// the call blocks until the event is accepted by the channel of the event,
+// or the dispatch timeout is reached. events that cannot be delivered within
+// the timeout are added to the retry queue and delivered later.
 func Dispatch(event events.SchedulingEvent) {
-	// currently if dispatch fails, we simply log the error
-	// we may revisit this later, e.g add retry here
 	if err := dispatcher.dispatch(event); err != nil {
 		log.Logger.Warn("failed to dispatch SchedulingEvent",
 			zap.Error(err))
@@ -100,72 +172,167 @@ func (p *Dispatcher) dispatch(event events.SchedulingEvent) error {
 	if !p.isRunning() {
 		return fmt.Errorf("dispatcher is not running")
 	}
+	eventType, err := getEventType(event)
+	if err != nil {
+		return err
+	}
+	channelType := getChannelType(eventType)
 
-	select {
-	case p.eventChan <- event:
-		return nil
-	default:
-		return fmt.Errorf("failed to dispatch event," +
-			" event channel is full or closed")
+	toDispatch := dispatchedEvent{
+		event:      event,
+		dispatched: time.Now(),
+	}
+	// an event that is dispatched while older events on the same channel wait for a retry
+	// must not overtake them: queue it behind the older events. The check and the send are
+	// done under the lock of the channel, otherwise a concurrent dispatch or retry could
+	// change the retry queue in between.
+	lock := p.dispatchLocks[channelType]
+	lock.Lock()
+	defer lock.Unlock()
+	retries := p.retryQueues[channelType]
+	if retries.size() == 0 {
+		timer := time.NewTimer(p.getDispatchTimeout())
+		defer timer.Stop()
+		select {
+		case p.eventChans[channelType] <- toDispatch:
+			metrics.queueDepth.WithLabelValues(channelType.String()).Inc()
+			return nil
+		case <-timer.C:
+			log.Logger.Warn("dispatch timeout reached, event will be retried",
+				zap.Stringer("eventType", eventType),
+				zap.Int("queueDepth", len(p.eventChans[channelType])))
+		}
+	}
+	if !retries.add(toDispatch) {
+		metrics.droppedEvents.WithLabelValues(channelType.String()).Inc()
+		return fmt.Errorf("failed to dispatch %s event, event channel and retry queue are full", eventType)
+	}
+	metrics.retriedEvents.WithLabelValues(channelType.String()).Inc()
+	return nil
+}
+
+func (p *Dispatcher) getDispatchTimeout() time.Duration {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.dispatchTimeout
+}
+
+// move the events from the retry queue back into the channel,
+// stops moving events as soon as the channel is full to keep the order of the events.
+func (p *Dispatcher) retry(channelType EventType) {
+	lock := p.dispatchLocks[channelType]
+	lock.Lock()
+	defer lock.Unlock()
+	retries := p.retryQueues[channelType]
+	for {
+		toDispatch, ok := retries.peek()
+		if !ok {
+			return
+		}
+		select {
+		case p.eventChans[channelType] <- toDispatch:
+			retries.remove()
+			metrics.queueDepth.WithLabelValues(channelType.String()).Inc()
+		default:
+			return
+		}
 	}
 }
 
+// the number of events waiting to be handled, including the events waiting for a retry
+func (p *Dispatcher) pendingEvents() int {
+	pending := 0
+	for _, channelType := range channelTypes {
+		pending += len(p.eventChans[channelType]) + p.retryQueues[channelType].size()
+	}
+	return pending
+}
+
 func (p *Dispatcher) drain() {
-	for len(p.eventChan) > 0 {
+	for remaining := p.pendingEvents(); remaining > 0; remaining = p.pendingEvents() {
 		log.Logger.Info("wait dispatcher to drain",
-			zap.Int("remaining events", len(p.eventChan)))
+			zap.Int("remaining events", remaining))
 		time.Sleep(1 * time.Second)
 	}
 	log.Logger.Info("dispatcher is draining out")
 }
 
+// handle the events of one channel until the dispatcher is stopped
+func (p *Dispatcher) handleEvents(channelType EventType, stopChan chan struct{}) {
+	defer p.wg.Done()
+	for {
+		select {
+		case toHandle := <-p.eventChans[channelType]:
+			metrics.queueDepth.WithLabelValues(channelType.String()).Dec()
+			// the type was checked on dispatch
+			eventType, _ := getEventType(toHandle.event)
+			getEventHandler(eventType)(toHandle.event)
+			metrics.eventLatency.WithLabelValues(channelType.String()).Observe(time.Since(toHandle.dispatched).Seconds())
+		case <-stopChan:
+			log.Logger.Info("shutting down event channel",
+				zap.Stringer("eventType", channelType))
+			return
+		}
+	}
+}
+
+// periodically move events from the retry queues into the channels until the dispatcher is stopped
+func (p *Dispatcher) handleRetries(stopChan chan struct{}) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, channelType := range channelTypes {
+				p.retry(channelType)
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
 //消费dispatcher里的events
 func Start() {
 	log.Logger.Info("starting the dispatcher")
-	go func() {
-		for {
-			select {
-			case event := <-dispatcher.eventChan:
-				switch v := event.(type) {
-				case events.ApplicationEvent:
-					getEventHandler(EventTypeApp)(v)
-				case events.TaskEvent:
-					getEventHandler(EventTypeTask)(v)
-				case events.SchedulerEvent:
-					getEventHandler(EventTypeScheduler)(v)
-				case events.SchedulerNodeEvent:
-					getEventHandler(EventTypeNode)(v)
-				default:
-					log.Logger.Fatal("unsupported event",
-						zap.Any("event", v))
-				}
-			case <-dispatcher.stopChan:
-				log.Logger.Info("shutting down event channel")
-				dispatcher.setRunning(false)
-				return
-			}
-		}
-	}()
+	dispatcher.lock.Lock()
+	dispatcher.stopChan = make(chan struct{})
+	dispatcher.dispatchTimeout = conf.DefaultDispatchTimeout
+	if configs := conf.GetSchedulerConf(); configs != nil && configs.DispatchTimeout > 0 {
+		dispatcher.dispatchTimeout = configs.DispatchTimeout
+	}
+	stopChan := dispatcher.stopChan
+	dispatcher.lock.Unlock()
+
+	for _, channelType := range channelTypes {
+		dispatcher.wg.Add(1)
+		go dispatcher.handleEvents(channelType, stopChan)
+	}
+	dispatcher.wg.Add(1)
+	go dispatcher.handleRetries(stopChan)
 	dispatcher.setRunning(true)
 }
 
 // stop the dispatcher and wait at most 5 seconds gracefully
 func Stop() {
 	log.Logger.Info("stopping the dispatcher")
-	select {
-	case dispatcher.stopChan <- struct{}{}:
-		maxTimeout := 5
-		for dispatcher.isRunning() && maxTimeout > 0 {
-			log.Logger.Info("waiting for dispatcher to be stopped",
-				zap.Int("remainingSeconds", maxTimeout))
-			time.Sleep(1 * time.Second)
-			maxTimeout--
-		}
-		if !dispatcher.isRunning() {
-			log.Logger.Info("dispatcher stopped")
-		}
-	default:
+	if !dispatcher.isRunning() {
 		log.Logger.Info("dispatcher is already stopped")
+		return
 	}
-}
+	dispatcher.setRunning(false)
+	close(dispatcher.stopChan)
 
+	stopped := make(chan struct{})
+	go func() {
+		dispatcher.wg.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		log.Logger.Info("dispatcher stopped")
+	case <-time.After(5 * time.Second):
+		log.Logger.Info("timeout waiting for dispatcher to be stopped")
+	}
+}
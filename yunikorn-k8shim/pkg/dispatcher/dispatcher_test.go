@@ -17,10 +17,13 @@ limitations under the License.
 package dispatcher
 
 import (
+	"fmt"
 	"github.com/cloudera/yunikorn-k8shim/pkg/common/events"
+	"github.com/cloudera/yunikorn-k8shim/pkg/common/utils"
 	"gotest.tools/assert"
 	"sync"
 	"testing"
+	"time"
 )
 
 // app event for testing
@@ -124,4 +127,172 @@ func TestDispatcherStartStop(t *testing.T) {
 	} else {
 		t.Logf("seen expected error: %v", err)
 	}
-}
\ No newline at end of file
+}
+
+// task event for testing
+type TestTaskEvent struct {
+	appId     string
+	taskId    string
+	eventType events.TaskEventType
+}
+
+func (t TestTaskEvent) GetApplicationId() string {
+	return t.appId
+}
+
+func (t TestTaskEvent) GetTaskId() string {
+	return t.taskId
+}
+
+func (t TestTaskEvent) GetEvent() events.TaskEventType {
+	return t.eventType
+}
+
+func (t TestTaskEvent) GetArgs() []interface{} {
+	return nil
+}
+
+// node event for testing
+type TestNodeEvent struct {
+	nodeId    string
+	eventType events.SchedulerNodeEventType
+}
+
+func (t TestNodeEvent) GetNodeId() string {
+	return t.nodeId
+}
+
+func (t TestNodeEvent) GetEvent() events.SchedulerNodeEventType {
+	return t.eventType
+}
+
+func (t TestNodeEvent) GetArgs() []interface{} {
+	return nil
+}
+
+func TestDispatcherBackpressure(t *testing.T) {
+	recorder := &appEventsRecorder{
+		apps: make([]string, 0),
+		lock: &sync.RWMutex{},
+	}
+	// the node handler blocks until released, the app handler must not be blocked by it
+	release := make(chan struct{})
+	var lock sync.Mutex
+	nodeIds := make([]string, 0)
+	RegisterEventHandler(EventTypeNode, func(obj interface{}) {
+		<-release
+		if event, ok := obj.(events.SchedulerNodeEvent); ok {
+			lock.Lock()
+			nodeIds = append(nodeIds, event.GetNodeId())
+			lock.Unlock()
+		}
+	})
+	RegisterEventHandler(EventTypeApp, func(obj interface{}) {
+		if event, ok := obj.(events.ApplicationEvent); ok {
+			recorder.addApp(event.GetApplicationId())
+		}
+	})
+
+	Start()
+	defer Stop()
+	dispatcher.lock.Lock()
+	dispatcher.dispatchTimeout = 10 * time.Millisecond
+	dispatcher.lock.Unlock()
+
+	// overflow the node channel: the events that do not fit must go to the retry queue
+	total := eventChannelCapacity + 10
+	for i := 0; i < total; i++ {
+		assert.NilError(t, dispatcher.dispatch(TestNodeEvent{
+			nodeId:    fmt.Sprintf("node-%d", i),
+			eventType: events.NodeAccepted,
+		}))
+	}
+	assert.Assert(t, dispatcher.retryQueues[EventTypeNode].size() > 0, "expected events in the node retry queue")
+
+	// app events are still handled
+	Dispatch(TestAppEvent{
+		appId:     "test-app-001",
+		eventType: events.RunApplication,
+	})
+	err := utils.WaitForCondition(func() bool {
+		return recorder.contains("test-app-001")
+	}, 10*time.Millisecond, time.Second)
+	assert.NilError(t, err, "app event was not handled while the node handler was blocked")
+
+	// release the node handler: all node events must be handled in order
+	close(release)
+	dispatcher.drain()
+	err = utils.WaitForCondition(func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(nodeIds) == total
+	}, 10*time.Millisecond, 5*time.Second)
+	assert.NilError(t, err, "not all node events were handled")
+	for i, nodeId := range nodeIds {
+		assert.Equal(t, nodeId, fmt.Sprintf("node-%d", i))
+	}
+}
+
+func TestDispatcherAppTaskOrder(t *testing.T) {
+	// app and task events are recorded in the order they are handled
+	var lock sync.Mutex
+	handled := make([]string, 0)
+	record := func(id string) {
+		lock.Lock()
+		defer lock.Unlock()
+		handled = append(handled, id)
+	}
+	RegisterEventHandler(EventTypeApp, func(obj interface{}) {
+		if event, ok := obj.(events.ApplicationEvent); ok {
+			record(event.GetApplicationId())
+		}
+	})
+	RegisterEventHandler(EventTypeTask, func(obj interface{}) {
+		if event, ok := obj.(events.TaskEvent); ok {
+			record(event.GetTaskId())
+		}
+	})
+
+	Start()
+	defer Stop()
+	dispatcher.lock.Lock()
+	dispatcher.dispatchTimeout = time.Millisecond
+	dispatcher.lock.Unlock()
+
+	// interleave app and task events, more than fit in the channel to also use the retry queue
+	total := eventChannelCapacity + 100
+	expected := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		if i%2 == 0 {
+			Dispatch(TestAppEvent{appId: fmt.Sprintf("event-%d", i), eventType: events.RunApplication})
+		} else {
+			Dispatch(TestTaskEvent{appId: "app", taskId: fmt.Sprintf("event-%d", i), eventType: events.TaskAllocated})
+		}
+		expected = append(expected, fmt.Sprintf("event-%d", i))
+	}
+	dispatcher.drain()
+	err := utils.WaitForCondition(func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(handled) == total
+	}, 10*time.Millisecond, 5*time.Second)
+	assert.NilError(t, err, "not all events were handled")
+	assert.DeepEqual(t, handled, expected)
+}
+
+func TestRetryQueue(t *testing.T) {
+	queue := newRetryQueue(2)
+	_, ok := queue.peek()
+	assert.Assert(t, !ok, "empty queue should not return an event")
+	assert.Assert(t, queue.add(dispatchedEvent{event: TestAppEvent{appId: "app-1"}}))
+	assert.Assert(t, queue.add(dispatchedEvent{event: TestAppEvent{appId: "app-2"}}))
+	assert.Assert(t, !queue.add(dispatchedEvent{event: TestAppEvent{appId: "app-3"}}), "full queue should reject events")
+	assert.Equal(t, queue.size(), 2)
+	head, ok := queue.peek()
+	assert.Assert(t, ok)
+	assert.Equal(t, head.event.(TestAppEvent).appId, "app-1")
+	queue.remove()
+	head, _ = queue.peek()
+	assert.Equal(t, head.event.(TestAppEvent).appId, "app-2")
+	assert.Equal(t, queue.size(), 1)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dispatcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DispatcherSubsystem - subsystem name used by the dispatcher metrics
+	DispatcherSubsystem = "yunikorn_k8shim_dispatcher"
+)
+
+var metrics = initDispatcherMetrics()
+
+// all dispatcher metrics, labelled by the event type of the channel: task events are part of the application channel
+type dispatcherMetrics struct {
+	queueDepth    *prometheus.GaugeVec
+	eventLatency  *prometheus.HistogramVec
+	retriedEvents *prometheus.CounterVec
+	droppedEvents *prometheus.CounterVec
+}
+
+func initDispatcherMetrics() *dispatcherMetrics {
+	m := &dispatcherMetrics{}
+	m.queueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: DispatcherSubsystem,
+			Name:      "queue_depth",
+			Help:      "Number of events waiting in the dispatcher channel, by event type.",
+		}, []string{"type"})
+	m.eventLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: DispatcherSubsystem,
+			Name:      "event_latency_seconds",
+			Help:      "Time between dispatching an event and finishing handling it in seconds, by event type.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 15),
+		}, []string{"type"})
+	m.retriedEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: DispatcherSubsystem,
+			Name:      "retried_events_total",
+			Help:      "Number of events that could not be dispatched in time and were added to the retry queue, by event type.",
+		}, []string{"type"})
+	m.droppedEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: DispatcherSubsystem,
+			Name:      "dropped_events_total",
+			Help:      "Number of events dropped because the channel and the retry queue were full, by event type.",
+		}, []string{"type"})
+
+	// Register the metrics.
+	for _, metric := range []prometheus.Collector{m.queueDepth, m.eventLatency, m.retriedEvents, m.droppedEvents} {
+		prometheus.MustRegister(metric)
+	}
+	return m
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dispatcher
+
+import (
+	"sync"
+)
+
+// bounded FIFO queue for events that could not be delivered to their channel in time
+type retryQueue struct {
+	events   []dispatchedEvent
+	capacity int
+	lock     sync.RWMutex
+}
+
+func newRetryQueue(capacity int) *retryQueue {
+	return &retryQueue{
+		events:   make([]dispatchedEvent, 0),
+		capacity: capacity,
+	}
+}
+
+// add the event at the end of the queue, returns false if the queue is full
+func (q *retryQueue) add(event dispatchedEvent) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.events) >= q.capacity {
+		return false
+	}
+	q.events = append(q.events, event)
+	return true
+}
+
+// get the event at the head of the queue without removing it
+func (q *retryQueue) peek() (dispatchedEvent, bool) {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	if len(q.events) == 0 {
+		return dispatchedEvent{}, false
+	}
+	return q.events[0], true
+}
+
+// remove the event at the head of the queue
+func (q *retryQueue) remove() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.events) > 0 {
+		q.events = q.events[1:]
+	}
+}
+
+func (q *retryQueue) size() int {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return len(q.events)
+}
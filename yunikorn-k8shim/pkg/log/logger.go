@@ -54,7 +54,7 @@ func init() {
 			StacktraceKey:  "stacktrace",
 			EncodeLevel:    zapcore.CapitalLevelEncoder,
 			EncodeTime:     zapcore.ISO8601TimeEncoder,
-			EncodeDuration: nil,
+			EncodeDuration: zapcore.StringDurationEncoder,
 			EncodeCaller:   zapcore.ShortCallerEncoder,
 		},
 		OutputPaths:      outputPaths,
@@ -19,6 +19,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/cloudera/yunikorn-k8shim/pkg/common"
 	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
 	"github.com/cloudera/yunikorn-k8shim/pkg/log"
 	"go.uber.org/zap"
@@ -38,7 +39,8 @@ var  (
 )
 
 type admissionController struct {
-
+	queueResolver      *queueResolver
+	configMapNamespace string // namespace of the scheduler ConfigMap
 }
 
 type patchOperation struct {
@@ -74,6 +76,21 @@ func (c *admissionController) mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admis
 			Path:  "/spec/schedulerName",
 			Value: conf.GetSchedulerConf().SchedulerName,
 		})
+
+		queueName, err := c.queueResolver.resolve(&pod, req.Namespace)
+		if err != nil {
+			log.Logger.Info("rejecting pod: queue resolution failed",
+				zap.String("namespace", req.Namespace),
+				zap.String("podName", pod.Name),
+				zap.Error(err))
+			return &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: err.Error(),
+				},
+			}
+		}
+		patch = append(patch, updateLabels(&pod, req.Namespace, queueName)...)
 	}
 
 	patchBytes, err := json.Marshal(patch)
@@ -95,6 +112,42 @@ func (c *admissionController) mutate(ar *v1beta1.AdmissionReview) *v1beta1.Admis
 	}
 }
 
+// Generate the patch to set the queue and application ID labels on the pod.
+// The queue label is only set if the queue is resolved, the application ID is only set if the pod does not have one.
+func updateLabels(pod *v1.Pod, namespace string, queueName string) []patchOperation {
+	labels := make(map[string]string)
+	if queueName != "" {
+		labels[common.LabelQueueName] = queueName
+	}
+	if appId, generated := getApplicationId(pod, namespace); generated {
+		labels[common.LabelApplicationId] = appId
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	// without labels on the pod the whole map must be added at once
+	if len(pod.Labels) == 0 {
+		return []patchOperation{{
+			Op:    "add",
+			Path:  "/metadata/labels",
+			Value: labels,
+		}}
+	}
+	var patch []patchOperation
+	for _, key := range []string{common.LabelQueueName, common.LabelApplicationId} {
+		value, ok := labels[key]
+		if !ok {
+			continue
+		}
+		patch = append(patch, patchOperation{
+			Op:    "add",
+			Path:  "/metadata/labels/" + key,
+			Value: value,
+		})
+	}
+	return patch
+}
+
 func (c *admissionController) serve(w http.ResponseWriter, r *http.Request) {
 	log.Logger.Debug("request", zap.Any("httpRequest", r))
 	var body []byte
@@ -125,8 +178,11 @@ func (c *admissionController) serve(w http.ResponseWriter, r *http.Request) {
 			},
 		}
 	} else {
-		if r.URL.Path == "/mutate" {
+		switch r.URL.Path {
+		case mutateURL:
 			admissionResponse = c.mutate(&ar)
+		case validateConfURL:
+			admissionResponse = c.validateConf(&ar)
 		}
 	}
 
@@ -0,0 +1,196 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/cloudera/yunikorn-core/pkg/cache"
+	"github.com/cloudera/yunikorn-core/pkg/common/configs"
+	"github.com/cloudera/yunikorn-core/pkg/scheduler/placement"
+	"github.com/cloudera/yunikorn-k8shim/pkg/common"
+	"github.com/cloudera/yunikorn-k8shim/pkg/log"
+	"go.uber.org/zap"
+	"k8s.io/api/core/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"strings"
+	"sync"
+)
+
+// the placeholder in the namespace to queue template that is replaced with the namespace name
+const namespacePlaceholder = "{namespace}"
+
+// The queue resolver decides which queue a pod is submitted to before it is admitted.
+// The queue is resolved in the following order, first match wins:
+// - the queue label on the pod
+// - the queue annotation on the namespace of the pod
+// - the namespace to queue template
+// When the scheduler configuration defines placement rules the resolved queue is passed into the
+// core placement rules as the submitted queue, the namespace is passed in as the "namespace" tag.
+// The outcome of the placement rules is the queue the application will end up in.
+// The placement rules are simulated: the resolver never creates queues, a queue that would be created by the rules
+// is created by the core when the application is submitted.
+type queueResolver struct {
+	namespaceLister listerv1.NamespaceLister
+	queueTemplate   string
+	partition       *cache.PartitionInfo
+	placement       *placement.AppPlacementManager
+	lock            sync.RWMutex
+}
+
+func newQueueResolver(namespaceLister listerv1.NamespaceLister, queueTemplate string) *queueResolver {
+	return &queueResolver{
+		namespaceLister: namespaceLister,
+		queueTemplate:   queueTemplate,
+	}
+}
+
+// Update the queue structure and placement rules used by the resolver from the scheduler ConfigMap.
+// Only the default partition is used, the shim always submits applications to the default partition.
+func (r *queueResolver) updateConfig(configMap *v1.ConfigMap, policyGroup string) error {
+	data, ok := configMap.Data[policyGroup+".yaml"]
+	if !ok {
+		return fmt.Errorf("configMap %s does not contain configuration for policy group %s",
+			configMap.Name, policyGroup)
+	}
+	schedulerConf, err := configs.LoadSchedulerConfigFromByteArray([]byte(data))
+	if err != nil {
+		return err
+	}
+	for _, partitionConf := range schedulerConf.Partitions {
+		if partitionConf.Name != common.DefaultPartition {
+			continue
+		}
+		partition, err := cache.NewPartitionInfo(partitionConf, "", nil)
+		if err != nil {
+			return err
+		}
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.releasePartition()
+		r.partition = partition
+		r.placement = placement.NewPlacementManager(partition)
+		log.Logger.Info("queue resolver configuration updated",
+			zap.String("policyGroup", policyGroup),
+			zap.Bool("placementRules", r.placement.IsInitialised()))
+		return nil
+	}
+	return fmt.Errorf("configuration for policy group %s does not contain partition %s",
+		policyGroup, common.DefaultPartition)
+}
+
+// Remove the configuration: the resolver falls back to the pod label, namespace annotation and template only.
+func (r *queueResolver) removeConfig() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.releasePartition()
+	r.partition = nil
+	r.placement = nil
+}
+
+// Release the partition that is replaced or removed: the partition holds a shared user group cache that is only
+// stopped when all partitions using it have released it. Must be called while holding the lock.
+func (r *queueResolver) releasePartition() {
+	if r.partition != nil {
+		r.partition.ReleaseUserGroupCache()
+	}
+}
+
+// Resolve the queue for the pod in the given namespace.
+// An error is returned if the queue cannot be resolved, does not exist or the submit ACL does not allow
+// the user to submit to the queue. An empty queue name without an error means that the resolver does not
+// have a scheduler configuration and no queue could be derived from the pod or namespace.
+func (r *queueResolver) resolve(pod *v1.Pod, namespace string) (string, error) {
+	queueName, err := r.getSubmittedQueue(pod, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	// without configuration we cannot check anything just use what we found
+	if r.partition == nil {
+		return queueName, nil
+	}
+	// the user is the service account, the same as what the shim sends to the core. The shim does not send groups:
+	// resolve them using the resolver of the partition, as the core does. If the groups cannot be resolved the
+	// placement rules and ACLs could give a different answer than the core: leave the checks to the core.
+	user, err := r.partition.GetUserGroup(pod.Spec.ServiceAccountName)
+	if err != nil {
+		log.Logger.Warn("user groups could not be resolved, skipping queue checks",
+			zap.String("user", pod.Spec.ServiceAccountName),
+			zap.Error(err))
+		return queueName, nil
+	}
+	if r.placement.IsInitialised() {
+		appId, _ := getApplicationId(pod, namespace)
+		app := cache.NewApplicationInfo(appId, r.partition.Name, queueName, user,
+			map[string]string{"namespace": namespace})
+		decision, err := r.placement.SimulatePlacement(app)
+		if err != nil {
+			return "", err
+		}
+		return decision.Queue, nil
+	}
+	if queueName == "" {
+		return "", fmt.Errorf("no queue found for pod in namespace %s", namespace)
+	}
+	queue := r.partition.GetQueue(queueName)
+	if queue == nil {
+		return "", fmt.Errorf("queue %s does not exist", queueName)
+	}
+	if !queue.IsLeafQueue() {
+		return "", fmt.Errorf("queue %s is not a leaf queue", queueName)
+	}
+	if !queue.CheckSubmitAccess(user) {
+		return "", fmt.Errorf("user %s is not allowed to submit to queue %s", user.User, queueName)
+	}
+	return queueName, nil
+}
+
+// Get the queue name from the pod, the namespace or the template, in that order.
+func (r *queueResolver) getSubmittedQueue(pod *v1.Pod, namespace string) (string, error) {
+	if queueName, ok := pod.Labels[common.LabelQueueName]; ok && queueName != "" {
+		return queueName, nil
+	}
+	if r.namespaceLister != nil {
+		ns, err := r.namespaceLister.Get(namespace)
+		if err != nil {
+			return "", fmt.Errorf("failed to get namespace %s: %v", namespace, err)
+		}
+		if queueName, ok := ns.Annotations[common.NamespaceAnnotationQueueName]; ok && queueName != "" {
+			return queueName, nil
+		}
+	}
+	if r.queueTemplate != "" {
+		return strings.Replace(r.queueTemplate, namespacePlaceholder, namespace, -1), nil
+	}
+	return "", nil
+}
+
+// Get the application ID for the pod, if the pod does not have one generate it based on the namespace.
+// The boolean returned is true when the ID was generated.
+func getApplicationId(pod *v1.Pod, namespace string) (string, bool) {
+	for _, label := range []string{common.SparkLabelAppId, common.LabelApplicationId} {
+		if appId, ok := pod.Labels[label]; ok && appId != "" {
+			return appId, false
+		}
+	}
+	if appId, ok := pod.Annotations[common.LabelApplicationId]; ok && appId != "" {
+		return appId, false
+	}
+	return fmt.Sprintf("%s-%s-%s", common.AutoGenAppPrefix, namespace, common.AutoGenAppSuffix), true
+}
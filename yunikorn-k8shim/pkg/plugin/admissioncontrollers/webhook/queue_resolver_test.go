@@ -0,0 +1,208 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/cloudera/yunikorn-core/pkg/common/security"
+	"github.com/cloudera/yunikorn-k8shim/pkg/common"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"k8s.io/api/core/v1"
+	apis "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const queuesConfig = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: dev
+            submitacl: "sa-dev"
+          - name: prod
+            submitacl: "sa-prod"
+          - name: parent
+            parent: true
+`
+
+const placementConfig = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        submitacl: "*"
+        queues:
+          - name: prod
+    placementrules:
+      - name: tag
+        value: namespace
+        create: true
+`
+
+func newTestResolver(t *testing.T, config string, template string, namespaces ...*v1.Namespace) *queueResolver {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range namespaces {
+		assert.NilError(t, indexer.Add(ns))
+	}
+	resolver := newQueueResolver(listerv1.NewNamespaceLister(indexer), template)
+	if config != "" {
+		configMap := &v1.ConfigMap{
+			ObjectMeta: apis.ObjectMeta{Name: common.DefaultConfigMapName},
+			Data:       map[string]string{"queues.yaml": config},
+		}
+		assert.NilError(t, resolver.updateConfig(configMap, "queues"))
+	}
+	return resolver
+}
+
+func newTestPod(serviceAccount string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: apis.ObjectMeta{Name: "pod-1", Labels: labels},
+		Spec:       v1.PodSpec{ServiceAccountName: serviceAccount},
+	}
+}
+
+func TestResolveWithoutConfig(t *testing.T) {
+	resolver := newTestResolver(t, "", "",
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{Name: "dev"}})
+	queue, err := resolver.resolve(newTestPod("sa", nil), "dev")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "")
+
+	queue, err = resolver.resolve(newTestPod("sa", map[string]string{common.LabelQueueName: "root.any"}), "dev")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "root.any")
+
+	// namespace must be known
+	_, err = resolver.resolve(newTestPod("sa", nil), "unknown")
+	assert.Assert(t, err != nil, "unknown namespace should have failed")
+}
+
+func TestResolveOrder(t *testing.T) {
+	resolver := newTestResolver(t, queuesConfig, "root.{namespace}",
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{Name: "dev"}},
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{
+			Name:        "other",
+			Annotations: map[string]string{common.NamespaceAnnotationQueueName: "root.prod"},
+		}})
+	// template
+	queue, err := resolver.resolve(newTestPod("sa-dev", nil), "dev")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "root.dev")
+	// label overrides the template
+	queue, err = resolver.resolve(newTestPod("sa-prod", map[string]string{common.LabelQueueName: "root.prod"}), "dev")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "root.prod")
+	// namespace annotation overrides the template
+	queue, err = resolver.resolve(newTestPod("sa-prod", nil), "other")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "root.prod")
+}
+
+func TestResolveRejected(t *testing.T) {
+	resolver := newTestResolver(t, queuesConfig, "root.{namespace}",
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{Name: "dev"}},
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{Name: "test"}},
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{Name: "parent"}})
+	// queue does not exist
+	_, err := resolver.resolve(newTestPod("sa-dev", nil), "test")
+	assert.ErrorContains(t, err, "does not exist")
+	// parent queue
+	_, err = resolver.resolve(newTestPod("sa-dev", nil), "parent")
+	assert.ErrorContains(t, err, "not a leaf queue")
+	// ACL denies the user
+	_, err = resolver.resolve(newTestPod("sa-prod", nil), "dev")
+	assert.ErrorContains(t, err, "not allowed to submit")
+}
+
+func TestResolvePlacementRules(t *testing.T) {
+	resolver := newTestResolver(t, placementConfig, "",
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{Name: "prod"}},
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{Name: "new"}})
+	queue, err := resolver.resolve(newTestPod("sa", nil), "prod")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "root.prod")
+	// tag rule would create the queue: the resolver must not create it
+	queue, err = resolver.resolve(newTestPod("sa", nil), "new")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "root.new")
+	assert.Assert(t, resolver.partition.GetQueue("root.new") == nil, "resolver must not create queues")
+}
+
+const groupConfig = `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        queues:
+          - name: dev
+            submitacl: " devs"
+    usergroups:
+      resolver: file
+      file: %s
+`
+
+func TestResolveGroups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resolver")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	userFile := filepath.Join(dir, "users.yaml")
+	assert.NilError(t, ioutil.WriteFile(userFile, []byte("users:\n  sa-dev:\n    - devs\n"), 0600))
+	resolver := newTestResolver(t, fmt.Sprintf(groupConfig, userFile), "root.{namespace}",
+		&v1.Namespace{ObjectMeta: apis.ObjectMeta{Name: "dev"}})
+	// the groups are resolved using the partition resolver: the ACL only allows the group
+	queue, err := resolver.resolve(newTestPod("sa-dev", nil), "dev")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "root.dev")
+	// the user is not in the file: resolution fails and the checks are left to the core
+	queue, err = resolver.resolve(newTestPod("sa-other", nil), "dev")
+	assert.NilError(t, err)
+	assert.Equal(t, queue, "root.dev")
+}
+
+func TestUpdateConfigReleasesUserGroupCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resolver")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	userFile := filepath.Join(dir, "users.yaml")
+	assert.NilError(t, ioutil.WriteFile(userFile, []byte("users:\n  sa-dev:\n    - devs\n"), 0600))
+	config := fmt.Sprintf(groupConfig, userFile)
+	resolver := newTestResolver(t, config, "")
+	// the partition of the resolver shares the cache for the same settings
+	ugc, err := security.GetUserGroupCacheFromConfig(security.ResolverConfig{Type: security.ResolverFile, File: userFile})
+	assert.NilError(t, err)
+	defer ugc.Release()
+	assert.Equal(t, ugc.GetUserCount(), 2)
+
+	// each update replaces the partition: the old partition releases the cache
+	configMap := &v1.ConfigMap{
+		ObjectMeta: apis.ObjectMeta{Name: common.DefaultConfigMapName},
+		Data:       map[string]string{"queues.yaml": config},
+	}
+	assert.NilError(t, resolver.updateConfig(configMap, "queues"))
+	assert.NilError(t, resolver.updateConfig(configMap, "queues"))
+	assert.Equal(t, ugc.GetUserCount(), 2)
+
+	resolver.removeConfig()
+	assert.Equal(t, ugc.GetUserCount(), 1)
+}
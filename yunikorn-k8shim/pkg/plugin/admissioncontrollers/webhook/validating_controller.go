@@ -0,0 +1,86 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cloudera/yunikorn-core/pkg/common/configs"
+	"github.com/cloudera/yunikorn-k8shim/pkg/common"
+	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
+	"github.com/cloudera/yunikorn-k8shim/pkg/log"
+	"go.uber.org/zap"
+	"k8s.io/api/admission/v1beta1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Validate changes to the scheduler ConfigMap before they are persisted.
+// The configuration is parsed and validated in the same way as the core does on a reload.
+// A change that would not be accepted by the core is denied with the validation error as the message.
+func (c *admissionController) validateConf(ar *v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
+	req := ar.Request
+	log.Logger.Info("AdmissionReview",
+		zap.Any("Kind", req.Kind),
+		zap.String("Namespace", req.Namespace),
+		zap.String("Name", req.Name),
+		zap.String("UID", string(req.UID)),
+		zap.String("Operation", string(req.Operation)),
+		zap.Any("UserInfo", req.UserInfo))
+
+	if req.Kind.Kind == "ConfigMap" {
+		var configMap v1.ConfigMap
+		if err := json.Unmarshal(req.Object.Raw, &configMap); err != nil {
+			return &v1beta1.AdmissionResponse{
+				Result: &metav1.Status{
+					Message: err.Error(),
+				},
+			}
+		}
+		// only the scheduler ConfigMap is checked: a ConfigMap with the same name in another namespace is not used
+		// by the scheduler. The namespace is not always set in the object on create, use the request namespace.
+		if configMap.Name == common.DefaultConfigMapName && req.Namespace == c.configMapNamespace {
+			if err := validateConfigMap(&configMap, conf.GetSchedulerConf().PolicyGroup); err != nil {
+				log.Logger.Info("rejecting scheduler configuration update",
+					zap.String("namespace", req.Namespace),
+					zap.String("name", configMap.Name),
+					zap.Error(err))
+				return &v1beta1.AdmissionResponse{
+					Allowed: false,
+					Result: &metav1.Status{
+						Message: err.Error(),
+					},
+				}
+			}
+		}
+	}
+
+	return &v1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// Check the scheduler configuration for the policy group in the ConfigMap.
+func validateConfigMap(configMap *v1.ConfigMap, policyGroup string) error {
+	data, ok := configMap.Data[policyGroup+".yaml"]
+	if !ok {
+		return fmt.Errorf("configMap %s does not contain configuration for policy group %s",
+			configMap.Name, policyGroup)
+	}
+	_, err := configs.LoadSchedulerConfigFromByteArray([]byte(data))
+	return err
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 Cloudera, Inc.  All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"github.com/cloudera/yunikorn-k8shim/pkg/common"
+	"gotest.tools/assert"
+	"k8s.io/api/admission/v1beta1"
+	"k8s.io/api/core/v1"
+	apis "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"testing"
+)
+
+func newConfigMapReview(t *testing.T, namespace, name string, data map[string]string) *v1beta1.AdmissionReview {
+	raw, err := json.Marshal(&v1.ConfigMap{
+		ObjectMeta: apis.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       data,
+	})
+	assert.NilError(t, err)
+	return &v1beta1.AdmissionReview{
+		Request: &v1beta1.AdmissionRequest{
+			Kind:      apis.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Namespace: namespace,
+			Name:      name,
+			Operation: v1beta1.Update,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func TestValidateConf(t *testing.T) {
+	c := &admissionController{configMapNamespace: "yunikorn"}
+	// valid configuration
+	resp := c.validateConf(newConfigMapReview(t, "yunikorn", common.DefaultConfigMapName,
+		map[string]string{"queues.yaml": queuesConfig}))
+	assert.Assert(t, resp.Allowed, "valid configuration should have been allowed")
+
+	// invalid configuration: resources must be numeric
+	invalid := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+        resources:
+          max:
+            memory: unlimited
+`
+	resp = c.validateConf(newConfigMapReview(t, "yunikorn", common.DefaultConfigMapName,
+		map[string]string{"queues.yaml": invalid}))
+	assert.Assert(t, !resp.Allowed, "invalid configuration should have been denied")
+	assert.Assert(t, resp.Result != nil && resp.Result.Message != "", "denied update should have a message")
+
+	// missing policy group
+	resp = c.validateConf(newConfigMapReview(t, "yunikorn", common.DefaultConfigMapName,
+		map[string]string{"other.yaml": queuesConfig}))
+	assert.Assert(t, !resp.Allowed, "configuration without policy group should have been denied")
+
+	// other ConfigMaps are not checked
+	resp = c.validateConf(newConfigMapReview(t, "yunikorn", "other-configs",
+		map[string]string{"queues.yaml": invalid}))
+	assert.Assert(t, resp.Allowed, "other ConfigMap should have been allowed")
+	// the same name in another namespace is not the scheduler ConfigMap
+	resp = c.validateConf(newConfigMapReview(t, "other", common.DefaultConfigMapName,
+		map[string]string{"queues.yaml": invalid}))
+	assert.Assert(t, resp.Allowed, "ConfigMap in other namespace should have been allowed")
+}
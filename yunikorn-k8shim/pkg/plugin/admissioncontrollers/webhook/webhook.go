@@ -20,8 +20,14 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"github.com/cloudera/yunikorn-k8shim/pkg/client"
+	"github.com/cloudera/yunikorn-k8shim/pkg/common"
+	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
 	"github.com/cloudera/yunikorn-k8shim/pkg/log"
 	"go.uber.org/zap"
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	k8sCache "k8s.io/client-go/tools/cache"
 	"net/http"
 	"os"
 	"os/signal"
@@ -34,6 +40,13 @@ const (
 	tlsDir      = `/run/secrets/tls`
 	tlsCertFile = `cert.pem`
 	tlsKeyFile  = `key.pem`
+	// endpoints served by the admission controller
+	mutateURL       = "/mutate"
+	validateConfURL = "/validate-conf"
+	// environment variables to configure the queue resolution
+	envConfigMapNamespace     = "CONFIGMAP_NAMESPACE"
+	envNamespaceQueueTemplate = "NAMESPACE_QUEUE_TEMPLATE"
+	defaultConfigMapNamespace = "default"
 )
 
 func main() {
@@ -44,9 +57,18 @@ func main() {
 		log.Logger.Fatal("Failed to load key pair", zap.Error(err))
 	}
 
-	webHook := admissionController{}
+	configMapNamespace := os.Getenv(envConfigMapNamespace)
+	if configMapNamespace == "" {
+		configMapNamespace = defaultConfigMapNamespace
+	}
+	stopCh := make(chan struct{})
+	webHook := admissionController{
+		queueResolver:      startQueueResolver(configMapNamespace, stopCh),
+		configMapNamespace: configMapNamespace,
+	}
 	mux := http.NewServeMux()
-	mux.HandleFunc("/mutate", webHook.serve)
+	mux.HandleFunc(mutateURL, webHook.serve)
+	mux.HandleFunc(validateConfURL, webHook.serve)
 	server := &http.Server{
 		Addr: fmt.Sprintf(":%v", HttpPort),
 		TLSConfig: &tls.Config{Certificates: []tls.Certificate{pair}},
@@ -61,15 +83,61 @@ func main() {
 
 	log.Logger.Info("the admission controller started",
 		zap.Int("port", HttpPort),
-		zap.String("listeningOn", "/mutate"))
+		zap.Strings("listeningOn", []string{mutateURL, validateConfURL}))
 
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 	<-signalChan
 
 	log.Logger.Info("shutting down the admission controller...")
+	close(stopCh)
 	err = server.Shutdown(context.Background()); if err != nil {
 		log.Logger.Warn("failed to stop the admission controller",
 			zap.Error(err))
 	}
 }
+
+// Create the queue resolver and start the informers that keep the namespaces and scheduler configuration
+// up to date. The call blocks until the informer caches are synced.
+func startQueueResolver(configMapNamespace string, stopCh <-chan struct{}) *queueResolver {
+	kubeClient := client.NewKubeClient(conf.GetSchedulerConf().KubeConfig)
+	informerFactory := informers.NewSharedInformerFactory(kubeClient.GetClientSet(), 0)
+	namespaceInformer := informerFactory.Core().V1().Namespaces()
+	configMapInformer := informers.NewSharedInformerFactoryWithOptions(kubeClient.GetClientSet(), 0,
+		informers.WithNamespace(configMapNamespace)).Core().V1().ConfigMaps()
+
+	resolver := newQueueResolver(namespaceInformer.Lister(), os.Getenv(envNamespaceQueueTemplate))
+	policyGroup := conf.GetSchedulerConf().PolicyGroup
+	updateConfig := func(obj interface{}) {
+		if configMap, ok := obj.(*v1.ConfigMap); ok {
+			if err := resolver.updateConfig(configMap, policyGroup); err != nil {
+				log.Logger.Warn("failed to update queue resolver configuration, keeping old configuration",
+					zap.Error(err))
+			}
+		}
+	}
+	configMapInformer.Informer().AddEventHandler(k8sCache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			configMap, ok := obj.(*v1.ConfigMap)
+			return ok && configMap.Name == common.DefaultConfigMapName && configMap.Namespace == configMapNamespace
+		},
+		Handler: k8sCache.ResourceEventHandlerFuncs{
+			AddFunc: updateConfig,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				updateConfig(newObj)
+			},
+			DeleteFunc: func(obj interface{}) {
+				log.Logger.Info("scheduler configMap removed, queue resolver has no configuration")
+				resolver.removeConfig()
+			},
+		},
+	})
+
+	go namespaceInformer.Informer().Run(stopCh)
+	go configMapInformer.Informer().Run(stopCh)
+	if !k8sCache.WaitForCacheSync(stopCh, namespaceInformer.Informer().HasSynced,
+		configMapInformer.Informer().HasSynced) {
+		log.Logger.Fatal("failed to sync informer caches for the queue resolver")
+	}
+	return resolver
+}
@@ -19,6 +19,8 @@ package main
 import (
 	"github.com/cloudera/yunikorn-core/pkg/api"
 	"github.com/cloudera/yunikorn-core/pkg/entrypoint"
+	"github.com/cloudera/yunikorn-core/pkg/scheduler"
+	"github.com/cloudera/yunikorn-core/pkg/webservice"
 	"github.com/cloudera/yunikorn-k8shim/pkg/conf"
 	"github.com/cloudera/yunikorn-k8shim/pkg/log"
 	"go.uber.org/zap"
@@ -37,7 +39,8 @@ func main() {
 	log.Logger.Info("starting scheduler",
 		zap.String("name", conf.GetSchedulerConf().SchedulerName))
 
-	serviceContext := entrypoint.StartAllServices()
+	serviceContext := entrypoint.StartAllServicesWithConfig(getScheduleLoopConfig(conf.GetSchedulerConf()),
+		getWebAuthConfig(conf.GetSchedulerConf()))
 
 	if sa, ok := serviceContext.RMProxy.(api.SchedulerApi); ok {
 		//conf包含一些默认的属性值
@@ -56,3 +59,21 @@ func main() {
 		}
 	}
 }
+
+// get the settings for the scheduling loop of the core from the shim configuration
+func getScheduleLoopConfig(configs *conf.SchedulerConf) scheduler.ScheduleLoopConfig {
+	return scheduler.ScheduleLoopConfig{
+		MinInterval: configs.ScheduleMinInterval,
+		MaxIdleTick: configs.ScheduleMaxIdleTick,
+	}
+}
+
+// get the settings to authenticate the users of the core web service from the shim configuration
+func getWebAuthConfig(configs *conf.SchedulerConf) webservice.AuthConfig {
+	return webservice.AuthConfig{
+		ProxyHeader:  configs.WebAuthProxyHeader,
+		CertFile:     configs.WebCertFile,
+		KeyFile:      configs.WebKeyFile,
+		ClientCAFile: configs.WebClientCAFile,
+	}
+}
@@ -41,6 +41,9 @@ const fakeClusterId = "test-cluster"
 const fakeClusterVersion = "0.1.0"
 const fakeClusterSchedulerName = "yunikorn-test"
 const fakeClusterSchedulingInterval = time.Second
+const fakeScheduleMinInterval = 5 * time.Millisecond
+const fakeScheduleMaxIdleTick = 500 * time.Millisecond
+const fakeTaskRetryBackoff = 10 * time.Millisecond
 
 // fake cluster is used for testing
 // it uses fake kube client to simulate API calls with k8s, all other code paths are real
@@ -54,6 +57,8 @@ type MockScheduler struct {
 	bindFn      func(pod *v1.Pod, hostId string) error
 	deleteFn    func(pod *v1.Pod) error
 	stopChan    chan struct{}
+	// task retry settings, the retries use a short backoff to not slow down the tests
+	taskRetryLimit int
 }
 
 func (fc *MockScheduler) init(queues string) {
@@ -61,9 +66,14 @@ func (fc *MockScheduler) init(queues string) {
 		ClusterId:      fakeClusterId,
 		ClusterVersion: fakeClusterVersion,
 		SchedulerName:  fakeClusterSchedulerName,
-		Interval:       fakeClusterSchedulingInterval,
-		KubeConfig:     "",
-		TestMode:       true,
+		Interval:            fakeClusterSchedulingInterval,
+		KubeConfig:          "",
+		TestMode:            true,
+		ScheduleMinInterval: fakeScheduleMinInterval,
+		ScheduleMaxIdleTick: fakeScheduleMaxIdleTick,
+		TaskRetryLimit:      fc.taskRetryLimit,
+		TaskRetryBackoff:    fakeTaskRetryBackoff,
+		TaskRetryMaxBackoff: fakeTaskRetryBackoff,
 	}
 
 	conf.Set(&configs)
@@ -84,7 +94,7 @@ func (fc *MockScheduler) init(queues string) {
 		}
 	}
 
-	serviceContext := entrypoint.StartAllServices()
+	serviceContext := entrypoint.StartAllServicesWithScheduleLoopConfig(getScheduleLoopConfig(&configs))
 	rmProxy := serviceContext.RMProxy
 	coreconfigs.MockSchedulerConfigByData([]byte(fc.conf))
 
@@ -124,9 +134,9 @@ func (fc *MockScheduler) addNode(nodeName string, memory, cpu int64) error {
 	return fc.proxy.Update(&request)
 }
 
-func (fc *MockScheduler) addTask(tid string, ask *si.Resource, app *cache.Application) cache.Task{
+func (fc *MockScheduler) addTask(tid string, ask *si.Resource, app *cache.Application) *cache.Task {
 	task := cache.CreateTaskForTest(tid, app, ask, fc.client, fc.proxy)
-	app.AddTask(&task)
+	app.AddTask(task)
 	return task
 }
 
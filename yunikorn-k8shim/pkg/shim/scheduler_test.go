@@ -26,7 +26,9 @@ import (
 	"github.com/cloudera/yunikorn-k8shim/pkg/log"
 	"github.com/cloudera/yunikorn-scheduler-interface/lib/go/si"
 	"go.uber.org/zap"
+	"gotest.tools/assert"
 	"k8s.io/api/core/v1"
+	"sync"
 	"testing"
 	"time"
 )
@@ -182,6 +184,8 @@ partitions:
 `
 	// init and register scheduler
 	cluster := MockScheduler{}
+	// a failed bind is not retried
+	cluster.taskRetryLimit = -1
 	// mock pod bind failures
 	cluster.bindFn = func(pod *v1.Pod, hostId string) error {
 		if pod.Name == "task0001" {
@@ -229,6 +233,58 @@ partitions:
 	}
 }
 
+func TestTaskRetries(t *testing.T) {
+	configData := `
+partitions:
+ - name: default
+   queues:
+     - name: root
+       submitacl: "*"
+       queues:
+         - name: a
+`
+	cluster := MockScheduler{}
+	cluster.taskRetryLimit = 2
+	// the bind of task0001 always fails: the task is retried and fails when the retries are exhausted
+	var lock sync.Mutex
+	binds := 0
+	cluster.bindFn = func(pod *v1.Pod, hostId string) error {
+		if pod.Name == "task0001" {
+			lock.Lock()
+			defer lock.Unlock()
+			binds++
+			return fmt.Errorf("mocked error when binding the pod")
+		}
+		return nil
+	}
+	cluster.init(configData)
+	cluster.start()
+	defer cluster.stop()
+
+	cluster.waitForSchedulerState(t, events.States().Scheduler.Running)
+	if err := cluster.addNode("test.host.01", 100, 10); err != nil {
+		t.Fatalf("add node failed %v", err)
+	}
+	app0001 := cluster.newApplication("app0001", "root.a")
+	taskResource := common.NewResourceBuilder().
+		AddResource(common.Memory, 10).
+		AddResource(common.CPU, 1).
+		Build()
+	cluster.addTask("task0001", taskResource, app0001)
+	cluster.addApplication(app0001)
+
+	cluster.waitAndAssertTaskState(t, "app0001", "task0001", events.States().Task.Failed)
+	// the first bind and one bind per retry
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, binds, 3)
+	// the allocations of the failed binds are released
+	if err := cluster.waitAndVerifySchedulerAllocations("root.a",
+		"[test-cluster]default", "app0001", 0); err != nil {
+		t.Fatalf("number of allocations is not expected, error: %v", err)
+	}
+}
+
 func waitShimSchedulerState(shim *KubernetesShim, expectedState string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
 	for {
@@ -245,3 +301,21 @@ func waitShimSchedulerState(shim *KubernetesShim, expectedState string, timeout
 		}
 	}
 }
+
+func TestScheduleLoopConfig(t *testing.T) {
+	configData := `
+partitions:
+  - name: default
+    queues:
+      - name: root
+`
+	cluster := MockScheduler{}
+	cluster.init(configData)
+	cluster.start()
+	defer cluster.stop()
+
+	// the settings from the shim configuration are passed to the core scheduler
+	loopConfig := cluster.coreContext.Scheduler.GetScheduleLoopConfig()
+	assert.Equal(t, loopConfig.MinInterval, fakeScheduleMinInterval)
+	assert.Equal(t, loopConfig.MaxIdleTick, fakeScheduleMaxIdleTick)
+}
@@ -51,6 +51,40 @@ func (UpdateResponse_ActionFromScheduler) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_fc4a0b9b2d5549ed, []int{3, 0}
 }
 
+type EventRecord_Type int32
+
+const (
+	EventRecord_REQUEST    EventRecord_Type = 0
+	EventRecord_APP        EventRecord_Type = 1
+	EventRecord_NODE       EventRecord_Type = 2
+	EventRecord_QUEUE      EventRecord_Type = 3
+	EventRecord_ALLOCATION EventRecord_Type = 4
+)
+
+var EventRecord_Type_name = map[int32]string{
+	0: "REQUEST",
+	1: "APP",
+	2: "NODE",
+	3: "QUEUE",
+	4: "ALLOCATION",
+}
+
+var EventRecord_Type_value = map[string]int32{
+	"REQUEST":    0,
+	"APP":        1,
+	"NODE":       2,
+	"QUEUE":      3,
+	"ALLOCATION": 4,
+}
+
+func (x EventRecord_Type) String() string {
+	return proto.EnumName(EventRecord_Type_name, int32(x))
+}
+
+func (EventRecord_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{4, 0}
+}
+
 // Following 4 operators can be specified, by default is "IN".
 // When EXIST/NOT_EXISTS specified, scheduler only check if given targetKey
 // appears on node attribute or allocation tag.
@@ -82,7 +116,7 @@ func (x AffinityTargetExpression_AffinityTargetOperator) String() string {
 }
 
 func (AffinityTargetExpression_AffinityTargetOperator) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{19, 0}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{24, 0}
 }
 
 // Action from RM
@@ -116,7 +150,7 @@ func (x UpdateNodeInfo_ActionFromRM) String() string {
 }
 
 func (UpdateNodeInfo_ActionFromRM) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{24, 0}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{29, 0}
 }
 
 type AllocationReleaseResponse_TerminationType int32
@@ -147,7 +181,7 @@ func (x AllocationReleaseResponse_TerminationType) String() string {
 }
 
 func (AllocationReleaseResponse_TerminationType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{29, 0}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{34, 0}
 }
 
 //
@@ -270,10 +304,12 @@ type UpdateRequest struct {
 	// This is optional if allocation request doesn't belong to a application. (Independent allocation)
 	NewApplications []*AddApplicationRequest `protobuf:"bytes,8,rep,name=newApplications,proto3" json:"newApplications,omitempty"`
 	// RM can also remove applications, all allocation/allocation requests associated with the application will be removed
-	RemoveApplications   []*RemoveApplicationRequest `protobuf:"bytes,9,rep,name=removeApplications,proto3" json:"removeApplications,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	RemoveApplications []*RemoveApplicationRequest `protobuf:"bytes,9,rep,name=removeApplications,proto3" json:"removeApplications,omitempty"`
+	// RM can move running applications to a different queue, the allocations and allocation requests move with the application
+	MoveApplications     []*MoveApplicationRequest `protobuf:"bytes,10,rep,name=moveApplications,proto3" json:"moveApplications,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
@@ -357,6 +393,13 @@ func (m *UpdateRequest) GetRemoveApplications() []*RemoveApplicationRequest {
 	return nil
 }
 
+func (m *UpdateRequest) GetMoveApplications() []*MoveApplicationRequest {
+	if m != nil {
+		return m.MoveApplications
+	}
+	return nil
+}
+
 type UpdateResponse struct {
 	// What RM needs to do, scheduler can send control code to RM when something goes wrong.
 	// Don't use/expand this field for other general purposed actions. (Like kill a remote container process).
@@ -382,10 +425,19 @@ type UpdateResponse struct {
 	// Rejected Node Registrations
 	RejectedNodes []*RejectedNode `protobuf:"bytes,8,rep,name=rejectedNodes,proto3" json:"rejectedNodes,omitempty"`
 	// Accepted Node Registrations
-	AcceptedNodes        []*AcceptedNode `protobuf:"bytes,9,rep,name=acceptedNodes,proto3" json:"acceptedNodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	AcceptedNodes []*AcceptedNode `protobuf:"bytes,9,rep,name=acceptedNodes,proto3" json:"acceptedNodes,omitempty"`
+	// Applications moved to a different queue
+	MovedApplications []*MovedApplication `protobuf:"bytes,11,rep,name=movedApplications,proto3" json:"movedApplications,omitempty"`
+	// Application moves that were rejected, the application stays in its current queue
+	RejectedApplicationMoves []*RejectedApplicationMove `protobuf:"bytes,12,rep,name=rejectedApplicationMoves,proto3" json:"rejectedApplicationMoves,omitempty"`
+	// Applications removed by the scheduler, like completed applications that are removed after the completing timeout
+	RemovedApplications []*RemovedApplication `protobuf:"bytes,13,rep,name=removedApplications,proto3" json:"removedApplications,omitempty"`
+	// Events from the scheduler that explain scheduling decisions, like the reason why an ask is not allocated.
+	// The RM can show the events to the user, events are batched and rate limited per object by the scheduler.
+	Events               []*EventRecord `protobuf:"bytes,10,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
@@ -476,6 +528,129 @@ func (m *UpdateResponse) GetAcceptedNodes() []*AcceptedNode {
 	return nil
 }
 
+func (m *UpdateResponse) GetMovedApplications() []*MovedApplication {
+	if m != nil {
+		return m.MovedApplications
+	}
+	return nil
+}
+
+func (m *UpdateResponse) GetRejectedApplicationMoves() []*RejectedApplicationMove {
+	if m != nil {
+		return m.RejectedApplicationMoves
+	}
+	return nil
+}
+
+func (m *UpdateResponse) GetRemovedApplications() []*RemovedApplication {
+	if m != nil {
+		return m.RemovedApplications
+	}
+	return nil
+}
+
+func (m *UpdateResponse) GetEvents() []*EventRecord {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type EventRecord struct {
+	// The type of the object the event is about
+	Type EventRecord_Type `protobuf:"varint,1,opt,name=type,proto3,enum=si.v1.EventRecord_Type" json:"type,omitempty"`
+	// The ID of the object: the allocation key for a request, the UUID for an allocation,
+	// the application, node or queue ID otherwise
+	ObjectId string `protobuf:"bytes,2,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	// The application of the object, set for request, allocation and application events
+	ApplicationId string `protobuf:"bytes,3,opt,name=applicationId,proto3" json:"applicationId,omitempty"`
+	// A short machine readable reason for the event
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// A human-readable message
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// Number of times the event happened since the event was last sent
+	Count int32 `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	// Time the event happened last, in nanoseconds since the epoch
+	TimestampNano        int64    `protobuf:"varint,7,opt,name=timestampNano,proto3" json:"timestampNano,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventRecord) Reset()         { *m = EventRecord{} }
+func (m *EventRecord) String() string { return proto.CompactTextString(m) }
+func (*EventRecord) ProtoMessage()    {}
+func (*EventRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{4}
+}
+
+func (m *EventRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventRecord.Unmarshal(m, b)
+}
+func (m *EventRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventRecord.Marshal(b, m, deterministic)
+}
+func (m *EventRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventRecord.Merge(m, src)
+}
+func (m *EventRecord) XXX_Size() int {
+	return xxx_messageInfo_EventRecord.Size(m)
+}
+func (m *EventRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventRecord proto.InternalMessageInfo
+
+func (m *EventRecord) GetType() EventRecord_Type {
+	if m != nil {
+		return m.Type
+	}
+	return EventRecord_REQUEST
+}
+
+func (m *EventRecord) GetObjectId() string {
+	if m != nil {
+		return m.ObjectId
+	}
+	return ""
+}
+
+func (m *EventRecord) GetApplicationId() string {
+	if m != nil {
+		return m.ApplicationId
+	}
+	return ""
+}
+
+func (m *EventRecord) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *EventRecord) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *EventRecord) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *EventRecord) GetTimestampNano() int64 {
+	if m != nil {
+		return m.TimestampNano
+	}
+	return 0
+}
+
 type RejectedApplication struct {
 	// The application ID that was rejected
 	ApplicationId string `protobuf:"bytes,1,opt,name=applicationId,proto3" json:"applicationId,omitempty"`
@@ -490,7 +665,7 @@ func (m *RejectedApplication) Reset()         { *m = RejectedApplication{} }
 func (m *RejectedApplication) String() string { return proto.CompactTextString(m) }
 func (*RejectedApplication) ProtoMessage()    {}
 func (*RejectedApplication) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{4}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{5}
 }
 
 func (m *RejectedApplication) XXX_Unmarshal(b []byte) error {
@@ -537,7 +712,7 @@ func (m *AcceptedApplication) Reset()         { *m = AcceptedApplication{} }
 func (m *AcceptedApplication) String() string { return proto.CompactTextString(m) }
 func (*AcceptedApplication) ProtoMessage()    {}
 func (*AcceptedApplication) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{5}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{6}
 }
 
 func (m *AcceptedApplication) XXX_Unmarshal(b []byte) error {
@@ -565,6 +740,153 @@ func (m *AcceptedApplication) GetApplicationId() string {
 	return ""
 }
 
+type MovedApplication struct {
+	// The application ID that was moved
+	ApplicationId string `protobuf:"bytes,1,opt,name=applicationId,proto3" json:"applicationId,omitempty"`
+	// The fully qualified name of the queue the application was moved to
+	QueueName            string   `protobuf:"bytes,2,opt,name=queueName,proto3" json:"queueName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MovedApplication) Reset()         { *m = MovedApplication{} }
+func (m *MovedApplication) String() string { return proto.CompactTextString(m) }
+func (*MovedApplication) ProtoMessage()    {}
+func (*MovedApplication) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{7}
+}
+
+func (m *MovedApplication) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MovedApplication.Unmarshal(m, b)
+}
+func (m *MovedApplication) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MovedApplication.Marshal(b, m, deterministic)
+}
+func (m *MovedApplication) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MovedApplication.Merge(m, src)
+}
+func (m *MovedApplication) XXX_Size() int {
+	return xxx_messageInfo_MovedApplication.Size(m)
+}
+func (m *MovedApplication) XXX_DiscardUnknown() {
+	xxx_messageInfo_MovedApplication.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MovedApplication proto.InternalMessageInfo
+
+func (m *MovedApplication) GetApplicationId() string {
+	if m != nil {
+		return m.ApplicationId
+	}
+	return ""
+}
+
+func (m *MovedApplication) GetQueueName() string {
+	if m != nil {
+		return m.QueueName
+	}
+	return ""
+}
+
+type RejectedApplicationMove struct {
+	// The application ID that was not moved
+	ApplicationId string `protobuf:"bytes,1,opt,name=applicationId,proto3" json:"applicationId,omitempty"`
+	// A human-readable reason message
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectedApplicationMove) Reset()         { *m = RejectedApplicationMove{} }
+func (m *RejectedApplicationMove) String() string { return proto.CompactTextString(m) }
+func (*RejectedApplicationMove) ProtoMessage()    {}
+func (*RejectedApplicationMove) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{8}
+}
+
+func (m *RejectedApplicationMove) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectedApplicationMove.Unmarshal(m, b)
+}
+func (m *RejectedApplicationMove) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectedApplicationMove.Marshal(b, m, deterministic)
+}
+func (m *RejectedApplicationMove) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectedApplicationMove.Merge(m, src)
+}
+func (m *RejectedApplicationMove) XXX_Size() int {
+	return xxx_messageInfo_RejectedApplicationMove.Size(m)
+}
+func (m *RejectedApplicationMove) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectedApplicationMove.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectedApplicationMove proto.InternalMessageInfo
+
+func (m *RejectedApplicationMove) GetApplicationId() string {
+	if m != nil {
+		return m.ApplicationId
+	}
+	return ""
+}
+
+func (m *RejectedApplicationMove) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type RemovedApplication struct {
+	// The application ID that was removed
+	ApplicationId string `protobuf:"bytes,1,opt,name=applicationId,proto3" json:"applicationId,omitempty"`
+	// A human-readable reason message
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemovedApplication) Reset()         { *m = RemovedApplication{} }
+func (m *RemovedApplication) String() string { return proto.CompactTextString(m) }
+func (*RemovedApplication) ProtoMessage()    {}
+func (*RemovedApplication) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{9}
+}
+
+func (m *RemovedApplication) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemovedApplication.Unmarshal(m, b)
+}
+func (m *RemovedApplication) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemovedApplication.Marshal(b, m, deterministic)
+}
+func (m *RemovedApplication) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemovedApplication.Merge(m, src)
+}
+func (m *RemovedApplication) XXX_Size() int {
+	return xxx_messageInfo_RemovedApplication.Size(m)
+}
+func (m *RemovedApplication) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemovedApplication.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemovedApplication proto.InternalMessageInfo
+
+func (m *RemovedApplication) GetApplicationId() string {
+	if m != nil {
+		return m.ApplicationId
+	}
+	return ""
+}
+
+func (m *RemovedApplication) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 type RejectedNode struct {
 	// The node ID that was rejected
 	NodeId string `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
@@ -579,7 +901,7 @@ func (m *RejectedNode) Reset()         { *m = RejectedNode{} }
 func (m *RejectedNode) String() string { return proto.CompactTextString(m) }
 func (*RejectedNode) ProtoMessage()    {}
 func (*RejectedNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{6}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{10}
 }
 
 func (m *RejectedNode) XXX_Unmarshal(b []byte) error {
@@ -626,7 +948,7 @@ func (m *AcceptedNode) Reset()         { *m = AcceptedNode{} }
 func (m *AcceptedNode) String() string { return proto.CompactTextString(m) }
 func (*AcceptedNode) ProtoMessage()    {}
 func (*AcceptedNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{7}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{11}
 }
 
 func (m *AcceptedNode) XXX_Unmarshal(b []byte) error {
@@ -668,7 +990,7 @@ func (m *Priority) Reset()         { *m = Priority{} }
 func (m *Priority) String() string { return proto.CompactTextString(m) }
 func (*Priority) ProtoMessage()    {}
 func (*Priority) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{8}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{12}
 }
 
 func (m *Priority) XXX_Unmarshal(b []byte) error {
@@ -746,7 +1068,7 @@ func (m *Resource) Reset()         { *m = Resource{} }
 func (m *Resource) String() string { return proto.CompactTextString(m) }
 func (*Resource) ProtoMessage()    {}
 func (*Resource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{9}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{13}
 }
 
 func (m *Resource) XXX_Unmarshal(b []byte) error {
@@ -786,7 +1108,7 @@ func (m *Quantity) Reset()         { *m = Quantity{} }
 func (m *Quantity) String() string { return proto.CompactTextString(m) }
 func (*Quantity) ProtoMessage()    {}
 func (*Quantity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{10}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{14}
 }
 
 func (m *Quantity) XXX_Unmarshal(b []byte) error {
@@ -850,7 +1172,7 @@ func (m *AllocationAsk) Reset()         { *m = AllocationAsk{} }
 func (m *AllocationAsk) String() string { return proto.CompactTextString(m) }
 func (*AllocationAsk) ProtoMessage()    {}
 func (*AllocationAsk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{11}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{15}
 }
 
 func (m *AllocationAsk) XXX_Unmarshal(b []byte) error {
@@ -960,7 +1282,7 @@ func (m *AddApplicationRequest) Reset()         { *m = AddApplicationRequest{} }
 func (m *AddApplicationRequest) String() string { return proto.CompactTextString(m) }
 func (*AddApplicationRequest) ProtoMessage()    {}
 func (*AddApplicationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{12}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{16}
 }
 
 func (m *AddApplicationRequest) XXX_Unmarshal(b []byte) error {
@@ -1037,7 +1359,7 @@ func (m *RemoveApplicationRequest) Reset()         { *m = RemoveApplicationReque
 func (m *RemoveApplicationRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveApplicationRequest) ProtoMessage()    {}
 func (*RemoveApplicationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{13}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{17}
 }
 
 func (m *RemoveApplicationRequest) XXX_Unmarshal(b []byte) error {
@@ -1072,6 +1394,65 @@ func (m *RemoveApplicationRequest) GetPartitionName() string {
 	return ""
 }
 
+type MoveApplicationRequest struct {
+	// The ID of the application to move
+	ApplicationId string `protobuf:"bytes,1,opt,name=applicationId,proto3" json:"applicationId,omitempty"`
+	// The partition the application belongs to
+	PartitionName string `protobuf:"bytes,2,opt,name=partitionName,proto3" json:"partitionName,omitempty"`
+	// The fully qualified name of the leaf queue to move the application to. The queue must exist, the scheduler
+	// checks the submit ACL of the queue for the application owner and the maximum resources of the queue.
+	QueueName            string   `protobuf:"bytes,3,opt,name=queueName,proto3" json:"queueName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveApplicationRequest) Reset()         { *m = MoveApplicationRequest{} }
+func (m *MoveApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveApplicationRequest) ProtoMessage()    {}
+func (*MoveApplicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{18}
+}
+
+func (m *MoveApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveApplicationRequest.Unmarshal(m, b)
+}
+func (m *MoveApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveApplicationRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveApplicationRequest.Merge(m, src)
+}
+func (m *MoveApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveApplicationRequest.Size(m)
+}
+func (m *MoveApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveApplicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveApplicationRequest proto.InternalMessageInfo
+
+func (m *MoveApplicationRequest) GetApplicationId() string {
+	if m != nil {
+		return m.ApplicationId
+	}
+	return ""
+}
+
+func (m *MoveApplicationRequest) GetPartitionName() string {
+	if m != nil {
+		return m.PartitionName
+	}
+	return ""
+}
+
+func (m *MoveApplicationRequest) GetQueueName() string {
+	if m != nil {
+		return m.QueueName
+	}
+	return ""
+}
+
 type UserGroupInformation struct {
 	// the user name
 	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -1086,7 +1467,7 @@ func (m *UserGroupInformation) Reset()         { *m = UserGroupInformation{} }
 func (m *UserGroupInformation) String() string { return proto.CompactTextString(m) }
 func (*UserGroupInformation) ProtoMessage()    {}
 func (*UserGroupInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{14}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{19}
 }
 
 func (m *UserGroupInformation) XXX_Unmarshal(b []byte) error {
@@ -1136,7 +1517,7 @@ func (m *PlacementConstraint) Reset()         { *m = PlacementConstraint{} }
 func (m *PlacementConstraint) String() string { return proto.CompactTextString(m) }
 func (*PlacementConstraint) ProtoMessage()    {}
 func (*PlacementConstraint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{15}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{20}
 }
 
 func (m *PlacementConstraint) XXX_Unmarshal(b []byte) error {
@@ -1205,7 +1586,7 @@ func (m *SimplePlacementConstraint) Reset()         { *m = SimplePlacementConstr
 func (m *SimplePlacementConstraint) String() string { return proto.CompactTextString(m) }
 func (*SimplePlacementConstraint) ProtoMessage()    {}
 func (*SimplePlacementConstraint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{16}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{21}
 }
 
 func (m *SimplePlacementConstraint) XXX_Unmarshal(b []byte) error {
@@ -1253,7 +1634,7 @@ func (m *NodeAffinityConstraints) Reset()         { *m = NodeAffinityConstraints
 func (m *NodeAffinityConstraints) String() string { return proto.CompactTextString(m) }
 func (*NodeAffinityConstraints) ProtoMessage()    {}
 func (*NodeAffinityConstraints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{17}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{22}
 }
 
 func (m *NodeAffinityConstraints) XXX_Unmarshal(b []byte) error {
@@ -1308,7 +1689,7 @@ func (m *AllocationAffinityConstraints) Reset()         { *m = AllocationAffinit
 func (m *AllocationAffinityConstraints) String() string { return proto.CompactTextString(m) }
 func (*AllocationAffinityConstraints) ProtoMessage()    {}
 func (*AllocationAffinityConstraints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{18}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{23}
 }
 
 func (m *AllocationAffinityConstraints) XXX_Unmarshal(b []byte) error {
@@ -1377,7 +1758,7 @@ func (m *AffinityTargetExpression) Reset()         { *m = AffinityTargetExpressi
 func (m *AffinityTargetExpression) String() string { return proto.CompactTextString(m) }
 func (*AffinityTargetExpression) ProtoMessage()    {}
 func (*AffinityTargetExpression) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{19}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{24}
 }
 
 func (m *AffinityTargetExpression) XXX_Unmarshal(b []byte) error {
@@ -1433,7 +1814,7 @@ func (m *AllocationReleasesRequest) Reset()         { *m = AllocationReleasesReq
 func (m *AllocationReleasesRequest) String() string { return proto.CompactTextString(m) }
 func (*AllocationReleasesRequest) ProtoMessage()    {}
 func (*AllocationReleasesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{20}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{25}
 }
 
 func (m *AllocationReleasesRequest) XXX_Unmarshal(b []byte) error {
@@ -1488,7 +1869,7 @@ func (m *AllocationReleaseRequest) Reset()         { *m = AllocationReleaseReque
 func (m *AllocationReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*AllocationReleaseRequest) ProtoMessage()    {}
 func (*AllocationReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{21}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{26}
 }
 
 func (m *AllocationReleaseRequest) XXX_Unmarshal(b []byte) error {
@@ -1557,7 +1938,7 @@ func (m *AllocationAskReleaseRequest) Reset()         { *m = AllocationAskReleas
 func (m *AllocationAskReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*AllocationAskReleaseRequest) ProtoMessage()    {}
 func (*AllocationAskReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{22}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{27}
 }
 
 func (m *AllocationAskReleaseRequest) XXX_Unmarshal(b []byte) error {
@@ -1624,7 +2005,7 @@ func (m *NewNodeInfo) Reset()         { *m = NewNodeInfo{} }
 func (m *NewNodeInfo) String() string { return proto.CompactTextString(m) }
 func (*NewNodeInfo) ProtoMessage()    {}
 func (*NewNodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{23}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{28}
 }
 
 func (m *NewNodeInfo) XXX_Unmarshal(b []byte) error {
@@ -1692,7 +2073,7 @@ func (m *UpdateNodeInfo) Reset()         { *m = UpdateNodeInfo{} }
 func (m *UpdateNodeInfo) String() string { return proto.CompactTextString(m) }
 func (*UpdateNodeInfo) ProtoMessage()    {}
 func (*UpdateNodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{24}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{29}
 }
 
 func (m *UpdateNodeInfo) XXX_Unmarshal(b []byte) error {
@@ -1755,7 +2136,7 @@ func (m *UtilizationReport) Reset()         { *m = UtilizationReport{} }
 func (m *UtilizationReport) String() string { return proto.CompactTextString(m) }
 func (*UtilizationReport) ProtoMessage()    {}
 func (*UtilizationReport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{25}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{30}
 }
 
 func (m *UtilizationReport) XXX_Unmarshal(b []byte) error {
@@ -1818,7 +2199,7 @@ func (m *Allocation) Reset()         { *m = Allocation{} }
 func (m *Allocation) String() string { return proto.CompactTextString(m) }
 func (*Allocation) ProtoMessage()    {}
 func (*Allocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{26}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{31}
 }
 
 func (m *Allocation) XXX_Unmarshal(b []byte) error {
@@ -1917,7 +2298,7 @@ func (m *RejectedAllocationAsk) Reset()         { *m = RejectedAllocationAsk{} }
 func (m *RejectedAllocationAsk) String() string { return proto.CompactTextString(m) }
 func (*RejectedAllocationAsk) ProtoMessage()    {}
 func (*RejectedAllocationAsk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{27}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{32}
 }
 
 func (m *RejectedAllocationAsk) XXX_Unmarshal(b []byte) error {
@@ -1972,7 +2353,7 @@ func (m *NodeRecommendation) Reset()         { *m = NodeRecommendation{} }
 func (m *NodeRecommendation) String() string { return proto.CompactTextString(m) }
 func (*NodeRecommendation) ProtoMessage()    {}
 func (*NodeRecommendation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{28}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{33}
 }
 
 func (m *NodeRecommendation) XXX_Unmarshal(b []byte) error {
@@ -2014,7 +2395,11 @@ type AllocationReleaseResponse struct {
 	// Termination type of the released allocation
 	TerminationType AllocationReleaseResponse_TerminationType `protobuf:"varint,2,opt,name=terminationType,proto3,enum=si.v1.AllocationReleaseResponse_TerminationType" json:"terminationType,omitempty"`
 	// Any other human-readable message
-	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Time the RM should give the allocation to terminate gracefully, in seconds.
+	// Only set for allocations preempted by the scheduler: the scheduler keeps the allocation until the RM
+	// confirms the termination by releasing the allocation via the AllocationReleasesRequest.
+	GracePeriodSeconds   int64    `protobuf:"varint,4,opt,name=gracePeriodSeconds,proto3" json:"gracePeriodSeconds,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2024,7 +2409,7 @@ func (m *AllocationReleaseResponse) Reset()         { *m = AllocationReleaseResp
 func (m *AllocationReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*AllocationReleaseResponse) ProtoMessage()    {}
 func (*AllocationReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{29}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{34}
 }
 
 func (m *AllocationReleaseResponse) XXX_Unmarshal(b []byte) error {
@@ -2066,6 +2451,13 @@ func (m *AllocationReleaseResponse) GetMessage() string {
 	return ""
 }
 
+func (m *AllocationReleaseResponse) GetGracePeriodSeconds() int64 {
+	if m != nil {
+		return m.GracePeriodSeconds
+	}
+	return 0
+}
+
 type PredicatesArgs struct {
 	// allocation key identifies a container, the predicates function is going to check
 	// if this container is eligible to be placed ont to a node.
@@ -2081,7 +2473,7 @@ func (m *PredicatesArgs) Reset()         { *m = PredicatesArgs{} }
 func (m *PredicatesArgs) String() string { return proto.CompactTextString(m) }
 func (*PredicatesArgs) ProtoMessage()    {}
 func (*PredicatesArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{30}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{35}
 }
 
 func (m *PredicatesArgs) XXX_Unmarshal(b []byte) error {
@@ -2128,7 +2520,7 @@ func (m *ReSyncSchedulerCacheArgs) Reset()         { *m = ReSyncSchedulerCacheAr
 func (m *ReSyncSchedulerCacheArgs) String() string { return proto.CompactTextString(m) }
 func (*ReSyncSchedulerCacheArgs) ProtoMessage()    {}
 func (*ReSyncSchedulerCacheArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{31}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{36}
 }
 
 func (m *ReSyncSchedulerCacheArgs) XXX_Unmarshal(b []byte) error {
@@ -2170,7 +2562,7 @@ func (m *AssumedAllocation) Reset()         { *m = AssumedAllocation{} }
 func (m *AssumedAllocation) String() string { return proto.CompactTextString(m) }
 func (*AssumedAllocation) ProtoMessage()    {}
 func (*AssumedAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fc4a0b9b2d5549ed, []int{32}
+	return fileDescriptor_fc4a0b9b2d5549ed, []int{37}
 }
 
 func (m *AssumedAllocation) XXX_Unmarshal(b []byte) error {
@@ -2216,6 +2608,7 @@ var E_SiSecret = &proto.ExtensionDesc{
 
 func init() {
 	proto.RegisterEnum("si.v1.UpdateResponse_ActionFromScheduler", UpdateResponse_ActionFromScheduler_name, UpdateResponse_ActionFromScheduler_value)
+	proto.RegisterEnum("si.v1.EventRecord_Type", EventRecord_Type_name, EventRecord_Type_value)
 	proto.RegisterEnum("si.v1.AffinityTargetExpression_AffinityTargetOperator", AffinityTargetExpression_AffinityTargetOperator_name, AffinityTargetExpression_AffinityTargetOperator_value)
 	proto.RegisterEnum("si.v1.UpdateNodeInfo_ActionFromRM", UpdateNodeInfo_ActionFromRM_name, UpdateNodeInfo_ActionFromRM_value)
 	proto.RegisterEnum("si.v1.AllocationReleaseResponse_TerminationType", AllocationReleaseResponse_TerminationType_name, AllocationReleaseResponse_TerminationType_value)
@@ -2223,8 +2616,12 @@ func init() {
 	proto.RegisterType((*RegisterResourceManagerResponse)(nil), "si.v1.RegisterResourceManagerResponse")
 	proto.RegisterType((*UpdateRequest)(nil), "si.v1.UpdateRequest")
 	proto.RegisterType((*UpdateResponse)(nil), "si.v1.UpdateResponse")
+	proto.RegisterType((*EventRecord)(nil), "si.v1.EventRecord")
 	proto.RegisterType((*RejectedApplication)(nil), "si.v1.RejectedApplication")
 	proto.RegisterType((*AcceptedApplication)(nil), "si.v1.AcceptedApplication")
+	proto.RegisterType((*MovedApplication)(nil), "si.v1.MovedApplication")
+	proto.RegisterType((*RejectedApplicationMove)(nil), "si.v1.RejectedApplicationMove")
+	proto.RegisterType((*RemovedApplication)(nil), "si.v1.RemovedApplication")
 	proto.RegisterType((*RejectedNode)(nil), "si.v1.RejectedNode")
 	proto.RegisterType((*AcceptedNode)(nil), "si.v1.AcceptedNode")
 	proto.RegisterType((*Priority)(nil), "si.v1.Priority")
@@ -2236,6 +2633,7 @@ func init() {
 	proto.RegisterType((*AddApplicationRequest)(nil), "si.v1.AddApplicationRequest")
 	proto.RegisterMapType((map[string]string)(nil), "si.v1.AddApplicationRequest.TagsEntry")
 	proto.RegisterType((*RemoveApplicationRequest)(nil), "si.v1.RemoveApplicationRequest")
+	proto.RegisterType((*MoveApplicationRequest)(nil), "si.v1.MoveApplicationRequest")
 	proto.RegisterType((*UserGroupInformation)(nil), "si.v1.UserGroupInformation")
 	proto.RegisterType((*PlacementConstraint)(nil), "si.v1.PlacementConstraint")
 	proto.RegisterType((*SimplePlacementConstraint)(nil), "si.v1.SimplePlacementConstraint")
@@ -2266,135 +2664,153 @@ func init() {
 }
 
 var fileDescriptor_fc4a0b9b2d5549ed = []byte{
-	// 2041 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcd, 0x72, 0xdb, 0xc8,
-	0x11, 0x16, 0x48, 0x4a, 0x22, 0x5b, 0x12, 0x45, 0x0d, 0x65, 0x2f, 0x2d, 0x7b, 0x65, 0x05, 0x65,
-	0xab, 0x9c, 0x4a, 0x99, 0xde, 0x55, 0x0e, 0x89, 0xed, 0x4d, 0x52, 0x14, 0x45, 0xaf, 0x58, 0x6b,
-	0x92, 0xda, 0x21, 0xbd, 0xc9, 0x6e, 0xa5, 0x4a, 0x05, 0x03, 0x23, 0x1a, 0x2b, 0x12, 0x80, 0x67,
-	0x00, 0xdb, 0x4a, 0x1e, 0x20, 0x79, 0x80, 0x54, 0xe5, 0x01, 0x72, 0xca, 0x2d, 0x87, 0x3c, 0x42,
-	0x2a, 0xc7, 0xbc, 0x40, 0x9e, 0x20, 0x87, 0x54, 0x72, 0xc8, 0x21, 0xc7, 0xd4, 0x0c, 0x30, 0xf8,
-	0x1d, 0x4a, 0xf2, 0xee, 0xe6, 0x86, 0xe9, 0xf9, 0xba, 0xa7, 0xd1, 0xdd, 0xe8, 0xee, 0x69, 0xc0,
-	0xfd, 0x8b, 0xc0, 0xb1, 0xcf, 0x5d, 0xea, 0x3c, 0x64, 0xe6, 0x2b, 0x62, 0x05, 0x33, 0x42, 0x1f,
-	0xda, 0x8e, 0x4f, 0xe8, 0x99, 0x61, 0x92, 0x47, 0xcc, 0x6e, 0x7b, 0xd4, 0xf5, 0x5d, 0xb4, 0xcc,
-	0xec, 0xf6, 0x9b, 0x8f, 0x77, 0xf6, 0xa6, 0xae, 0x3b, 0x9d, 0x91, 0x47, 0x82, 0xf8, 0x32, 0x38,
-	0x7b, 0x64, 0x11, 0x66, 0x52, 0xdb, 0xf3, 0x5d, 0x1a, 0x02, 0x75, 0x0f, 0x76, 0x31, 0x99, 0xda,
-	0xcc, 0x27, 0x14, 0x13, 0xe6, 0x06, 0xd4, 0x24, 0x03, 0xc3, 0x31, 0xa6, 0x7c, 0xf9, 0x3a, 0x20,
-	0xcc, 0x47, 0x08, 0x2a, 0x74, 0xde, 0xb7, 0x5a, 0xda, 0x9e, 0xf6, 0xa0, 0x86, 0xc5, 0x33, 0x6a,
-	0xc1, 0xea, 0x1b, 0x42, 0x99, 0xed, 0x3a, 0xad, 0x92, 0x20, 0xcb, 0x25, 0xda, 0x83, 0x35, 0xcf,
-	0x9d, 0xd9, 0xe6, 0xc5, 0xa7, 0xd4, 0x0d, 0xbc, 0x56, 0x59, 0xec, 0xa6, 0x49, 0xfa, 0xf7, 0xe0,
-	0xee, 0xc2, 0x13, 0x99, 0xe7, 0x3a, 0x8c, 0xe8, 0xff, 0x2a, 0xc3, 0xc6, 0x0b, 0xcf, 0x32, 0x7c,
-	0x22, 0x95, 0x78, 0x00, 0x15, 0x83, 0x9d, 0xb3, 0x96, 0xb6, 0x57, 0x7e, 0xb0, 0x76, 0xb0, 0xdd,
-	0x16, 0xaf, 0xd7, 0xee, 0xcc, 0x66, 0xae, 0x69, 0xf8, 0xb6, 0xeb, 0x74, 0xd8, 0x39, 0x16, 0x08,
-	0xf4, 0x09, 0x54, 0x29, 0x99, 0x11, 0x83, 0x11, 0x26, 0x74, 0x5b, 0x3b, 0xd8, 0x2b, 0xa0, 0x71,
-	0x04, 0x88, 0xa4, 0xe3, 0x98, 0x03, 0x1d, 0x41, 0xd3, 0x21, 0x6f, 0xc7, 0xa1, 0x69, 0x8d, 0x97,
-	0x33, 0x32, 0x74, 0x2d, 0xc2, 0x5a, 0x65, 0x71, 0x2c, 0x8a, 0x04, 0x0d, 0xc9, 0x5b, 0x4e, 0xee,
-	0x3b, 0x67, 0x2e, 0x56, 0xc1, 0xd1, 0x63, 0x58, 0x0f, 0x84, 0xfa, 0x56, 0xc8, 0x5e, 0x11, 0xec,
-	0x37, 0x22, 0xf6, 0xf0, 0xcd, 0x62, 0x09, 0x19, 0x28, 0x3a, 0x06, 0x14, 0xf8, 0xf6, 0xcc, 0xfe,
-	0x55, 0xa4, 0xa8, 0xe7, 0x52, 0x9f, 0xb5, 0x96, 0x85, 0x80, 0x96, 0x14, 0x90, 0x07, 0x60, 0x05,
-	0x4f, 0xec, 0xb7, 0x95, 0x94, 0xdf, 0x9e, 0xc1, 0xa6, 0x43, 0xde, 0x76, 0x3c, 0x6f, 0x66, 0x87,
-	0x96, 0x60, 0xad, 0xaa, 0x10, 0x7d, 0x47, 0xda, 0xc8, 0xb2, 0x52, 0xbb, 0xd2, 0x3e, 0x79, 0x26,
-	0x34, 0x02, 0x44, 0xc9, 0xdc, 0x7d, 0x43, 0x32, 0xa2, 0x6a, 0x42, 0xd4, 0xdd, 0x48, 0x14, 0xce,
-	0x03, 0xa4, 0x34, 0x05, 0xab, 0xfe, 0xd7, 0x65, 0xa8, 0x4b, 0x8f, 0x87, 0x41, 0x80, 0x3a, 0xb0,
-	0x62, 0x98, 0x7c, 0x57, 0x44, 0x5e, 0xfd, 0xe0, 0xfb, 0x19, 0xf3, 0x49, 0x58, 0xbb, 0x23, 0x30,
-	0xcf, 0xa8, 0x3b, 0x1f, 0xcb, 0x0f, 0x02, 0x47, 0x8c, 0xe8, 0x31, 0xd4, 0xb9, 0xe6, 0xb1, 0xdf,
-	0x79, 0x44, 0x70, 0x15, 0xb7, 0x8a, 0x11, 0x91, 0x03, 0x22, 0x0c, 0xcd, 0x28, 0x28, 0xac, 0x34,
-	0x7f, 0x18, 0x08, 0x0b, 0x23, 0x4a, 0x6a, 0x85, 0x55, 0xcc, 0x68, 0xc8, 0x65, 0x7e, 0x4d, 0x4c,
-	0x3f, 0x2b, 0xb3, 0x92, 0xf1, 0x00, 0x2e, 0x20, 0x78, 0x6c, 0xab, 0x18, 0xd1, 0x67, 0xd0, 0x74,
-	0x5c, 0x8b, 0x60, 0x62, 0xba, 0xf3, 0x39, 0x71, 0xac, 0x48, 0x5e, 0x18, 0x2c, 0xb7, 0x64, 0xb0,
-	0x16, 0x10, 0x58, 0xc5, 0x85, 0x86, 0xb0, 0x1d, 0x9f, 0x91, 0x76, 0xea, 0x8a, 0x90, 0xb6, 0x93,
-	0xd7, 0x2e, 0xe5, 0x56, 0x25, 0x1f, 0x97, 0x67, 0x98, 0x26, 0xf1, 0xf2, 0xf2, 0x56, 0x33, 0xf2,
-	0x3a, 0x45, 0x08, 0x56, 0xf2, 0xa1, 0xc7, 0xb0, 0x21, 0xcf, 0x09, 0x3f, 0xaa, 0x30, 0x70, 0x9b,
-	0x39, 0xc5, 0xc4, 0xeb, 0x66, 0x91, 0x9c, 0x55, 0x8a, 0x0c, 0x59, 0x6b, 0x19, 0xd6, 0x4e, 0x6a,
-	0x0f, 0x67, 0x91, 0xfa, 0x23, 0x68, 0x2a, 0x02, 0x0c, 0xad, 0x43, 0x75, 0x38, 0xea, 0x74, 0x27,
-	0xfd, 0xd1, 0xb0, 0xb1, 0x84, 0x00, 0x56, 0x70, 0x6f, 0xfc, 0xe5, 0xb0, 0xdb, 0xd0, 0xf4, 0x31,
-	0x34, 0x15, 0x36, 0x42, 0xf7, 0x60, 0xc3, 0x48, 0x96, 0x71, 0x36, 0xcd, 0x12, 0xd1, 0x4d, 0x58,
-	0xa1, 0xc4, 0x60, 0x71, 0x56, 0x8d, 0x56, 0xfa, 0x53, 0xae, 0x45, 0xc1, 0x26, 0xd7, 0x13, 0xaa,
-	0xff, 0x14, 0xd6, 0xd3, 0xc6, 0xe1, 0x87, 0x70, 0xff, 0xc7, 0xf0, 0x68, 0xb5, 0xf0, 0xf0, 0x7d,
-	0x58, 0x4f, 0x5b, 0x68, 0x11, 0xbf, 0xee, 0x40, 0xf5, 0x84, 0xda, 0x2e, 0xb5, 0xfd, 0x0b, 0xb4,
-	0x0f, 0x1b, 0x5e, 0xf4, 0xfc, 0x85, 0x31, 0x0b, 0x88, 0x80, 0x2e, 0x1f, 0x2f, 0xe1, 0x2c, 0x19,
-	0xb5, 0x61, 0x4b, 0x12, 0xba, 0x33, 0x83, 0xb1, 0xa1, 0x31, 0x27, 0xe1, 0xf1, 0xc7, 0x4b, 0xb8,
-	0xb8, 0x75, 0x08, 0x50, 0x95, 0x44, 0xfd, 0xf7, 0x1a, 0x54, 0x65, 0x01, 0x41, 0x9f, 0x40, 0x8d,
-	0x46, 0xcf, 0xb2, 0x48, 0xec, 0xc6, 0x91, 0x11, 0xd2, 0xe3, 0x07, 0xd6, 0x73, 0x7c, 0x7a, 0x81,
-	0x13, 0x86, 0x9d, 0x01, 0xd4, 0xb3, 0x9b, 0xa8, 0x01, 0xe5, 0x73, 0x72, 0x11, 0xbd, 0x21, 0x7f,
-	0x44, 0xf7, 0x61, 0xf9, 0x8d, 0x78, 0x95, 0xb0, 0xa8, 0x6c, 0x46, 0xd2, 0x3f, 0x0f, 0x0c, 0xc7,
-	0xb7, 0xfd, 0x0b, 0x1c, 0xee, 0x3e, 0x29, 0xfd, 0x58, 0xd3, 0xf7, 0xa0, 0x2a, 0xc9, 0x68, 0x5b,
-	0xb2, 0x71, 0x51, 0xe5, 0x08, 0xa5, 0xff, 0xb6, 0x02, 0x1b, 0x99, 0x0f, 0x5c, 0xf8, 0x32, 0x26,
-	0x7c, 0x16, 0x1f, 0x9d, 0x25, 0x16, 0x3d, 0x5e, 0x52, 0x85, 0xd1, 0x3d, 0xd8, 0xf0, 0x0c, 0xea,
-	0xdb, 0x7c, 0x29, 0x2c, 0x1a, 0x56, 0xe1, 0x2c, 0x11, 0x7d, 0x0c, 0x6b, 0xd2, 0x02, 0x1d, 0x76,
-	0xde, 0xaa, 0x64, 0x5e, 0x4b, 0x9a, 0x03, 0xa7, 0x31, 0x68, 0x1f, 0xea, 0x73, 0xe3, 0x5d, 0x3a,
-	0x77, 0x2d, 0x73, 0xbf, 0xe2, 0x1c, 0x15, 0xfd, 0x20, 0x71, 0x93, 0x28, 0x3f, 0x89, 0x5c, 0x19,
-	0x21, 0x38, 0x06, 0xa0, 0x43, 0xb8, 0x43, 0xde, 0x11, 0x33, 0xe0, 0xac, 0x13, 0x7b, 0x4e, 0xdc,
-	0xc0, 0x1f, 0xd8, 0xb3, 0x99, 0x3d, 0x26, 0xa6, 0xeb, 0x58, 0x3c, 0x61, 0x70, 0xc3, 0x5d, 0x8a,
-	0x41, 0x07, 0x50, 0xf1, 0x8d, 0xa9, 0xcc, 0x09, 0xbb, 0xaa, 0xf6, 0xa0, 0x3d, 0x31, 0xa6, 0x91,
-	0xe7, 0x05, 0x16, 0x3d, 0x87, 0xa6, 0x37, 0x33, 0x4c, 0x32, 0x27, 0x8e, 0xdf, 0x75, 0x1d, 0xe6,
-	0x53, 0xc3, 0x76, 0xfc, 0x56, 0x4d, 0xe8, 0x2b, 0xf3, 0xd3, 0x49, 0x11, 0x81, 0x55, 0x6c, 0x3b,
-	0x3f, 0x82, 0x5a, 0x7c, 0x80, 0x22, 0x7a, 0xb6, 0xd3, 0xd1, 0x53, 0x4b, 0x07, 0xcb, 0x3f, 0x4a,
-	0x70, 0x43, 0x59, 0x75, 0xaf, 0x99, 0x33, 0xee, 0x40, 0xed, 0x75, 0x40, 0x02, 0x92, 0x7c, 0x3a,
-	0x38, 0x21, 0x5c, 0x33, 0x14, 0x1e, 0x42, 0x39, 0x98, 0xda, 0x51, 0x08, 0xdc, 0x96, 0x75, 0x96,
-	0x11, 0x2a, 0x3a, 0x36, 0xde, 0xa5, 0xd0, 0x79, 0xa8, 0x1a, 0xc7, 0xa1, 0x27, 0x91, 0xb5, 0xc3,
-	0x42, 0xb3, 0x7f, 0x59, 0xeb, 0x50, 0xb0, 0xfa, 0x55, 0xde, 0x5e, 0xb9, 0xda, 0xdb, 0xdf, 0xdc,
-	0xd6, 0x67, 0xd0, 0x5a, 0xd4, 0x95, 0x5c, 0xd3, 0xda, 0x05, 0x7b, 0x96, 0x14, 0xf6, 0xd4, 0x0f,
-	0x61, 0x5b, 0x65, 0x3d, 0xde, 0x92, 0x05, 0x8c, 0x50, 0xd9, 0x4a, 0xf3, 0x67, 0x9e, 0x4e, 0xa7,
-	0x1c, 0x17, 0xf6, 0x26, 0x35, 0x1c, 0xad, 0x74, 0x06, 0x4d, 0x45, 0xf0, 0xa1, 0x21, 0x34, 0x98,
-	0x3d, 0xf7, 0x66, 0x24, 0x15, 0xb2, 0x5a, 0xa6, 0xcd, 0x1d, 0x8b, 0x6d, 0x05, 0xef, 0xf1, 0x12,
-	0x2e, 0xf0, 0x1e, 0xae, 0x03, 0x98, 0xf1, 0x4a, 0xff, 0xbb, 0x06, 0xb7, 0x16, 0xf2, 0xa3, 0x2f,
-	0xe0, 0x26, 0xcf, 0xf5, 0x9d, 0xb3, 0x33, 0xdb, 0xe1, 0x69, 0x39, 0xaf, 0xc1, 0x6e, 0xaa, 0xe5,
-	0x28, 0x82, 0x18, 0x5e, 0xc0, 0x8d, 0xce, 0xe0, 0x76, 0x92, 0xe6, 0xe4, 0x7e, 0xc7, 0xf7, 0xa9,
-	0xfd, 0x32, 0xf0, 0x65, 0xc2, 0xbd, 0x57, 0xfc, 0xa8, 0x15, 0x47, 0x5c, 0x26, 0x48, 0x7f, 0x05,
-	0x1f, 0x2c, 0x50, 0x0d, 0x0d, 0x60, 0xcb, 0x37, 0xe8, 0x94, 0xf8, 0xbd, 0x77, 0x1e, 0x25, 0x8c,
-	0xa5, 0x9a, 0x45, 0xd9, 0xcf, 0x4a, 0xb6, 0x49, 0x0e, 0x87, 0x8b, 0x9c, 0xfa, 0xbf, 0x35, 0xf8,
-	0xf0, 0x52, 0x45, 0x79, 0x90, 0x32, 0xd3, 0xf5, 0x48, 0x14, 0x0b, 0xe1, 0x42, 0xa8, 0x41, 0x8d,
-	0x6f, 0xaa, 0x46, 0x9e, 0x53, 0xe4, 0x6b, 0xdb, 0xe9, 0x1a, 0xd4, 0xb2, 0x1d, 0x63, 0xc6, 0xb3,
-	0x71, 0x39, 0xca, 0xd7, 0x19, 0x6a, 0x94, 0xd7, 0xd3, 0xb8, 0x4a, 0x9c, 0xd7, 0xd3, 0xb8, 0x1d,
-	0x7e, 0xb7, 0x7a, 0x1d, 0xd8, 0x94, 0x58, 0x22, 0xf3, 0x57, 0x71, 0xbc, 0xd6, 0xff, 0xa9, 0x41,
-	0x6b, 0x91, 0x6e, 0xe8, 0x53, 0xa8, 0x87, 0x46, 0x1a, 0x79, 0x84, 0x1a, 0xbe, 0x4b, 0xa3, 0x88,
-	0xb9, 0xf2, 0xa5, 0x72, 0x6c, 0x3c, 0xdb, 0x85, 0x14, 0x5e, 0x22, 0xa3, 0x6c, 0x17, 0x13, 0x90,
-	0x0e, 0xeb, 0xe1, 0x42, 0x74, 0x17, 0x61, 0xb7, 0x5e, 0xc3, 0x19, 0x9a, 0xfe, 0x0c, 0x6e, 0x66,
-	0x4f, 0x8b, 0x65, 0xaf, 0x40, 0xa9, 0x1f, 0xb5, 0x73, 0xc3, 0xd1, 0xe4, 0xb4, 0x3f, 0x6c, 0x68,
-	0xa8, 0x06, 0xcb, 0xbd, 0x5f, 0xf4, 0xc7, 0x93, 0x46, 0x09, 0x6d, 0x40, 0x8d, 0x93, 0xc3, 0x65,
-	0x59, 0xff, 0x9b, 0x06, 0xb7, 0x16, 0xde, 0x28, 0xd1, 0x18, 0xb6, 0x93, 0x48, 0x64, 0x13, 0x37,
-	0xda, 0x8f, 0x5a, 0x93, 0xbb, 0x8b, 0xef, 0x0f, 0xe1, 0x15, 0x49, 0xc9, 0x8c, 0x7e, 0x09, 0x1f,
-	0x18, 0xe9, 0x92, 0x96, 0x92, 0x1b, 0xc6, 0x88, 0xae, 0xbc, 0x17, 0x67, 0x45, 0x2f, 0x12, 0xa1,
-	0xff, 0x8e, 0x3b, 0x70, 0x81, 0x42, 0xc5, 0xbc, 0xa7, 0xa9, 0xea, 0xc8, 0xf5, 0xda, 0x13, 0x9e,
-	0x05, 0x03, 0xdb, 0x8a, 0x4a, 0x91, 0x78, 0x46, 0x2d, 0x58, 0x9d, 0x13, 0xc6, 0x8c, 0x29, 0x11,
-	0xa1, 0x57, 0xc3, 0x72, 0xa9, 0xff, 0x51, 0x83, 0xdb, 0x97, 0xbc, 0xcf, 0x77, 0xaa, 0x59, 0xa6,
-	0x09, 0xe3, 0x55, 0xa5, 0x9c, 0x6f, 0xc2, 0x78, 0x7d, 0x59, 0xac, 0xeb, 0x9f, 0x4a, 0xb0, 0x96,
-	0x1a, 0x0e, 0x2c, 0x6c, 0xb5, 0x0f, 0x01, 0x0c, 0x99, 0x95, 0x58, 0xce, 0x77, 0x29, 0xfe, 0x76,
-	0x9c, 0xba, 0xa2, 0x12, 0x9a, 0xe2, 0x42, 0x1d, 0x68, 0xb2, 0x64, 0xee, 0x20, 0xfb, 0x35, 0xa1,
-	0xb1, 0xa2, 0x8d, 0x53, 0x61, 0x51, 0x17, 0x9a, 0xe4, 0x9d, 0xcd, 0x7c, 0xdb, 0x99, 0x16, 0xef,
-	0xa3, 0x8a, 0x3b, 0xb2, 0x0a, 0xbd, 0xf3, 0x13, 0xd8, 0xcc, 0xa9, 0xf9, 0x5e, 0x25, 0xf9, 0xbf,
-	0x25, 0x79, 0xf1, 0xbf, 0xd2, 0x6a, 0x3d, 0x85, 0xd5, 0xee, 0x2b, 0x67, 0x2a, 0xff, 0x6f, 0xc3,
-	0x3d, 0x89, 0x47, 0x13, 0x15, 0x31, 0x9a, 0xd0, 0x17, 0x68, 0x11, 0xdf, 0x1c, 0xf1, 0x40, 0xce,
-	0x24, 0xbe, 0xad, 0xbd, 0xfa, 0xfc, 0x36, 0x96, 0x88, 0x45, 0x75, 0x80, 0x23, 0xdc, 0xe9, 0x0f,
-	0x4f, 0x87, 0xa3, 0xa3, 0x5e, 0x63, 0x09, 0x6d, 0xc2, 0xda, 0x51, 0xaf, 0x3b, 0x1a, 0xf4, 0xc7,
-	0x63, 0x7e, 0x39, 0xd5, 0x50, 0x0b, 0xb6, 0x43, 0xc0, 0x64, 0x74, 0x3a, 0xee, 0x1e, 0xf7, 0x8e,
-	0x5e, 0x3c, 0xef, 0x1c, 0x3e, 0xef, 0x35, 0x4a, 0xba, 0x05, 0x5b, 0x85, 0x49, 0x12, 0xaa, 0x43,
-	0xc9, 0x96, 0x86, 0x2f, 0xd9, 0x16, 0xfa, 0x19, 0x20, 0xc3, 0xf4, 0x03, 0x63, 0xf6, 0x82, 0x11,
-	0x2b, 0x36, 0x56, 0x49, 0x6d, 0x2c, 0x05, 0x54, 0xff, 0x4b, 0x19, 0x20, 0x89, 0x97, 0x6b, 0xde,
-	0x73, 0x06, 0x50, 0x4f, 0x08, 0xbc, 0xd7, 0xcb, 0xb9, 0x3b, 0x11, 0x98, 0x7a, 0x4c, 0x5a, 0xcd,
-	0x1c, 0xb3, 0x32, 0xe3, 0x3c, 0x85, 0x86, 0xbc, 0xda, 0x9c, 0x10, 0x2a, 0xa4, 0x88, 0x9a, 0xa6,
-	0x78, 0xad, 0x02, 0xf0, 0xfd, 0x2e, 0x38, 0x99, 0x0e, 0x7d, 0x35, 0xdf, 0xa1, 0x27, 0xd1, 0x5e,
-	0xcd, 0x44, 0x7b, 0x21, 0x63, 0xd5, 0xae, 0xd5, 0x8f, 0x82, 0x22, 0xfb, 0xed, 0x74, 0xa0, 0xa9,
-	0x30, 0xd3, 0x7b, 0xc5, 0xdd, 0xaf, 0xe1, 0x86, 0x72, 0x32, 0xf5, 0x9d, 0x5e, 0x5c, 0x93, 0x11,
-	0x44, 0x39, 0x33, 0x82, 0xf8, 0x8d, 0x06, 0xa8, 0x38, 0xc7, 0x42, 0x3f, 0x87, 0x5d, 0x2a, 0x29,
-	0xc4, 0x1a, 0x2b, 0x3e, 0x6a, 0x4d, 0xed, 0xd0, 0x2b, 0xd8, 0xd2, 0x19, 0xbe, 0x94, 0xcd, 0xf0,
-	0xff, 0x51, 0x55, 0xfd, 0x78, 0x64, 0x29, 0xe3, 0x4c, 0x4b, 0xc5, 0xd9, 0x57, 0xb0, 0xe9, 0x13,
-	0x3a, 0xb7, 0x9d, 0xd0, 0xf8, 0x17, 0x5e, 0x28, 0xb3, 0x7e, 0xf0, 0xd1, 0x55, 0x43, 0xc4, 0xf6,
-	0x24, 0xcb, 0x87, 0xf3, 0x82, 0xd2, 0x7a, 0x96, 0xb3, 0x7a, 0x0e, 0x60, 0x33, 0xc7, 0x8d, 0xb6,
-	0x60, 0x63, 0x3c, 0x19, 0x9d, 0x9c, 0xf4, 0x8e, 0x4e, 0x0f, 0xbf, 0x3c, 0xc5, 0x83, 0xc6, 0x12,
-	0x5a, 0x83, 0xd5, 0x49, 0x7f, 0xd0, 0x1b, 0xbd, 0x98, 0x34, 0x34, 0xb4, 0x03, 0x37, 0x4f, 0x70,
-	0xaf, 0x37, 0x38, 0x99, 0x84, 0x88, 0x28, 0x59, 0xf4, 0x70, 0xa3, 0xa4, 0x0f, 0xa1, 0x7e, 0x42,
-	0x89, 0xc5, 0x3d, 0x45, 0x58, 0x87, 0x4e, 0xd9, 0x35, 0xdd, 0x9e, 0x04, 0x77, 0x29, 0x33, 0x2b,
-	0xb2, 0xf8, 0x45, 0x6c, 0x7c, 0xe1, 0x98, 0xf1, 0x48, 0xad, 0x6b, 0x98, 0xaf, 0x88, 0x90, 0x7c,
-	0x0c, 0xc8, 0x60, 0x2c, 0x98, 0x67, 0x87, 0xa4, 0x5a, 0x66, 0x02, 0xde, 0xc9, 0x03, 0xb0, 0x82,
-	0x47, 0xff, 0x1c, 0xb6, 0x0a, 0xc0, 0x6f, 0xa7, 0xf8, 0xc1, 0x9f, 0x35, 0xa8, 0x25, 0x63, 0xc0,
-	0xaf, 0xe1, 0x83, 0x05, 0xbf, 0x32, 0xd0, 0xfd, 0x38, 0xe6, 0x2e, 0xfb, 0xb9, 0xb2, 0xb3, 0x7f,
-	0x15, 0x2c, 0xfa, 0x23, 0xb2, 0x84, 0x9e, 0xc2, 0x4a, 0x58, 0x5e, 0xd0, 0x76, 0x6e, 0x10, 0x1e,
-	0x4a, 0xba, 0xa1, 0x1c, 0x8f, 0xeb, 0x4b, 0x0f, 0xb4, 0x8f, 0xb4, 0x27, 0x4f, 0xa1, 0xc6, 0xec,
-	0x53, 0x46, 0x4c, 0x4a, 0x7c, 0xf4, 0x61, 0x3b, 0xfc, 0x2b, 0xd4, 0x96, 0x7f, 0x85, 0xda, 0xcf,
-	0x6c, 0x32, 0xb3, 0x46, 0x5e, 0x68, 0xe9, 0x3f, 0x54, 0xc3, 0xce, 0x9e, 0xf1, 0xfb, 0x36, 0x25,
-	0xfe, 0x61, 0xe5, 0xab, 0x12, 0xb3, 0x5f, 0xae, 0x08, 0xf4, 0x0f, 0xff, 0x17, 0x00, 0x00, 0xff,
-	0xff, 0x7d, 0xa2, 0xab, 0x49, 0x81, 0x1a, 0x00, 0x00,
+	// 2327 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x4f, 0x73, 0x1b, 0x49,
+	0x15, 0xf7, 0x48, 0xb2, 0x2c, 0x3d, 0xdb, 0xb2, 0xdc, 0x72, 0xe2, 0x89, 0x77, 0x93, 0x98, 0xa9,
+	0x24, 0x15, 0xd8, 0x8a, 0xb2, 0x6b, 0x0e, 0x90, 0x64, 0x81, 0x1a, 0xdb, 0xca, 0x5a, 0xb5, 0x91,
+	0xe4, 0xb4, 0xe4, 0x2c, 0x9b, 0xa2, 0xca, 0x35, 0xd1, 0xb4, 0x95, 0xd9, 0x48, 0x33, 0x93, 0xee,
+	0x19, 0x27, 0x86, 0x1b, 0x17, 0xf8, 0x00, 0x54, 0xf1, 0x01, 0xe0, 0xc2, 0x81, 0x2a, 0x0e, 0x9c,
+	0x38, 0x73, 0xe6, 0x0b, 0x70, 0xe3, 0xc6, 0x81, 0x2a, 0x8e, 0x1c, 0xa9, 0xee, 0xf9, 0xa3, 0xf9,
+	0xd3, 0x63, 0x3b, 0x9b, 0xec, 0x6d, 0xfa, 0xf5, 0xfb, 0xbd, 0x7e, 0xfd, 0xfa, 0xf5, 0xeb, 0xf7,
+	0xde, 0xc0, 0xed, 0x33, 0xdf, 0xb6, 0x5e, 0x39, 0xd4, 0xbe, 0xc7, 0xc6, 0x2f, 0x89, 0xe9, 0x4f,
+	0x09, 0xbd, 0x67, 0xd9, 0x1e, 0xa1, 0x27, 0xc6, 0x98, 0xdc, 0x67, 0x56, 0xdb, 0xa5, 0x8e, 0xe7,
+	0xa0, 0x45, 0x66, 0xb5, 0x4f, 0x3f, 0xdb, 0xda, 0x9e, 0x38, 0xce, 0x64, 0x4a, 0xee, 0x0b, 0xe2,
+	0x0b, 0xff, 0xe4, 0xbe, 0x49, 0xd8, 0x98, 0x5a, 0xae, 0xe7, 0xd0, 0x80, 0x51, 0x73, 0xe1, 0x06,
+	0x26, 0x13, 0x8b, 0x79, 0x84, 0x62, 0xc2, 0x1c, 0x9f, 0x8e, 0x49, 0xcf, 0xb0, 0x8d, 0x09, 0x1f,
+	0xbe, 0xf6, 0x09, 0xf3, 0x10, 0x82, 0x0a, 0x9d, 0x75, 0x4d, 0x55, 0xd9, 0x56, 0xee, 0xd6, 0xb1,
+	0xf8, 0x46, 0x2a, 0x2c, 0x9d, 0x12, 0xca, 0x2c, 0xc7, 0x56, 0x4b, 0x82, 0x1c, 0x0d, 0xd1, 0x36,
+	0x2c, 0xbb, 0xce, 0xd4, 0x1a, 0x9f, 0x7d, 0x41, 0x1d, 0xdf, 0x55, 0xcb, 0x62, 0x36, 0x49, 0xd2,
+	0xbe, 0x07, 0x37, 0x0b, 0x57, 0x64, 0xae, 0x63, 0x33, 0xa2, 0xfd, 0xad, 0x02, 0xab, 0x47, 0xae,
+	0x69, 0x78, 0x24, 0x52, 0xe2, 0x2e, 0x54, 0x0c, 0xf6, 0x8a, 0xa9, 0xca, 0x76, 0xf9, 0xee, 0xf2,
+	0xce, 0x46, 0x5b, 0x6c, 0xaf, 0xad, 0x4f, 0xa7, 0xce, 0xd8, 0xf0, 0x2c, 0xc7, 0xd6, 0xd9, 0x2b,
+	0x2c, 0x38, 0xd0, 0xe7, 0x50, 0xa3, 0x64, 0x4a, 0x0c, 0x46, 0x98, 0xd0, 0x6d, 0x79, 0x67, 0x3b,
+	0xc7, 0x8d, 0x43, 0x86, 0x50, 0x3a, 0x8e, 0x11, 0x68, 0x1f, 0x5a, 0x36, 0x79, 0x33, 0x0c, 0x4c,
+	0x6b, 0xbc, 0x98, 0x92, 0xbe, 0x63, 0x12, 0xa6, 0x96, 0xc5, 0xb2, 0x28, 0x14, 0xd4, 0x27, 0x6f,
+	0x38, 0xb9, 0x6b, 0x9f, 0x38, 0x58, 0xc6, 0x8e, 0x1e, 0xc0, 0x8a, 0x2f, 0xd4, 0x37, 0x03, 0x78,
+	0x45, 0xc0, 0xaf, 0x84, 0xf0, 0x60, 0x67, 0xb1, 0x84, 0x14, 0x2b, 0x3a, 0x00, 0xe4, 0x7b, 0xd6,
+	0xd4, 0xfa, 0x65, 0xa8, 0xa8, 0xeb, 0x50, 0x8f, 0xa9, 0x8b, 0x42, 0x80, 0x1a, 0x09, 0xc8, 0x32,
+	0x60, 0x09, 0x26, 0x3e, 0xb7, 0x6a, 0xe2, 0xdc, 0x1e, 0xc3, 0x9a, 0x4d, 0xde, 0xe8, 0xae, 0x3b,
+	0xb5, 0x02, 0x4b, 0x30, 0xb5, 0x26, 0x44, 0x7f, 0x1c, 0xd9, 0xc8, 0x34, 0x13, 0xb3, 0x91, 0x7d,
+	0xb2, 0x20, 0x34, 0x00, 0x44, 0xc9, 0xcc, 0x39, 0x25, 0x29, 0x51, 0x75, 0x21, 0xea, 0x66, 0x28,
+	0x0a, 0x67, 0x19, 0x22, 0x69, 0x12, 0x28, 0xea, 0x42, 0x33, 0x27, 0x0e, 0x84, 0xb8, 0xeb, 0xa1,
+	0xb8, 0x9e, 0x5c, 0x58, 0x0e, 0xa6, 0xfd, 0x6b, 0x09, 0x1a, 0x91, 0xf3, 0x04, 0xfe, 0x84, 0x74,
+	0xa8, 0x1a, 0x63, 0x3e, 0x2b, 0x9c, 0xb8, 0xb1, 0xf3, 0xfd, 0xd4, 0x49, 0x44, 0x6c, 0x6d, 0x5d,
+	0xf0, 0x3c, 0xa6, 0xce, 0x6c, 0x18, 0xdd, 0x2d, 0x1c, 0x02, 0xd1, 0x03, 0x68, 0x70, 0x23, 0xc4,
+	0x2e, 0xc4, 0x9d, 0x8b, 0xab, 0xb7, 0x9e, 0x77, 0xae, 0x0c, 0x23, 0xc2, 0xd0, 0x0a, 0xfd, 0xcb,
+	0x4c, 0xe2, 0x03, 0x9f, 0x2a, 0x74, 0xce, 0x48, 0x2b, 0x2c, 0x03, 0xa3, 0x3e, 0x97, 0xf9, 0x0d,
+	0x19, 0x7b, 0x69, 0x99, 0x95, 0xd4, 0x61, 0xe2, 0x1c, 0x07, 0xbf, 0x26, 0x32, 0x20, 0xfa, 0x12,
+	0x5a, 0xb6, 0x63, 0x12, 0x4c, 0xc6, 0xce, 0x6c, 0x46, 0x6c, 0x33, 0x94, 0x17, 0xf8, 0xdd, 0xb5,
+	0xc8, 0xef, 0x73, 0x1c, 0x58, 0x86, 0x42, 0x7d, 0xd8, 0x88, 0xd7, 0x48, 0x1e, 0x68, 0x55, 0x48,
+	0xdb, 0xca, 0x6a, 0x97, 0x38, 0x54, 0x29, 0x8e, 0xcb, 0x33, 0xc6, 0x63, 0xe2, 0x66, 0xe5, 0x2d,
+	0xa5, 0xe4, 0xe9, 0x79, 0x16, 0x2c, 0xc5, 0xa1, 0x07, 0xb0, 0x1a, 0xad, 0x13, 0xdc, 0xcf, 0xe0,
+	0x0e, 0xb4, 0x32, 0x8a, 0x89, 0xed, 0xa6, 0x39, 0x39, 0x34, 0x12, 0x19, 0x40, 0xeb, 0x29, 0xa8,
+	0x9e, 0x98, 0xc3, 0x69, 0x4e, 0xd4, 0x81, 0x75, 0xee, 0xab, 0xe9, 0x2d, 0x2c, 0x0b, 0xf8, 0x66,
+	0xc2, 0xc7, 0x53, 0xfa, 0xe7, 0x11, 0xe8, 0x39, 0xa8, 0x12, 0x23, 0x71, 0x24, 0x53, 0x57, 0x84,
+	0xb4, 0x1b, 0xc5, 0x06, 0xe6, 0x6c, 0xb8, 0x10, 0xcf, 0xbd, 0x20, 0xb8, 0x9b, 0x69, 0x25, 0x57,
+	0x53, 0x5e, 0x80, 0x73, 0x1c, 0x58, 0x86, 0x42, 0x3f, 0x80, 0x2a, 0x39, 0x25, 0xb6, 0x17, 0x5d,
+	0xe4, 0x28, 0x7a, 0x76, 0x38, 0x91, 0xbb, 0x0c, 0x35, 0x71, 0xc8, 0xa1, 0xdd, 0x87, 0x96, 0xe4,
+	0xf2, 0xa1, 0x15, 0xa8, 0xf5, 0x07, 0xfa, 0xde, 0xa8, 0x3b, 0xe8, 0x37, 0x17, 0x10, 0x40, 0x15,
+	0x77, 0x86, 0x5f, 0xf7, 0xf7, 0x9a, 0x8a, 0xf6, 0xe7, 0x12, 0x2c, 0x27, 0x04, 0xa1, 0x4f, 0xa0,
+	0xe2, 0x9d, 0xb9, 0x24, 0xbc, 0xdf, 0x9b, 0xf9, 0xa5, 0xda, 0xa3, 0x33, 0x97, 0x60, 0xc1, 0x84,
+	0xb6, 0xa0, 0xe6, 0xbc, 0xe0, 0x26, 0xe8, 0x9a, 0xe1, 0xf3, 0x15, 0x8f, 0xd1, 0x2d, 0x58, 0x35,
+	0xe6, 0xbb, 0xe8, 0x9a, 0xe1, 0x0b, 0x96, 0x26, 0xa2, 0xab, 0x50, 0xa5, 0xc4, 0x60, 0x8e, 0xad,
+	0x56, 0xc4, 0x74, 0x38, 0xe2, 0xef, 0xe2, 0x8c, 0x30, 0x66, 0x4c, 0x88, 0xba, 0x18, 0xbc, 0x8b,
+	0xe1, 0x10, 0x6d, 0xc0, 0xe2, 0xd8, 0xf1, 0x6d, 0x4f, 0x84, 0xe3, 0x45, 0x1c, 0x0c, 0xf8, 0x6a,
+	0x9e, 0x35, 0x23, 0xcc, 0x33, 0x66, 0x6e, 0xdf, 0xb0, 0x1d, 0x75, 0x69, 0x5b, 0xb9, 0x5b, 0xc6,
+	0x69, 0xa2, 0xa6, 0x43, 0x85, 0x6b, 0x8f, 0x96, 0x61, 0x09, 0x77, 0x9e, 0x1e, 0x75, 0x86, 0xa3,
+	0xe6, 0x02, 0x5a, 0x82, 0xb2, 0x7e, 0x78, 0xd8, 0x54, 0x50, 0x0d, 0x2a, 0xfd, 0xc1, 0x7e, 0xa7,
+	0x59, 0x42, 0x75, 0x58, 0x7c, 0x7a, 0xd4, 0x39, 0xea, 0x34, 0xcb, 0xa8, 0x01, 0xa0, 0x3f, 0x79,
+	0x32, 0xd8, 0xd3, 0x85, 0xed, 0x2a, 0xda, 0x10, 0x5a, 0x12, 0x77, 0xc8, 0xef, 0x56, 0x39, 0x7f,
+	0xb7, 0xa5, 0xe4, 0x6e, 0xb5, 0x47, 0xfc, 0xd4, 0x72, 0xf7, 0xeb, 0x72, 0x42, 0xb5, 0x67, 0xd0,
+	0xcc, 0xba, 0xfb, 0x25, 0xd5, 0xf9, 0x18, 0xea, 0xaf, 0x7d, 0xe2, 0x93, 0xbe, 0x31, 0x23, 0xa1,
+	0x46, 0x73, 0x82, 0xf6, 0x15, 0x6c, 0x16, 0x38, 0xfe, 0x7b, 0xee, 0x16, 0x03, 0xca, 0xbb, 0xfe,
+	0x7b, 0xca, 0xfc, 0x29, 0xac, 0x24, 0xa3, 0x0d, 0xe7, 0xe3, 0x01, 0x35, 0x16, 0x13, 0x8e, 0x0a,
+	0xf1, 0x77, 0x60, 0x25, 0x19, 0x72, 0x8a, 0xf0, 0x9a, 0x0d, 0xb5, 0x43, 0x6a, 0x39, 0xd4, 0xf2,
+	0xce, 0xd0, 0x1d, 0x58, 0x75, 0xc3, 0xef, 0x67, 0xc6, 0xd4, 0x0f, 0xee, 0xcc, 0xe2, 0xc1, 0x02,
+	0x4e, 0x93, 0x51, 0x1b, 0xd6, 0x23, 0xc2, 0xde, 0xd4, 0x60, 0x6c, 0x6e, 0xee, 0x83, 0x05, 0x9c,
+	0x9f, 0xda, 0x05, 0xa8, 0x45, 0x44, 0xed, 0xf7, 0x0a, 0xd4, 0xa2, 0xe4, 0x0e, 0x7d, 0x0e, 0x75,
+	0x1a, 0x7e, 0x47, 0x09, 0xdc, 0x3c, 0x44, 0x05, 0xf4, 0xf8, 0x83, 0x75, 0x6c, 0x8f, 0x9e, 0xe1,
+	0x39, 0x60, 0xab, 0x07, 0x8d, 0xf4, 0x24, 0x6a, 0x42, 0xf9, 0x15, 0x39, 0x0b, 0x77, 0xc8, 0x3f,
+	0xd1, 0x6d, 0x58, 0x3c, 0x15, 0x5b, 0x09, 0x12, 0xbe, 0xb5, 0x50, 0xfa, 0x53, 0xdf, 0xb0, 0x3d,
+	0xcb, 0x3b, 0xc3, 0xc1, 0xec, 0xc3, 0xd2, 0x8f, 0x15, 0x6d, 0x1b, 0x6a, 0x11, 0x99, 0xdf, 0xc9,
+	0xd3, 0xd8, 0x02, 0xe5, 0x90, 0x4b, 0xfb, 0x6d, 0x05, 0x56, 0x53, 0x2f, 0xa6, 0x38, 0xe3, 0x98,
+	0xf0, 0x65, 0xbc, 0x74, 0x9a, 0x98, 0xf7, 0x84, 0x92, 0xcc, 0x13, 0x6e, 0xc1, 0xaa, 0x6b, 0x50,
+	0xcf, 0xe2, 0x43, 0x61, 0xd1, 0x30, 0xbe, 0xa4, 0x88, 0xe8, 0x33, 0x58, 0x8e, 0x2c, 0xa0, 0xb3,
+	0x57, 0x6a, 0x25, 0xb5, 0xad, 0xc8, 0x1c, 0x38, 0xc9, 0x83, 0xee, 0x40, 0x63, 0x66, 0xbc, 0x4d,
+	0x26, 0x03, 0x8b, 0x22, 0xd2, 0x64, 0xa8, 0xe8, 0x93, 0xf9, 0x31, 0xa9, 0xd5, 0x94, 0xdc, 0xc8,
+	0x43, 0x70, 0xcc, 0x80, 0x76, 0xe1, 0x63, 0xf2, 0x96, 0x8c, 0x7d, 0x0e, 0x1d, 0x59, 0x33, 0xe2,
+	0xf8, 0x5e, 0xcf, 0x9a, 0x4e, 0xad, 0x21, 0x19, 0x3b, 0xb6, 0xc9, 0xc2, 0x70, 0x75, 0x2e, 0x0f,
+	0xda, 0x81, 0x8a, 0x67, 0x4c, 0xa2, 0x47, 0xf6, 0x86, 0x2c, 0x75, 0x6f, 0x8f, 0x8c, 0x49, 0x78,
+	0xf2, 0x82, 0x17, 0x3d, 0x81, 0x96, 0x3b, 0x35, 0xc6, 0x64, 0x46, 0x6c, 0x6f, 0xcf, 0xb1, 0x99,
+	0x47, 0x0d, 0xcb, 0xf6, 0xd4, 0xfa, 0xb6, 0x92, 0x78, 0xf0, 0x0f, 0xf3, 0x1c, 0x58, 0x06, 0xdb,
+	0xfa, 0x11, 0xd4, 0xe3, 0x05, 0x24, 0xde, 0xb3, 0x91, 0xf4, 0x9e, 0x7a, 0xd2, 0x59, 0xfe, 0x5d,
+	0x82, 0x2b, 0xd2, 0x8c, 0xf8, 0x43, 0x44, 0xaa, 0x4b, 0xba, 0xc2, 0x3d, 0x28, 0xfb, 0x13, 0x2b,
+	0x74, 0x81, 0x8f, 0xa2, 0xc4, 0x95, 0x11, 0x2a, 0xaa, 0x29, 0x5e, 0x41, 0xd0, 0x59, 0xa0, 0x1a,
+	0xe7, 0x43, 0x0f, 0x43, 0x6b, 0x07, 0x99, 0xdb, 0x9d, 0xf3, 0xd2, 0xfa, 0x9c, 0xd5, 0x2f, 0x3a,
+	0xed, 0xea, 0xc5, 0xa7, 0xfd, 0xed, 0x6d, 0x7d, 0x02, 0x6a, 0x51, 0xc5, 0x70, 0x49, 0x6b, 0xe7,
+	0xec, 0x59, 0x92, 0xd8, 0x53, 0xfb, 0xb5, 0x02, 0x57, 0x7b, 0xdf, 0xf9, 0x32, 0xe9, 0xa3, 0x2f,
+	0x67, 0x1f, 0xa9, 0x5d, 0xd8, 0x90, 0x1d, 0x21, 0xaf, 0xd9, 0x7c, 0x46, 0x68, 0x54, 0x6b, 0xf3,
+	0x6f, 0x1e, 0xd3, 0x27, 0x9c, 0x2f, 0xa8, 0x38, 0xea, 0x38, 0x1c, 0x69, 0x0c, 0x5a, 0x92, 0x1b,
+	0x80, 0xfa, 0xd0, 0x64, 0xd6, 0xcc, 0x9d, 0x92, 0x39, 0x4d, 0x88, 0x9b, 0x97, 0x1a, 0x43, 0x31,
+	0x2d, 0xc1, 0x1e, 0x2c, 0xe0, 0x1c, 0x76, 0x77, 0x05, 0x60, 0x1c, 0x8f, 0xb4, 0x7f, 0x2a, 0x70,
+	0xad, 0x10, 0x8f, 0x9e, 0xc1, 0x55, 0xfe, 0xe0, 0xe8, 0x27, 0x27, 0x96, 0xcd, 0xdf, 0x86, 0xac,
+	0x06, 0x37, 0x12, 0x85, 0x44, 0x9e, 0x89, 0xe1, 0x02, 0x34, 0x3a, 0x81, 0x8f, 0xe6, 0xb1, 0x36,
+	0x9a, 0xd7, 0x3d, 0x8f, 0x5a, 0x2f, 0x7c, 0x2f, 0x8a, 0xfa, 0xb7, 0xf2, 0x91, 0x45, 0xb2, 0xc4,
+	0x79, 0x82, 0xb4, 0x97, 0xb0, 0x59, 0xa0, 0x1a, 0xea, 0xc1, 0xba, 0x67, 0xd0, 0x09, 0xf1, 0x3a,
+	0x6f, 0x5d, 0x4a, 0x18, 0x4b, 0x94, 0x80, 0x51, 0xc1, 0x1b, 0xc1, 0x46, 0x19, 0x3e, 0x9c, 0x47,
+	0x6a, 0xff, 0x55, 0xe0, 0xfa, 0xb9, 0x8a, 0xf2, 0x9b, 0xc2, 0xc6, 0x4e, 0x98, 0xd2, 0xd6, 0x71,
+	0x30, 0x10, 0x6a, 0x50, 0xe3, 0xdb, 0xaa, 0x91, 0x45, 0x8a, 0x47, 0xc3, 0xb2, 0xf7, 0x0c, 0x6a,
+	0x5a, 0xb6, 0x31, 0xe5, 0x4f, 0x42, 0x39, 0x7c, 0x34, 0x52, 0xd4, 0xf0, 0x71, 0x49, 0xf2, 0x55,
+	0xe2, 0xc7, 0x25, 0xc9, 0xb7, 0xc5, 0x9b, 0x2f, 0xaf, 0x7d, 0x8b, 0x12, 0x53, 0x3c, 0x3f, 0x35,
+	0x1c, 0x8f, 0xb5, 0xff, 0x28, 0xa0, 0x16, 0xe9, 0x86, 0xbe, 0x80, 0x46, 0x60, 0xa4, 0x81, 0x4b,
+	0xa8, 0xe1, 0x39, 0x34, 0xf4, 0x98, 0x0b, 0x37, 0x95, 0x81, 0xf1, 0x7b, 0x17, 0x50, 0xf8, 0x3b,
+	0x1d, 0x86, 0xdc, 0x98, 0x80, 0x34, 0x58, 0x09, 0x06, 0x22, 0xc5, 0x09, 0x6a, 0xf0, 0x3a, 0x4e,
+	0xd1, 0xb4, 0xc7, 0x70, 0x35, 0xbd, 0x5a, 0x2c, 0xbb, 0x0a, 0xa5, 0x6e, 0x58, 0x88, 0xf4, 0x07,
+	0xa3, 0xe3, 0x6e, 0xbf, 0xa9, 0xf0, 0x9c, 0xbb, 0xf3, 0xf3, 0xee, 0x70, 0xd4, 0x2c, 0xa1, 0x55,
+	0xa8, 0x73, 0x72, 0x30, 0x2c, 0x6b, 0xff, 0x50, 0xe0, 0x5a, 0x61, 0xcb, 0x09, 0x0d, 0x61, 0x63,
+	0xee, 0x89, 0x6c, 0xe4, 0x84, 0xf3, 0x61, 0x7e, 0x74, 0xb3, 0xb8, 0x2b, 0x20, 0xe0, 0x58, 0x0a,
+	0x46, 0xbf, 0x80, 0x4d, 0x23, 0xf9, 0xae, 0x26, 0xe4, 0x06, 0x3e, 0xa2, 0x49, 0x1b, 0x67, 0x69,
+	0xd1, 0x45, 0x22, 0xb4, 0xdf, 0xf1, 0x03, 0x2c, 0x50, 0x28, 0x1f, 0x15, 0x15, 0x59, 0x54, 0xbc,
+	0x5c, 0x8e, 0xc4, 0xa3, 0xa0, 0x6f, 0x45, 0xa5, 0x97, 0xf8, 0x4e, 0x56, 0x56, 0x95, 0x54, 0x65,
+	0xa5, 0xfd, 0x49, 0x81, 0x8f, 0xce, 0xd9, 0xcf, 0x07, 0xd5, 0x2c, 0x95, 0x09, 0xf2, 0xa7, 0xad,
+	0x9c, 0xcd, 0x04, 0xf9, 0x23, 0x57, 0xac, 0xeb, 0x5f, 0x4a, 0xb0, 0x9c, 0xe8, 0x1e, 0x16, 0xe6,
+	0xfb, 0xbb, 0x00, 0x46, 0x14, 0x95, 0x58, 0xe6, 0xec, 0x12, 0xf8, 0x76, 0x1c, 0xba, 0xc2, 0x77,
+	0x3c, 0x81, 0x42, 0x3a, 0xb4, 0xd8, 0xbc, 0x31, 0x19, 0x25, 0x8d, 0x6a, 0x39, 0x95, 0xf3, 0x45,
+	0x64, 0x2c, 0xe3, 0x45, 0x7b, 0xd0, 0x22, 0x6f, 0x2d, 0xe6, 0x59, 0xf6, 0x24, 0xdf, 0x65, 0x92,
+	0x74, 0xbe, 0x64, 0xdc, 0x5b, 0x3f, 0x81, 0xb5, 0x8c, 0x9a, 0xef, 0x94, 0x17, 0xfc, 0xaf, 0x14,
+	0xb5, 0xf3, 0x2e, 0xb4, 0x5a, 0x47, 0x62, 0xb5, 0xdb, 0xd2, 0xa6, 0xeb, 0x77, 0x6d, 0xb8, 0x87,
+	0x71, 0xc3, 0xb1, 0x22, 0x1a, 0x12, 0x5a, 0x81, 0x16, 0x71, 0xcf, 0x03, 0xf7, 0xa2, 0x4e, 0xe3,
+	0xfb, 0xda, 0xab, 0xcb, 0x4b, 0xc2, 0xb9, 0x58, 0xde, 0x09, 0xd8, 0xc7, 0x7a, 0xb7, 0x7f, 0x2c,
+	0x9a, 0x04, 0x0b, 0x68, 0x0d, 0x96, 0xf7, 0x3b, 0x7b, 0x83, 0x5e, 0x77, 0x38, 0xe4, 0xad, 0x01,
+	0x05, 0xa9, 0xb0, 0x11, 0x30, 0x8c, 0x06, 0xc7, 0xc3, 0xbd, 0x83, 0xce, 0xfe, 0xd1, 0x13, 0x7d,
+	0xf7, 0x49, 0xa7, 0x59, 0xd2, 0x4c, 0x58, 0xcf, 0xb5, 0x9a, 0x51, 0x03, 0x4a, 0x56, 0x64, 0xf8,
+	0x92, 0x65, 0xa2, 0x9f, 0x01, 0x32, 0xc6, 0x9e, 0x6f, 0x4c, 0x8f, 0x18, 0x31, 0x63, 0x63, 0x95,
+	0xe4, 0xc6, 0x92, 0xb0, 0x6a, 0x7f, 0x2f, 0x03, 0xcc, 0xfd, 0xe5, 0x92, 0xc5, 0x56, 0x0f, 0x1a,
+	0x73, 0x02, 0x4f, 0x38, 0x33, 0xc7, 0x3d, 0x17, 0xd8, 0xd6, 0x53, 0x7c, 0xc1, 0x71, 0x67, 0xc0,
+	0xd2, 0x88, 0xf3, 0x08, 0x9a, 0x51, 0x7d, 0x75, 0x48, 0xa8, 0x90, 0xa2, 0x2e, 0xca, 0xb7, 0x95,
+	0x63, 0x7c, 0xb7, 0x2a, 0x2b, 0x95, 0x2b, 0x2e, 0x65, 0xcb, 0x84, 0xb9, 0xb7, 0xd7, 0x52, 0xde,
+	0x9e, 0x8b, 0x58, 0xf5, 0x4b, 0x65, 0xab, 0x20, 0x89, 0x7e, 0x5b, 0x3a, 0xb4, 0x24, 0x66, 0x7a,
+	0x27, 0xbf, 0xfb, 0x15, 0x5c, 0x91, 0xf6, 0x9b, 0x3f, 0x68, 0xf5, 0x3c, 0xef, 0x83, 0x94, 0x53,
+	0x7d, 0x90, 0xdf, 0x28, 0x80, 0xf2, 0xdd, 0x69, 0xf4, 0x15, 0xdc, 0xa0, 0x11, 0x85, 0x98, 0x43,
+	0xc9, 0xa5, 0x56, 0xe4, 0x07, 0x7a, 0x01, 0x2c, 0x19, 0xe1, 0x4b, 0xe9, 0x08, 0xff, 0xc7, 0x12,
+	0x5c, 0x2b, 0xec, 0xe5, 0xc7, 0x7e, 0xa6, 0x24, 0xfc, 0xec, 0x39, 0xac, 0x79, 0x84, 0xce, 0x2c,
+	0x3b, 0x30, 0xfe, 0x99, 0x1b, 0xc8, 0x6c, 0xec, 0x7c, 0x7a, 0xd1, 0xaf, 0x81, 0xf6, 0x28, 0x8d,
+	0xc3, 0x59, 0x41, 0x49, 0x3d, 0xcb, 0x29, 0x3d, 0x51, 0x1b, 0xd0, 0x84, 0x1a, 0xc2, 0x63, 0x2d,
+	0xc7, 0x8c, 0x2a, 0xbc, 0x8a, 0xa8, 0xf0, 0x24, 0x33, 0x5a, 0x0f, 0xd6, 0x32, 0xab, 0xa1, 0x75,
+	0x58, 0x1d, 0x8e, 0x06, 0x87, 0x87, 0x9d, 0xfd, 0xe3, 0xdd, 0xaf, 0x8f, 0x71, 0xaf, 0xb9, 0xc0,
+	0x3b, 0x94, 0xa3, 0x6e, 0xaf, 0x33, 0x38, 0x1a, 0x35, 0x15, 0xb4, 0x05, 0x57, 0x0f, 0x71, 0xa7,
+	0xd3, 0x3b, 0x1c, 0x05, 0x1c, 0x61, 0x70, 0xe9, 0xe0, 0x66, 0x49, 0xeb, 0x43, 0xe3, 0x90, 0x12,
+	0x93, 0x9f, 0x2c, 0x61, 0x3a, 0x9d, 0xb0, 0x4b, 0xba, 0xc9, 0xfc, 0x32, 0x94, 0x52, 0x0d, 0x2e,
+	0x93, 0x57, 0x8f, 0xc3, 0x33, 0x7b, 0x1c, 0x37, 0x8f, 0xf7, 0x8c, 0xf1, 0x4b, 0x22, 0x24, 0x1f,
+	0x00, 0x32, 0x18, 0xf3, 0x67, 0xe9, 0x5f, 0x25, 0x4a, 0xea, 0x97, 0x9a, 0x9e, 0x65, 0xc0, 0x12,
+	0x8c, 0xf6, 0x14, 0xd6, 0x73, 0x8c, 0xef, 0xa7, 0xf8, 0xce, 0x5f, 0x15, 0xa8, 0xc7, 0x3a, 0xa3,
+	0x6f, 0x60, 0xb3, 0xe0, 0xdf, 0x28, 0xba, 0x1d, 0xfb, 0xe8, 0x79, 0x7f, 0x6b, 0xb7, 0xee, 0x5c,
+	0xc4, 0x16, 0xfe, 0x62, 0x5d, 0x40, 0x8f, 0xa0, 0x1a, 0x3c, 0x47, 0x68, 0x23, 0xf3, 0x3b, 0x2c,
+	0x90, 0x74, 0x45, 0xfa, 0x93, 0x4c, 0x5b, 0xb8, 0xab, 0x7c, 0xaa, 0x3c, 0x7c, 0x04, 0x75, 0x66,
+	0x1d, 0x33, 0x32, 0xa6, 0xc4, 0x43, 0xd7, 0xdb, 0xc1, 0x6f, 0xe6, 0x76, 0xf4, 0x9b, 0xb9, 0xfd,
+	0xd8, 0x22, 0x53, 0x73, 0xe0, 0x06, 0x96, 0xfe, 0x43, 0x2d, 0xa8, 0x04, 0x18, 0x6f, 0x12, 0x50,
+	0xe2, 0xed, 0x56, 0x9e, 0x97, 0x98, 0xf5, 0xa2, 0x2a, 0xb8, 0x7f, 0xf8, 0xff, 0x01, 0x00, 0xdd,
+	0x9f, 0x0a, 0xbe, 0xd2, 0x1e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.